@@ -0,0 +1,366 @@
+package backup
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"playlist-manager/pkg/i18n"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+// ArchiveFormat is the format of a single-file backup archive
+type ArchiveFormat string
+
+const (
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+	ArchiveZip   ArchiveFormat = "zip"
+)
+
+// archiveManifestName is the name of the manifest inside the archives
+const archiveManifestName = "manifest.json"
+
+// ArchiveManifest describes the content of an archive, it is saved inside it as manifest.json
+type ArchiveManifest struct {
+	User      string             `json:"user"`
+	Date      string             `json:"date"`
+	Version   string             `json:"version"`
+	Created   time.Time          `json:"created"`
+	Playlists int                `json:"playlists"`
+	Tracks    int                `json:"tracks"`
+	Files     []ArchiveFileEntry `json:"files"`
+}
+
+// ArchiveFileEntry is a playlist saved in an archive, with the SHA-256 of its file and of its cover image, if present
+type ArchiveFileEntry struct {
+	Name        string `json:"name"`
+	ID          api.ID `json:"id"`
+	Playlist    string `json:"playlist"`
+	Personal    bool   `json:"personal"`
+	Tracks      int    `json:"tracks"`
+	SHA256      string `json:"sha256"`
+	Cover       string `json:"cover,omitempty"`
+	CoverSHA256 string `json:"cover_sha256,omitempty"`
+}
+
+// ArchiveFile is a playlist to save in an archive
+type ArchiveFile struct {
+	Playlist Playlist
+	Personal bool
+}
+
+/*
+ParseArchiveFormat converts a string (from the configuration or a CLI flag) to an ArchiveFormat, an empty string means no archive
+Returns the format and an error if the format is not supported
+*/
+func ParseArchiveFormat(s string) (ArchiveFormat, error) {
+	switch f := ArchiveFormat(strings.ToLower(strings.TrimPrefix(s, "."))); f {
+	case "", ArchiveTarGz, ArchiveZip:
+		return f, nil
+	case "tgz":
+		return ArchiveTarGz, nil
+	default:
+		return "", fmt.Errorf(i18n.T("archive.unsupported"), s)
+	}
+}
+
+// IsArchive returns true if the path has the extension of a supported archive, plain or encrypted
+func IsArchive(p string) bool {
+	p = strings.TrimSuffix(p, EncryptedExt)
+	return strings.HasSuffix(p, ".tar.gz") || strings.HasSuffix(p, ".tgz") || strings.HasSuffix(p, ".zip")
+}
+
+// ArchivePath returns the path of the archive of a user for a date (data/backup/<userID>/<date>.<format>)
+func ArchivePath(userID string, date string, f ArchiveFormat) string {
+	return filepath.Join(Dir, userID, date+"."+string(f))
+}
+
+/*
+Archives returns the paths of the archives found in the backup folder of a user, sorted by name
+Returns the paths and an error, if present
+*/
+func Archives(userID string) (paths []string, err error) {
+	entries, err := os.ReadDir(filepath.Join(Dir, userID))
+	if errors.Is(err, os.ErrNotExist) {
+		return paths, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() && IsArchive(e.Name()) {
+			paths = append(paths, filepath.Join(Dir, userID, e.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+/*
+WriteArchive saves the playlists in a single compressed archive (tar.gz or zip, based on the extension of the path)
+together with a manifest containing the user, the date, the version of the tool, the counts and the checksums of the files.
+The personal playlists are saved as <playlistID>.json, the ones of other users as altre/<playlistID>.json,
+the custom cover images (if loaded in CoverImage) next to them as <playlistID>.jpg.
+If the encryption is enabled the whole archive is encrypted and the .age extension is added to its path
+Returns the path of the archive, the manifest and an error, if present
+*/
+func WriteArchive(archivePath string, userID string, date string, version string, files []ArchiveFile) (written string, m ArchiveManifest, err error) {
+	m = ArchiveManifest{User: userID, Date: date, Version: version, Created: time.Now()}
+	contents := map[string][]byte{}
+	for _, f := range files {
+		data, err := json.Marshal(f.Playlist)
+		if err != nil {
+			return "", m, err
+		}
+		name := string(f.Playlist.ID) + ".json"
+		if !f.Personal {
+			name = OthersDir + "/" + name
+		}
+		contents[name] = data
+		entry := ArchiveFileEntry{
+			Name:     name,
+			ID:       f.Playlist.ID,
+			Playlist: f.Playlist.Name,
+			Personal: f.Personal,
+			Tracks:   len(f.Playlist.TrackIDs),
+			SHA256:   hashData(data),
+		}
+		if len(f.Playlist.CoverImage) > 0 {
+			entry.Cover = strings.TrimSuffix(name, ".json") + CoverExt
+			entry.CoverSHA256 = hashData(f.Playlist.CoverImage)
+			contents[entry.Cover] = f.Playlist.CoverImage
+		}
+		m.Files = append(m.Files, entry)
+		m.Playlists++
+		m.Tracks += len(f.Playlist.TrackIDs)
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Name < m.Files[j].Name })
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", m, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(archivePath), 0755)
+	if err != nil {
+		return "", m, err
+	}
+	var out bytes.Buffer
+	if strings.HasSuffix(archivePath, ".zip") {
+		zw := zip.NewWriter(&out)
+		err = writeZipFile(zw, archiveManifestName, manifest, m.Created)
+		for _, name := range archiveNames(m) {
+			if err != nil {
+				break
+			}
+			err = writeZipFile(zw, name, contents[name], m.Created)
+		}
+		if err != nil {
+			return "", m, err
+		}
+		err = zw.Close()
+	} else {
+		gw := gzip.NewWriter(&out)
+		tw := tar.NewWriter(gw)
+		err = writeTarFile(tw, archiveManifestName, manifest, m.Created)
+		for _, name := range archiveNames(m) {
+			if err != nil {
+				break
+			}
+			err = writeTarFile(tw, name, contents[name], m.Created)
+		}
+		if err != nil {
+			return "", m, err
+		}
+		err = tw.Close()
+		if err != nil {
+			return "", m, err
+		}
+		err = gw.Close()
+	}
+	if err != nil {
+		return "", m, err
+	}
+	written, err = writeFile(archivePath, out.Bytes())
+	return written, m, err
+}
+
+// archiveNames returns the names of the files listed in the manifest of an archive: every playlist followed by its cover, if present
+func archiveNames(m ArchiveManifest) (names []string) {
+	for _, e := range m.Files {
+		names = append(names, e.Name)
+		if e.Cover != "" {
+			names = append(names, e.Cover)
+		}
+	}
+	return names
+}
+
+// writeZipFile adds a file to a zip archive
+func writeZipFile(zw *zip.Writer, name string, data []byte, modified time.Time) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// writeTarFile adds a file to a tar archive
+func writeTarFile(tw *tar.Writer, name string, data []byte, modified time.Time) error {
+	err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modified, Typeflag: tar.TypeReg})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+/*
+ReadArchive reads an archive created by WriteArchive, decrypting it if it is encrypted, and checks the checksums of its files against the manifest.
+The paths of the returned files are in the form <archive>#<name>, the cover images are loaded in CoverImage
+Returns the manifest, the files (in the order of the manifest) and an error if the archive can't be read or its content doesn't match the manifest
+*/
+func ReadArchive(archivePath string) (m ArchiveManifest, files []File, err error) {
+	raw, err := readFile(archivePath)
+	if err != nil {
+		return m, nil, err
+	}
+	contents := map[string][]byte{}
+	if strings.HasSuffix(strings.TrimSuffix(archivePath, EncryptedExt), ".zip") {
+		zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+		if err != nil {
+			return m, nil, err
+		}
+		for _, f := range zr.File {
+			r, err := f.Open()
+			if err != nil {
+				return m, nil, err
+			}
+			data, err := io.ReadAll(r)
+			r.Close()
+			if err != nil {
+				return m, nil, err
+			}
+			contents[path.Clean(f.Name)] = data
+		}
+	} else {
+		gr, err := gzip.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return m, nil, err
+		}
+		tr := tar.NewReader(gr)
+		for {
+			h, err := tr.Next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return m, nil, err
+			}
+			if h.Typeflag != tar.TypeReg {
+				continue
+			}
+			var buf bytes.Buffer
+			_, err = io.Copy(&buf, tr)
+			if err != nil {
+				return m, nil, err
+			}
+			contents[path.Clean(h.Name)] = buf.Bytes()
+		}
+	}
+
+	manifest, ok := contents[archiveManifestName]
+	if !ok {
+		return m, nil, fmt.Errorf(i18n.T("archive.notFound"), archiveManifestName)
+	}
+	err = json.Unmarshal(manifest, &m)
+	if err != nil {
+		return m, nil, err
+	}
+
+	for _, e := range m.Files {
+		data, ok := contents[e.Name]
+		if !ok {
+			return m, nil, fmt.Errorf(i18n.T("archive.missingFile"), e.Name)
+		}
+		if hashData(data) != e.SHA256 {
+			return m, nil, fmt.Errorf(i18n.T("archive.badChecksum"), e.Name)
+		}
+		var p Playlist
+		err = json.Unmarshal(data, &p)
+		if err != nil {
+			return m, nil, fmt.Errorf("file %s: %w", e.Name, err)
+		}
+		if e.Cover != "" {
+			cover, ok := contents[e.Cover]
+			if !ok {
+				return m, nil, fmt.Errorf(i18n.T("archive.missingFile"), e.Cover)
+			}
+			if hashData(cover) != e.CoverSHA256 {
+				return m, nil, fmt.Errorf(i18n.T("archive.badChecksum"), e.Cover)
+			}
+			p.CoverImage = cover
+		}
+		files = append(files, File{Path: archivePath + "#" + e.Name, Playlist: p})
+	}
+	return m, files, nil
+}
+
+/*
+LatestFiles returns, for every playlist ID given, its newest backup of the user (from the date folders or the store) with its cover image,
+together with the information if it is a personal playlist. The playlists without backups are skipped
+Returns the files and an error, if present
+*/
+func LatestFiles(userID string, ids []api.ID) (files []ArchiveFile, err error) {
+	versions, err := Versions(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		v := versions[id]
+		if len(v) == 0 {
+			continue
+		}
+		p, err := Read(v[0].Path)
+		if err != nil {
+			return nil, err
+		}
+		p.CoverImage, err = ReadCover(File{Path: v[0].Path, Playlist: p})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, ArchiveFile{Playlist: p, Personal: v[0].Personal})
+	}
+	return files, nil
+}
+
+/*
+DateArchiveFiles returns the playlists of the user, personal and of other users, as they were saved on the given date
+(from the date folders and the manifest of the store) with their cover images, ready to be written to an archive
+Returns the files and an error, if present
+*/
+func DateArchiveFiles(userID string, date string) (files []ArchiveFile, err error) {
+	for _, personal := range []bool{true, false} {
+		dateFiles, err := DateFiles(userID, personal, date)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range dateFiles {
+			f.Playlist.CoverImage, err = ReadCover(f)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, ArchiveFile{Playlist: f.Playlist, Personal: personal})
+		}
+	}
+	return files, nil
+}
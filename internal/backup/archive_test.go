@@ -0,0 +1,80 @@
+package backup
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+func TestArchiveCovers(t *testing.T) {
+	withCover := Playlist{ID: "p1", Name: "Con copertina", TrackIDs: []api.ID{"t1"}}
+	withCover.SetCover([]byte("jpeg"))
+	without := Playlist{ID: "p2", Name: "Senza copertina", TrackIDs: []api.ID{"t2"}}
+	other := Playlist{ID: "p3", Name: "Di un altro utente", TrackIDs: []api.ID{}}
+	other.SetCover([]byte("other jpeg"))
+	files := []ArchiveFile{{Playlist: withCover, Personal: true}, {Playlist: without, Personal: true}, {Playlist: other}}
+
+	for _, f := range []ArchiveFormat{ArchiveTarGz, ArchiveZip} {
+		t.Run(string(f), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "2024-03-15."+string(f))
+			written, m, err := WriteArchive(path, "user", "2024-03-15", "test", files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.Files[0].Cover != "altre/p3"+CoverExt || m.Files[1].Cover != "p1"+CoverExt || m.Files[2].Cover != "" {
+				t.Errorf("covers in the manifest = %+v", m.Files)
+			}
+
+			_, read, err := ReadArchive(written)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := map[api.ID][]byte{"p1": []byte("jpeg"), "p2": nil, "p3": []byte("other jpeg")}
+			for _, rf := range read {
+				if !bytes.Equal(rf.Playlist.CoverImage, want[rf.Playlist.ID]) {
+					t.Errorf("cover of %s = %q, want %q", rf.Playlist.ID, rf.Playlist.CoverImage, want[rf.Playlist.ID])
+				}
+
+				// The cover can be read again from the archive also if it is not loaded
+				rf.Playlist.CoverImage = nil
+				cover, err := ReadCover(rf)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(cover, want[rf.Playlist.ID]) {
+					t.Errorf("ReadCover of %s = %q, want %q", rf.Path, cover, want[rf.Playlist.ID])
+				}
+			}
+		})
+	}
+}
+
+func TestCoverPath(t *testing.T) {
+	hash := hashData([]byte("jpeg"))
+	tests := []struct {
+		name string
+		f    File
+		want string
+	}{
+		{name: "no cover", f: File{Path: filepath.Join("data", "2024-03-15", "p1.json"), Playlist: Playlist{ID: "p1"}}, want: ""},
+		{name: "date folder", f: File{Path: filepath.Join("data", "2024-03-15", "p1.json"), Playlist: Playlist{ID: "p1", Cover: hash}},
+			want: filepath.Join("data", "2024-03-15", "p1"+CoverExt)},
+		{name: "archive", f: File{Path: "data/2024-03-15.zip#altre/p1.json", Playlist: Playlist{ID: "p1", Cover: hash}},
+			want: "data/2024-03-15.zip#altre/p1" + CoverExt},
+		{name: "store", f: File{Path: filepath.Join(Dir, "user", StoreDir, "objects", "ab", "ab.json"), Playlist: Playlist{ID: "p1", Cover: hash}},
+			want: filepath.Join(Dir, "user", StoreDir, "objects", hash[:2], hash+CoverExt)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CoverPath(tt.f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("CoverPath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
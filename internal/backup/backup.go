@@ -0,0 +1,386 @@
+// Contents: reading, writing and listing of the playlist backups stored in the data/backup folder
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"playlist-manager/pkg/i18n"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+const (
+	// Dir is the root folder of the backups, every user has its own subfolder
+	Dir = "data/backup"
+	// OthersDir is the subfolder (inside the user folder) containing the backups of the playlists owned by other users
+	OthersDir = "altre"
+)
+
+// Mode is the way the backups are saved
+type Mode string
+
+const (
+	// ModeFiles saves every backup as a JSON file in a date folder (data/backup/<userID>/<date>/<playlistID>.json)
+	ModeFiles Mode = "files"
+	// ModeStore saves the backups in the content-addressed store (data/backup/<userID>/store), see store.go
+	ModeStore Mode = "store"
+)
+
+var mode = ModeFiles
+
+// Init sets the way the backups are saved (files or store), if the mode is not valid the files are used
+func Init(m string) {
+	switch Mode(strings.ToLower(m)) {
+	case ModeFiles, "":
+		mode = ModeFiles
+	case ModeStore:
+		mode = ModeStore
+	default:
+		log.Warn(i18n.T("log.backupModeInvalid"), "mode", m)
+		mode = ModeFiles
+	}
+	log.Info(i18n.T("log.backupMode"), "mode", mode)
+}
+
+// Track struct used to store the details of a track in the backup files, so they can be read without the Spotify API
+type Track struct {
+	ID       api.ID   `json:"id"`
+	URI      api.URI  `json:"uri"`
+	Name     string   `json:"name"`
+	Artists  []string `json:"artists"`
+	Album    string   `json:"album"`
+	ISRC     string   `json:"isrc,omitempty"`
+	Duration int      `json:"duration_ms"`
+	AddedAt  string   `json:"added_at,omitempty"`
+	Type     ItemType `json:"type,omitempty"`
+	// Release date of the album (or of the episode): AAAA, AAAA-MM or AAAA-MM-GG depending on the precision known by Spotify
+	ReleaseDate string `json:"release_date,omitempty"`
+	Explicit    bool   `json:"explicit,omitempty"`
+	// ID of the user that added the track to the playlist, useful for the collaborative playlists (empty for the library and the older backups)
+	AddedBy string `json:"added_by,omitempty"`
+	// Popularity (from 0 to 100) when the track was read, it changes every day so it is not saved in the backups
+	Popularity int `json:"-"`
+}
+
+// ItemType is the type of an item of a playlist
+type ItemType string
+
+const (
+	// ItemTrack is a Spotify track, the only type stored in TrackIDs
+	ItemTrack ItemType = ""
+	// ItemEpisode is a podcast episode, it can be restored with its URI (spotify:episode:<id>)
+	ItemEpisode ItemType = "episode"
+	// ItemLocal is a local file added from the Spotify desktop app: only its details are saved, it can't be restored with the API
+	ItemLocal ItemType = "local"
+)
+
+// Playlist struct used to store the playlist data in json files to backup and restore them
+type Playlist struct {
+	ID       api.ID   `json:"id"`
+	Name     string   `json:"name"`
+	TrackIDs []api.ID `json:"tracks"`
+	// Details of the tracks, missing in the older backups that only stored the IDs
+	Items []Track `json:"items,omitempty"`
+	// Kind of the backup, empty for the playlists (see library.go)
+	Kind        Kind   `json:"kind,omitempty"`
+	Description string `json:"description,omitempty"`
+	// SHA-256 of the custom cover image, saved next to the backup (see cover.go), empty if the playlist has no custom cover
+	Cover string `json:"cover,omitempty"`
+	// Content of the cover image to save, it is not part of the JSON
+	CoverImage []byte `json:"-"`
+	// Number of items not available on Spotify when the backup was made, so not saved
+	Unavailable int `json:"unavailable,omitempty"`
+}
+
+// File is a backup file found on disk, with its path and the parsed playlist
+type File struct {
+	Path     string
+	Playlist Playlist
+}
+
+// String returns the track formatted as "Name di Artist1, Artist2"
+func (t Track) String() string {
+	if len(t.Artists) == 0 {
+		return t.Name
+	}
+	return t.Name + i18n.T("track.by") + strings.Join(t.Artists, ", ")
+}
+
+/*
+Tracks returns the details of the tracks of the playlist.
+For the backups without details only the ID is filled, so they can still be listed and searched by ID
+*/
+func (p Playlist) Tracks() []Track {
+	if len(p.Items) > 0 {
+		return p.Items
+	}
+	tracks := make([]Track, 0, len(p.TrackIDs))
+	for _, id := range p.TrackIDs {
+		tracks = append(tracks, Track{ID: id, URI: api.URI("spotify:track:" + string(id)), Name: string(id)})
+	}
+	return tracks
+}
+
+// Restorable returns true if the item can be added again to a playlist with the API
+func (t Track) Restorable() bool {
+	return t.Type != ItemLocal && t.URI != ""
+}
+
+// YearOf returns the year of a release date (AAAA, AAAA-MM or AAAA-MM-GG, see Track.ReleaseDate), empty if the date is not known
+func YearOf(date string) string {
+	if len(date) < 4 {
+		return ""
+	}
+	return date[:4]
+}
+
+/*
+RestorableItems divides the items of the playlist in the ones that can be added to a playlist (tracks and episodes), in their order,
+and the ones that can't be restored (local files)
+Returns the restorable and the skipped items
+*/
+func (p Playlist) RestorableItems() (items []Track, skipped []Track) {
+	for _, t := range p.Tracks() {
+		if t.Restorable() {
+			items = append(items, t)
+		} else {
+			skipped = append(skipped, t)
+		}
+	}
+	return items, skipped
+}
+
+// UserDir returns the backup folder of a user, for its personal playlists or for the ones of other users
+func UserDir(userID string, personal bool) string {
+	if personal {
+		return filepath.Join(Dir, userID)
+	}
+	return filepath.Join(Dir, userID, OthersDir)
+}
+
+/*
+Users returns the IDs of the users that have at least a backup folder in data/backup
+Returns the user IDs and an error, if present
+*/
+func Users() (users []string, err error) {
+	entries, err := os.ReadDir(Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return users, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			users = append(users, e.Name())
+		}
+	}
+	return users, nil
+}
+
+/*
+Dates returns the names of the date folders inside a backup folder (see UserDir), sorted from the oldest to the newest.
+The folder of the playlists of other users and the one of the store are skipped
+Returns the dates and an error, if present
+*/
+func Dates(dir string) (dates []string, err error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return dates, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != OthersDir && e.Name() != StoreDir {
+			dates = append(dates, e.Name())
+		}
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
+/*
+UserDates returns the dates of the backups of a user, both the date folders and the manifests of the store,
+only for the personal playlists or the ones of other users, sorted from the oldest to the newest
+Returns the dates and an error, if present
+*/
+func UserDates(userID string, personal bool) (dates []string, err error) {
+	dates, err = Dates(UserDir(userID, personal))
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]bool{}
+	for _, d := range dates {
+		found[d] = true
+	}
+
+	manifestDates, err := ManifestDates(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range manifestDates {
+		if found[d] {
+			continue
+		}
+		m, err := ReadManifest(userID, d)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range m.Entries {
+			if e.Personal == personal {
+				dates = append(dates, d)
+				found[d] = true
+				break
+			}
+		}
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
+/*
+DateFiles returns the backups of a user saved in a date, from the date folder and from the manifest of the store,
+only for the personal playlists or the ones of other users
+Returns the files and an error, if present
+*/
+func DateFiles(userID string, personal bool, date string) (files []File, err error) {
+	dir := filepath.Join(UserDir(userID, personal), date)
+	if _, err := os.Stat(dir); err == nil {
+		files, err = List(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	found := map[api.ID]bool{}
+	for _, f := range files {
+		found[f.Playlist.ID] = true
+	}
+
+	stored, err := storeFiles(userID, personal, date)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range stored {
+		if !found[f.Playlist.ID] {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+/*
+Read reads and parses the backup file at the given path, decrypting it if it is encrypted (see InitEncryption)
+Returns the playlist and an error, if present
+*/
+func Read(path string) (p Playlist, err error) {
+	data, err := readFile(path)
+	if err != nil {
+		return p, err
+	}
+	err = json.Unmarshal(data, &p)
+	return p, err
+}
+
+/*
+List returns the backup files (JSON, plain or encrypted) found in a date folder. Files that can't be read or parsed are skipped and logged
+Returns the files and an error, if present
+*/
+func List(dir string) (files []File, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !isJSON(e.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		p, err := Read(path)
+		if err != nil {
+			log.Warn(i18n.T("log.backupFileReadError"), "file", path, "error", err)
+			continue
+		}
+		files = append(files, File{Path: path, Playlist: p})
+	}
+	return files, nil
+}
+
+// Location returns the folder where the backups of the user made on the date of now are saved, in the way set with Init
+func Location(userID string, personal bool, now time.Time) string {
+	if mode == ModeStore {
+		return filepath.ToSlash(storeDir(userID))
+	}
+	return filepath.ToSlash(filepath.Join(UserDir(userID, personal), now.Format(DateLayout)))
+}
+
+/*
+Save saves the backup of a playlist of the user made on the date of now, in the way set with Init.
+If the playlist is the same as its latest backup unchanged is true: with the files it is not saved again and location is the folder of the latest backup,
+with the store it is only added to the manifest of the date
+Returns the location of the backup, if the playlist is unchanged and an error, if present
+*/
+func Save(userID string, personal bool, p Playlist, now time.Time) (location string, unchanged bool, err error) {
+	today := now.Format(DateLayout)
+	if mode == ModeStore {
+		_, unchanged, err = StorePut(userID, personal, p, today)
+		return Location(userID, personal, now), unchanged, err
+	}
+
+	//Skip the playlist if it didn't change since the latest backup
+	userDir := UserDir(userID, personal)
+	latest, found, err := Latest(userDir, p.ID)
+	if err != nil {
+		return "", false, err
+	}
+	if found && Equal(latest.Playlist, p) {
+		return filepath.ToSlash(filepath.Dir(latest.Path)), true, nil
+	}
+
+	location = Location(userID, personal, now)
+	_, err = Write(location, p)
+	if err != nil {
+		return "", false, err
+	}
+	return location, false, nil
+}
+
+/*
+SaveRemoved records the personal playlists of the user deleted on Spotify, given all the current ones, in the backup made on the date of now.
+Only the store needs it (see StoreRemoved): with the files a deleted playlist simply has no newer backups
+Returns the IDs of the playlists recorded as removed and an error, if present
+*/
+func SaveRemoved(userID string, current []api.ID, now time.Time) (removed []api.ID, err error) {
+	if mode != ModeStore {
+		return nil, nil
+	}
+	return StoreRemoved(userID, current, now.Format(DateLayout))
+}
+
+/*
+Write saves the playlist as JSON in the given folder, creating it if needed. The file is named after the playlist ID,
+with the .age extension added if the encryption is enabled. The cover image, if present, is saved next to it (<playlistID>.jpg)
+Returns the path of the file and an error, if present
+*/
+func Write(dir string, p Playlist) (path string, err error) {
+	jsonData, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+	if len(p.CoverImage) > 0 {
+		_, err = writeFile(filepath.Join(dir, string(p.ID)+CoverExt), p.CoverImage)
+		if err != nil {
+			return "", err
+		}
+	}
+	return writeFile(filepath.Join(dir, string(p.ID)+".json"), jsonData)
+}
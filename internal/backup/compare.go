@@ -0,0 +1,131 @@
+package backup
+
+/*
+Difference returns the elements of a that are not in b, in the order of a (the repeated elements of a are kept).
+It is used by the linked playlists to find the tracks to add and to remove
+*/
+func Difference[T comparable](a, b []T) []T {
+	in := make(map[T]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	var res []T
+	for _, v := range a {
+		if !in[v] {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// Intersection returns the distinct elements of a that are also in b, in the order of a
+func Intersection[T comparable](a, b []T) []T {
+	in := make(map[T]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	var res []T
+	for _, v := range Distinct(a) {
+		if in[v] {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// Distinct returns the elements of s without the repeated ones, keeping the first occurrence of each
+func Distinct[T comparable](s []T) []T {
+	seen := make(map[T]bool, len(s))
+	var res []T
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// Jaccard returns the Jaccard similarity of the elements of a and b: the size of the intersection divided by the size of the union (0 if both are empty)
+func Jaccard[T comparable](a, b []T) float64 {
+	common := len(Intersection(a, b))
+	union := len(Distinct(a)) + len(Distinct(b)) - common
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
+
+// Comparison is the result of the comparison of two or more playlists
+type Comparison struct {
+	Names []string
+	// Distinct tracks in all the playlists, in the order of the first one
+	Common []Track
+	// Unique[i] are the distinct tracks only in the playlist i
+	Unique [][]Track
+	// Similarity[i][j] is the Jaccard similarity of the playlists i and j
+	Similarity [][]float64
+	// Artists in all the playlists, in the order of the first one
+	SharedArtists []string
+}
+
+/*
+Compare compares two or more playlists, the tracks are compared by URI (see Track.Key)
+Returns the comparison
+*/
+func Compare(playlists []Playlist) Comparison {
+	c := Comparison{
+		Names:      make([]string, len(playlists)),
+		Unique:     make([][]Track, len(playlists)),
+		Similarity: make([][]float64, len(playlists)),
+	}
+	keys := make([][]string, len(playlists))
+	artists := make([][]string, len(playlists))
+	byKey := map[string]Track{}
+	for i, p := range playlists {
+		c.Names[i] = p.Name
+		for _, t := range p.Tracks() {
+			keys[i] = append(keys[i], t.Key())
+			artists[i] = append(artists[i], t.Artists...)
+			if _, ok := byKey[t.Key()]; !ok {
+				byKey[t.Key()] = t
+			}
+		}
+	}
+	if len(playlists) == 0 {
+		return c
+	}
+
+	common := Distinct(keys[0])
+	sharedArtists := Distinct(artists[0])
+	for i := 1; i < len(playlists); i++ {
+		common = Intersection(common, keys[i])
+		sharedArtists = Intersection(sharedArtists, artists[i])
+	}
+	c.Common = tracksOf(common, byKey)
+	c.SharedArtists = sharedArtists
+
+	for i := range playlists {
+		var others []string
+		for j := range playlists {
+			if j != i {
+				others = append(others, keys[j]...)
+			}
+		}
+		c.Unique[i] = tracksOf(Distinct(Difference(keys[i], others)), byKey)
+		c.Similarity[i] = make([]float64, len(playlists))
+		for j := range playlists {
+			c.Similarity[i][j] = Jaccard(keys[i], keys[j])
+		}
+	}
+	return c
+}
+
+// tracksOf returns the tracks with the given keys
+func tracksOf(keys []string, byKey map[string]Track) []Track {
+	tracks := make([]Track, 0, len(keys))
+	for _, k := range keys {
+		tracks = append(tracks, byKey[k])
+	}
+	return tracks
+}
@@ -0,0 +1,161 @@
+package backup
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{a: "abc", b: "b", want: "ac"},
+		{a: "abca", b: "c", want: "aba"},
+		{a: "abc", b: "", want: "abc"},
+		{a: "", b: "abc", want: ""},
+		{a: "abc", b: "cba", want: ""},
+		{a: "abc", b: "xyz", want: "abc"},
+	}
+	for _, tt := range tests {
+		got := strings.Join(Difference(strings.Split(tt.a, ""), strings.Split(tt.b, "")), "")
+		if got != tt.want {
+			t.Errorf("Difference(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{a: "abc", b: "cb", want: "bc"},
+		{a: "abab", b: "ba", want: "ab"},
+		{a: "abc", b: "", want: ""},
+		{a: "abc", b: "xyz", want: ""},
+		{a: "aa", b: "aa", want: "a"},
+	}
+	for _, tt := range tests {
+		got := strings.Join(Intersection(strings.Split(tt.a, ""), strings.Split(tt.b, "")), "")
+		if got != tt.want {
+			t.Errorf("Intersection(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{s: "", want: ""},
+		{s: "abc", want: "abc"},
+		{s: "abacbc", want: "abc"},
+		{s: "bba", want: "ba"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(Distinct(strings.Split(tt.s, "")), ""); got != tt.want {
+			t.Errorf("Distinct(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "", b: "", want: 0},
+		{a: "abc", b: "", want: 0},
+		{a: "abc", b: "abc", want: 1},
+		{a: "abc", b: "cba", want: 1},
+		{a: "ab", b: "bc", want: 1.0 / 3},
+		{a: "aab", b: "bc", want: 1.0 / 3},
+		{a: "abcd", b: "ab", want: 0.5},
+		{a: "ab", b: "cd", want: 0},
+	}
+	for _, tt := range tests {
+		if got := Jaccard(strings.Split(tt.a, ""), strings.Split(tt.b, "")); got != tt.want {
+			t.Errorf("Jaccard(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// trackIDs returns the IDs of the tracks, in order
+func trackIDs(tracks []Track) string {
+	var s string
+	for _, t := range tracks {
+		s += string(t.ID)
+	}
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name       string
+		playlists  []string
+		common     string
+		unique     []string
+		similarity [][]float64
+	}{
+		{name: "two playlists", playlists: []string{"abcc", "cbd"}, common: "bc", unique: []string{"a", "d"},
+			similarity: [][]float64{{1, 0.5}, {0.5, 1}}},
+		{name: "three playlists", playlists: []string{"abc", "bcd", "cx"}, common: "c", unique: []string{"a", "d", "x"},
+			similarity: [][]float64{{1, 0.5, 0.25}, {0.5, 1, 0.25}, {0.25, 0.25, 1}}},
+		{name: "nothing in common", playlists: []string{"ab", "cd"}, common: "", unique: []string{"ab", "cd"},
+			similarity: [][]float64{{1, 0}, {0, 1}}},
+		{name: "empty playlist", playlists: []string{"ab", ""}, common: "", unique: []string{"ab", ""},
+			similarity: [][]float64{{1, 0}, {0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var playlists []Playlist
+			for i, ids := range tt.playlists {
+				p := playlistOf(ids)
+				p.Name = string(rune('A' + i))
+				playlists = append(playlists, p)
+			}
+			c := Compare(playlists)
+			if got := trackIDs(c.Common); got != tt.common {
+				t.Errorf("common = %q, want %q", got, tt.common)
+			}
+			var unique []string
+			for _, u := range c.Unique {
+				unique = append(unique, trackIDs(u))
+			}
+			if !reflect.DeepEqual(unique, tt.unique) {
+				t.Errorf("unique = %q, want %q", unique, tt.unique)
+			}
+			if !reflect.DeepEqual(c.Similarity, tt.similarity) {
+				t.Errorf("similarity = %v, want %v", c.Similarity, tt.similarity)
+			}
+			if len(c.Names) != len(playlists) || c.Names[0] != "A" {
+				t.Errorf("names = %v", c.Names)
+			}
+		})
+	}
+
+	if c := Compare(nil); len(c.Names) != 0 || c.Common != nil {
+		t.Errorf("Compare(nil) = %+v", c)
+	}
+}
+
+func TestCompareSharedArtists(t *testing.T) {
+	a := Playlist{Name: "A", Items: []Track{
+		{URI: "spotify:track:1", Artists: []string{"Queen", "David Bowie"}},
+		{URI: "spotify:track:2", Artists: []string{"Muse"}},
+		{URI: "spotify:track:3", Artists: []string{"Queen"}},
+	}}
+	b := Playlist{Name: "B", Items: []Track{
+		{URI: "spotify:track:4", Artists: []string{"Muse"}},
+		{URI: "spotify:track:5", Artists: []string{"David Bowie"}},
+	}}
+	c := Compare([]Playlist{a, b})
+	if want := []string{"David Bowie", "Muse"}; !reflect.DeepEqual(c.SharedArtists, want) {
+		t.Errorf("shared artists = %v, want %v", c.SharedArtists, want)
+	}
+	if len(c.Common) != 0 {
+		t.Errorf("common = %v, want none", c.Common)
+	}
+}
@@ -0,0 +1,114 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Custom cover images of the playlists: with the files they are saved next to the JSON (<date>/<playlistID>.jpg),
+in the store as objects named after their SHA-256 (store/objects/<hh>/<hash>.jpg) referenced by the manifests.
+The Cover field of the playlist contains the hash, so a backup with a different cover is a different state of the playlist
+*/
+
+// CoverExt is the extension of the cover images, Spotify only accepts JPEG images
+const CoverExt = ".jpg"
+
+// SetCover sets the cover image of the playlist and its hash, an empty image removes the cover
+func (p *Playlist) SetCover(image []byte) {
+	p.CoverImage = image
+	p.Cover = ""
+	if len(image) > 0 {
+		p.Cover = hashData(image)
+	}
+}
+
+/*
+coverObjectPath returns the path of a cover image in the store of a user
+Returns the path and an error, if the hash is not a valid SHA-256
+*/
+func coverObjectPath(userID string, hash string) (string, error) {
+	err := checkHash(hash)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(storeDir(userID), "objects", hash[:2], hash+CoverExt), nil
+}
+
+/*
+CoverPath returns the path of the cover image of a backup file: next to the JSON for the date folders and the archives
+(<archive>#<playlistID>.jpg, see WriteArchive), among the objects for the store
+Returns the path (empty if the playlist has no custom cover) and an error, if the hash of the cover is not valid
+*/
+func CoverPath(f File) (string, error) {
+	if f.Playlist.Cover == "" {
+		return "", nil
+	}
+	if strings.Contains(f.Path, "#") {
+		return strings.TrimSuffix(f.Path, ".json") + CoverExt, nil
+	}
+	dir := filepath.Dir(f.Path)
+	// Store object: store/objects/<hh>/<hash>.json
+	if filepath.Base(filepath.Dir(dir)) == "objects" && filepath.Base(filepath.Dir(filepath.Dir(dir))) == StoreDir {
+		err := checkHash(f.Playlist.Cover)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(filepath.Dir(dir), f.Playlist.Cover[:2], f.Playlist.Cover+CoverExt), nil
+	}
+	return filepath.Join(dir, string(f.Playlist.ID)+CoverExt), nil
+}
+
+/*
+ReadCover reads the cover image of a backup file, decrypting it if needed (see CoverPath).
+The image already loaded (for example by ReadArchive) is returned without reading it again
+Returns the image, nil if the playlist has no custom cover, and an error, if present
+*/
+func ReadCover(f File) ([]byte, error) {
+	if len(f.Playlist.CoverImage) > 0 {
+		return f.Playlist.CoverImage, nil
+	}
+	path, err := CoverPath(f)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	if archivePath, name, ok := strings.Cut(path, "#"); ok {
+		return readArchiveCover(archivePath, name)
+	}
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+/*
+readArchiveCover reads the cover image with the given name from an archive
+Returns the image, nil if the archive doesn't contain it, and an error, if present
+*/
+func readArchiveCover(archivePath string, name string) ([]byte, error) {
+	_, files, err := ReadArchive(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if strings.TrimSuffix(f.Path, ".json")+CoverExt == archivePath+"#"+name {
+			return f.Playlist.CoverImage, nil
+		}
+	}
+	return nil, nil
+}
+
+// removeCover removes the cover image saved next to a backup file in a date folder, if present
+func removeCover(backupPath string) error {
+	name := trimExt(filepath.Base(backupPath), ".json")
+	path := filepath.Join(filepath.Dir(backupPath), name+CoverExt)
+	for _, p := range []string{path, path + EncryptedExt} {
+		err := os.Remove(p)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
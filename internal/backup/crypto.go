@@ -0,0 +1,334 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"playlist-manager/pkg/i18n"
+	"strings"
+
+	"filippo.io/age"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+Optional encryption of the backups with age (https://age-encryption.org), so they can also be decrypted with the age CLI.
+The encrypted files have the .age extension added (<playlistID>.json.age, <date>.tar.gz.age), the encryption is authenticated:
+a file changed after the encryption can't be decrypted, so tampering is always detected when reading it.
+With a passphrase the files are encrypted with an age key saved in data/backup/key.age, itself encrypted with the passphrase:
+the slow derivation from the passphrase (scrypt, with the work factor of age) is made once and not for every file
+*/
+
+// EncryptedExt is the extension added to the encrypted backup files
+const EncryptedExt = ".age"
+
+// KeyFile is the name of the file, in the backup folder, with the key of the backups encrypted with the passphrase (see passphraseKey)
+const KeyFile = "key.age"
+
+var (
+	recipients []age.Recipient
+	identities []age.Identity
+)
+
+// Encrypted returns true if the new backups are encrypted
+func Encrypted() bool {
+	return len(recipients) > 0
+}
+
+/*
+InitEncryption sets the keys used to encrypt and decrypt the backups:
+  - passphrase: the backups are encrypted with a key derived from the passphrase (scrypt) and it is used to decrypt them
+  - publicKeys: comma separated age public keys (age1...), the backups are encrypted for all of them; it takes precedence over the passphrase for encryption
+  - identityFile: file containing the age private keys (AGE-SECRET-KEY-1...) used to decrypt the backups encrypted with the public keys
+
+If all the parameters are empty the backups are not encrypted
+Returns an error, if present
+*/
+func InitEncryption(passphrase string, publicKeys string, identityFile string) error {
+	recipients, identities = nil, nil
+
+	var key *age.X25519Identity
+	if passphrase != "" {
+		// The backups encrypted directly with the passphrase by the previous versions can still be read
+		id, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return err
+		}
+		key, err = passphraseKey(passphrase)
+		if err != nil {
+			return err
+		}
+		identities = append(identities, key, id)
+	}
+	if identityFile != "" {
+		f, err := os.Open(identityFile)
+		if err != nil {
+			return fmt.Errorf(i18n.T("crypto.openIdentity"), err)
+		}
+		defer f.Close()
+		ids, err := age.ParseIdentities(f)
+		if err != nil {
+			return fmt.Errorf(i18n.T("crypto.readIdentity"), err)
+		}
+		identities = append(identities, ids...)
+	}
+
+	if publicKeys != "" {
+		for _, k := range strings.Split(publicKeys, ",") {
+			r, err := age.ParseX25519Recipient(strings.TrimSpace(k))
+			if err != nil {
+				return fmt.Errorf(i18n.T("crypto.invalidRecipient"), k, err)
+			}
+			recipients = append(recipients, r)
+		}
+	} else if key != nil {
+		recipients = append(recipients, key.Recipient())
+	}
+
+	log.Info(i18n.T("log.encryption"), "encrypted", Encrypted(), "recipients", len(recipients), "identities", len(identities))
+	return nil
+}
+
+/*
+passphraseKey reads the key used to encrypt the backups with a passphrase from data/backup/key.age, decrypting it with the passphrase.
+The first time the key is generated and saved, encrypted with the passphrase; the file is needed to read the backups, together with the passphrase
+Returns the key and an error, if present
+*/
+func passphraseKey(passphrase string) (*age.X25519Identity, error) {
+	path := filepath.Join(Dir, KeyFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return newPassphraseKey(path, passphrase)
+	} else if err != nil {
+		return nil, fmt.Errorf(i18n.T("crypto.readKey"), path, err)
+	}
+	id, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), id)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("crypto.wrongPassphrase"), path, err)
+	}
+	privateKey, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("crypto.readKey"), path, err)
+	}
+	return age.ParseX25519Identity(strings.TrimSpace(string(privateKey)))
+}
+
+/*
+newPassphraseKey generates the key of the backups and saves it in path, encrypted with the passphrase
+Returns the key and an error, if present
+*/
+func newPassphraseKey(path string, passphrase string) (*age.X25519Identity, error) {
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		return nil, err
+	}
+	r, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, r)
+	if err != nil {
+		return nil, err
+	}
+	_, err = io.WriteString(w, key.String()+"\n")
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(path, buf.Bytes(), 0600)
+	if err != nil {
+		return nil, err
+	}
+	log.Info(i18n.T("log.backupKeyCreated"), "file", path)
+	return key, nil
+}
+
+/*
+GenerateKey generates a new age key pair
+Returns the private key (to save in the identity file), the public key and an error, if present
+*/
+func GenerateKey() (privateKey string, publicKey string, err error) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		return "", "", err
+	}
+	return id.String(), id.Recipient().String(), nil
+}
+
+// encrypt encrypts the data for the recipients set with InitEncryption
+func encrypt(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	_, err = w.Write(data)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decrypt decrypts the data with the identities set with InitEncryption, it fails if the data has been changed after the encryption
+func decrypt(data []byte) ([]byte, error) {
+	if len(identities) == 0 {
+		return nil, errors.New(i18n.T("crypto.noKey"))
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("crypto.decryptError"), err)
+	}
+	data, err = io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("crypto.altered"), err)
+	}
+	return data, nil
+}
+
+/*
+readFile reads a backup file, decrypting it if it has the .age extension.
+If the file doesn't exist the encrypted version (path + .age) is read
+Returns the content and an error, if present
+*/
+func readFile(path string) ([]byte, error) {
+	if !strings.HasSuffix(path, EncryptedExt) {
+		data, err := os.ReadFile(path)
+		if !errors.Is(err, os.ErrNotExist) {
+			return data, err
+		}
+		path += EncryptedExt
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(data)
+}
+
+/*
+writeFile writes a backup file, encrypted (adding the .age extension to the path) if the encryption is enabled.
+The other version of the file (plain or encrypted) is removed, so there is only one copy of it
+Returns the path of the written file and an error, if present
+*/
+func writeFile(path string, data []byte) (string, error) {
+	other := path + EncryptedExt
+	if Encrypted() {
+		var err error
+		data, err = encrypt(data)
+		if err != nil {
+			return "", err
+		}
+		path, other = other, path
+	}
+	err := os.WriteFile(path, data, 0644)
+	if err != nil {
+		return "", err
+	}
+	err = os.Remove(other)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	return path, nil
+}
+
+// exists returns true if the file exists, plain or encrypted
+func exists(path string) bool {
+	if _, err := os.Stat(path); err == nil {
+		return true
+	}
+	_, err := os.Stat(path + EncryptedExt)
+	return err == nil
+}
+
+// trimExt removes the .age extension, if present, and then the given extension from a file name
+func trimExt(name string, ext string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(name, EncryptedExt), ext)
+}
+
+// isJSON returns true if the file name is a JSON backup file, plain or encrypted
+func isJSON(name string) bool {
+	return strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json"+EncryptedExt)
+}
+
+// IntegrityResult contains the problems found by VerifyAll
+type IntegrityResult struct {
+	Files    int      // Backup files checked, the objects of the store are counted in Store
+	Plain    int      // Files not encrypted: only their JSON is read, a change can't be detected
+	Failed   []string // Files that can't be read, decrypted or whose checksums don't match, as "<path>: <error>"
+	Store    VerifyResult
+	Archives int
+}
+
+// OK returns true if no problem has been found
+func (r IntegrityResult) OK() bool {
+	return len(r.Failed) == 0 && r.Store.OK()
+}
+
+/*
+VerifyAll checks the integrity of all the backups of a user: every file of the date folders and every archive must be readable
+(and decryptable, if encrypted) and the archives must match their manifest; the store is checked with Verify.
+The files of the date folders have no checksum: only the encrypted ones are really verified, of the others only the JSON is read
+Returns the result and an error, if present
+*/
+func VerifyAll(userID string) (res IntegrityResult, err error) {
+	for _, personal := range []bool{true, false} {
+		dir := UserDir(userID, personal)
+		dates, err := Dates(dir)
+		if err != nil {
+			return res, err
+		}
+		for _, d := range dates {
+			entries, err := os.ReadDir(filepath.Join(dir, d))
+			if err != nil {
+				return res, err
+			}
+			for _, e := range entries {
+				if e.IsDir() || !isJSON(e.Name()) {
+					continue
+				}
+				res.Files++
+				if !strings.HasSuffix(e.Name(), EncryptedExt) {
+					res.Plain++
+				}
+				path := filepath.Join(dir, d, e.Name())
+				if _, err := Read(path); err != nil {
+					res.Failed = append(res.Failed, path+": "+err.Error())
+				}
+			}
+		}
+	}
+
+	archives, err := Archives(userID)
+	if err != nil {
+		return res, err
+	}
+	for _, a := range archives {
+		res.Archives++
+		if _, _, err := ReadArchive(a); err != nil {
+			res.Failed = append(res.Failed, a+": "+err.Error())
+		}
+	}
+
+	res.Store, err = Verify(userID)
+	return res, err
+}
@@ -0,0 +1,58 @@
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestPassphraseEncryption(t *testing.T) {
+	inTempDir(t)
+	t.Cleanup(func() { InitEncryption("", "", "") })
+
+	err := InitEncryption("segreto", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(Dir, KeyFile)); err != nil {
+		t.Fatalf("the key of the backups has not been saved: %v", err)
+	}
+	path, err := writeFile(filepath.Join(Dir, "p1.json"), []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The key is read again from its file, with the same passphrase
+	err = InitEncryption("segreto", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := readFile(path)
+	if err != nil || !bytes.Equal(data, []byte("{}")) {
+		t.Errorf("readFile = %q, %v, want {}", data, err)
+	}
+
+	// A file encrypted directly with the passphrase, as the previous versions did, can still be read
+	r, err := age.NewScryptRecipient("segreto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.SetWorkFactor(10)
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("old"))
+	w.Close()
+	if data, err := decrypt(buf.Bytes()); err != nil || string(data) != "old" {
+		t.Errorf("decrypt of a file encrypted with the passphrase = %q, %v", data, err)
+	}
+
+	if err := InitEncryption("sbagliata", "", ""); err == nil {
+		t.Error("InitEncryption with a different passphrase must fail")
+	}
+}
@@ -0,0 +1,214 @@
+package backup
+
+// Move is a track that is in both versions of a playlist but in a different relative order
+type Move struct {
+	Track Track
+	From  int // Position in the old version (starting from 1)
+	To    int // Position in the new version (starting from 1)
+}
+
+// Change is a track added to or removed from a playlist, with its position (starting from 1) in the version that contains it
+type Change struct {
+	Track    Track
+	Position int
+}
+
+// DiffResult contains the differences between two versions of a playlist
+type DiffResult struct {
+	Added   []Change
+	Removed []Change
+	Moved   []Move
+}
+
+// HasChanges returns true if there is at least one difference between the two versions
+func (d DiffResult) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Moved) > 0
+}
+
+// Key returns the value used to compare two tracks: the URI if present, otherwise the ID
+func (t Track) Key() string {
+	if t.URI != "" {
+		return string(t.URI)
+	}
+	return string(t.ID)
+}
+
+/*
+Diff compares two versions of a playlist (for example two backups or a backup and the live playlist).
+Duplicated tracks are compared by number of occurrences, the tracks kept in both versions are reported as moved
+if they are not part of the longest common subsequence of the two versions.
+When one of the versions has only the IDs of the tracks the names are taken from the other one, if possible
+*/
+func Diff(before, after Playlist) DiffResult {
+	// Copy the tracks, so enrich doesn't change the playlists given as parameters
+	beforeTracks := append([]Track(nil), before.Tracks()...)
+	afterTracks := append([]Track(nil), after.Tracks()...)
+	enrich(beforeTracks, afterTracks)
+	enrich(afterTracks, beforeTracks)
+
+	// Count the occurrences to find added and removed tracks (duplicates included)
+	beforeCount := map[string]int{}
+	for _, t := range beforeTracks {
+		beforeCount[t.Key()]++
+	}
+	afterCount := map[string]int{}
+	for _, t := range afterTracks {
+		afterCount[t.Key()]++
+	}
+
+	var res DiffResult
+	var beforeKept, afterKept []Change
+	seen := map[string]int{}
+	for i, t := range beforeTracks {
+		seen[t.Key()]++
+		if seen[t.Key()] > afterCount[t.Key()] {
+			res.Removed = append(res.Removed, Change{Track: t, Position: i + 1})
+		} else {
+			beforeKept = append(beforeKept, Change{Track: t, Position: i + 1})
+		}
+	}
+	seen = map[string]int{}
+	for i, t := range afterTracks {
+		seen[t.Key()]++
+		if seen[t.Key()] > beforeCount[t.Key()] {
+			res.Added = append(res.Added, Change{Track: t, Position: i + 1})
+		} else {
+			afterKept = append(afterKept, Change{Track: t, Position: i + 1})
+		}
+	}
+
+	res.Moved = moved(beforeKept, afterKept)
+	return res
+}
+
+/*
+moved returns the tracks that are not part of the longest common subsequence of the two lists, that contain the same tracks.
+For every moved track the position in the old and in the new version is reported
+*/
+func moved(oldKept, newKept []Change) []Move {
+	n, m := len(oldKept), len(newKept)
+	if n == 0 || m == 0 {
+		return nil
+	}
+
+	oldKeys := make([]string, n)
+	for i, c := range oldKept {
+		oldKeys[i] = c.Track.Key()
+	}
+	newKeys := make([]string, m)
+	for j, c := range newKept {
+		newKeys[j] = c.Track.Key()
+	}
+
+	// Mark the positions (old and new) that are in the LCS
+	oldInLCS := make([]bool, n)
+	inLCS := make([]bool, m)
+	markLCS(oldKeys, newKeys, oldInLCS, inLCS)
+
+	// Match every moved occurrence with the first old occurrence of the same track not in the LCS
+	oldPositions := map[string][]int{}
+	for i, c := range oldKept {
+		if !oldInLCS[i] {
+			oldPositions[c.Track.Key()] = append(oldPositions[c.Track.Key()], c.Position)
+		}
+	}
+
+	var moves []Move
+	for j, c := range newKept {
+		if inLCS[j] {
+			continue
+		}
+		from := 0
+		if positions := oldPositions[c.Track.Key()]; len(positions) > 0 {
+			from = positions[0]
+			oldPositions[c.Track.Key()] = positions[1:]
+		}
+		moves = append(moves, Move{Track: c.Track, From: from, To: c.Position})
+	}
+	return moves
+}
+
+/*
+markLCS marks in inA and inB the elements of a and b that are part of a longest common subsequence of the two lists.
+It uses the Hirschberg algorithm, so the memory used is linear in the length of b instead of the full table of the lengths
+*/
+func markLCS(a, b []string, inA, inB []bool) {
+	// The common prefix and suffix are always part of an LCS, removing them makes the usual cases (few changes) fast
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		inA[0], inB[0] = true, true
+		a, b, inA, inB = a[1:], b[1:], inA[1:], inB[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		inA[len(a)-1], inB[len(b)-1] = true, true
+		a, b, inA, inB = a[:len(a)-1], b[:len(b)-1], inA[:len(a)-1], inB[:len(b)-1]
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return
+	}
+	if len(a) == 1 {
+		for j, k := range b {
+			if k == a[0] {
+				inA[0], inB[j] = true, true
+				return
+			}
+		}
+		return
+	}
+
+	// Split a in half and find the split of b that maximizes the LCS of the two halves
+	mid := len(a) / 2
+	front := lcsLengths(a[:mid], b, false)
+	back := lcsLengths(a[mid:], b, true)
+	split, best := 0, -1
+	for j := 0; j <= len(b); j++ {
+		if l := front[j] + back[len(b)-j]; l > best {
+			split, best = j, l
+		}
+	}
+	markLCS(a[:mid], b[:split], inA[:mid], inB[:split])
+	markLCS(a[mid:], b[split:], inA[mid:], inB[split:])
+}
+
+/*
+lcsLengths returns the lengths of the LCS of a and every prefix of b (l[j] is the LCS of a and b[:j]).
+If reverse is true both lists are read from the end, so l[j] is the LCS of a and the last j elements of b
+*/
+func lcsLengths(a, b []string, reverse bool) []int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	at := func(s []string, i int) string {
+		if reverse {
+			return s[len(s)-1-i]
+		}
+		return s[i]
+	}
+	for i := range a {
+		for j := range b {
+			if at(a, i) == at(b, j) {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+// enrich fills the details of the tracks in dst that have only the ID, using the tracks with the same key in src
+func enrich(dst, src []Track) {
+	details := map[string]Track{}
+	for _, t := range src {
+		if len(t.Artists) > 0 {
+			details[t.Key()] = t
+		}
+	}
+	for i, t := range dst {
+		if len(t.Artists) > 0 {
+			continue
+		}
+		if d, ok := details[t.Key()]; ok {
+			dst[i] = d
+		}
+	}
+}
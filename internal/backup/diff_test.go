@@ -0,0 +1,121 @@
+package backup
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+// playlistOf returns a playlist with a track for every letter of ids
+func playlistOf(ids string) Playlist {
+	var p Playlist
+	for _, id := range strings.Split(ids, "") {
+		p.TrackIDs = append(p.TrackIDs, api.ID(id))
+	}
+	return p
+}
+
+// changeIDs returns the IDs of the tracks of the changes, in order
+func changeIDs(changes []Change) string {
+	var s string
+	for _, c := range changes {
+		s += string(c.Track.ID)
+	}
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name             string
+		before, after    string
+		added, removed   string
+		moved            []Move
+		addedPositions   []int
+		removedPositions []int
+	}{
+		{name: "equal", before: "abc", after: "abc"},
+		{name: "added", before: "abc", after: "abxc", added: "x", addedPositions: []int{3}},
+		{name: "removed", before: "abc", after: "ac", removed: "b", removedPositions: []int{2}},
+		{name: "duplicate added", before: "ab", after: "abb", added: "b", addedPositions: []int{3}},
+		{name: "duplicate removed", before: "aab", after: "ab", removed: "a", removedPositions: []int{2}},
+		{name: "moved to the end", before: "abcd", after: "bcda", moved: []Move{{From: 1, To: 4}}},
+		{name: "moved to the start", before: "abc", after: "cab", moved: []Move{{From: 3, To: 1}}},
+		{name: "empty before", before: "", after: "ab", added: "ab", addedPositions: []int{1, 2}},
+		{name: "empty after", before: "ab", after: "", removed: "ab", removedPositions: []int{1, 2}},
+		{name: "mixed", before: "abcde", after: "xbdea", added: "x", addedPositions: []int{1}, removed: "c", removedPositions: []int{3},
+			moved: []Move{{From: 1, To: 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Diff(playlistOf(tt.before), playlistOf(tt.after))
+			if got := changeIDs(res.Added); got != tt.added {
+				t.Errorf("added = %q, want %q", got, tt.added)
+			}
+			if got := changeIDs(res.Removed); got != tt.removed {
+				t.Errorf("removed = %q, want %q", got, tt.removed)
+			}
+			var addedPositions, removedPositions []int
+			for _, c := range res.Added {
+				addedPositions = append(addedPositions, c.Position)
+			}
+			for _, c := range res.Removed {
+				removedPositions = append(removedPositions, c.Position)
+			}
+			if !reflect.DeepEqual(addedPositions, tt.addedPositions) {
+				t.Errorf("added positions = %v, want %v", addedPositions, tt.addedPositions)
+			}
+			if !reflect.DeepEqual(removedPositions, tt.removedPositions) {
+				t.Errorf("removed positions = %v, want %v", removedPositions, tt.removedPositions)
+			}
+			var moved []Move
+			for _, m := range res.Moved {
+				moved = append(moved, Move{From: m.From, To: m.To})
+			}
+			if !reflect.DeepEqual(moved, tt.moved) {
+				t.Errorf("moved = %v, want %v", moved, tt.moved)
+			}
+			if res.HasChanges() != (tt.added != "" || tt.removed != "" || tt.moved != nil) {
+				t.Errorf("HasChanges = %v", res.HasChanges())
+			}
+		})
+	}
+}
+
+// TestDiffMovedIsMinimal checks on random shuffles that the tracks not moved are a longest common subsequence
+func TestDiffMovedIsMinimal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		before := make([]byte, r.Intn(30))
+		for j := range before {
+			before[j] = byte('a' + r.Intn(6))
+		}
+		after := append([]byte(nil), before...)
+		r.Shuffle(len(after), func(a, b int) { after[a], after[b] = after[b], after[a] })
+
+		res := Diff(playlistOf(string(before)), playlistOf(string(after)))
+		if want := len(before) - lcsLength(string(before), string(after)); len(res.Moved) != want {
+			t.Fatalf("Diff(%q, %q) moved %d tracks, want %d", before, after, len(res.Moved), want)
+		}
+	}
+}
+
+// lcsLength returns the length of the longest common subsequence of a and b with the full table
+func lcsLength(a, b string) int {
+	l := make([][]int, len(a)+1)
+	for i := range l {
+		l[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				l[i][j] = l[i-1][j-1] + 1
+			} else {
+				l[i][j] = max(l[i-1][j], l[i][j-1])
+			}
+		}
+	}
+	return l[len(a)][len(b)]
+}
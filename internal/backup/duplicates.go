@@ -0,0 +1,146 @@
+package backup
+
+import (
+	"slices"
+	"strings"
+
+	"playlist-manager/pkg/utils"
+)
+
+// Reasons for which the tracks of a DuplicateGroup are considered the same, from the strongest.
+// They are keys of the catalogue of the messages, translated when shown
+const (
+	DuplicateID   = "duplicates.sameTrack"
+	DuplicateISRC = "duplicates.sameISRC"
+	DuplicateName = "duplicates.sameName"
+)
+
+// DuplicateMaxDurationDiff is the maximum difference of duration (in milliseconds) between two tracks with the same title and artists
+const DuplicateMaxDurationDiff = 5000
+
+// DuplicateGroup is a group of occurrences of the same track in a playlist, in their order: by default the first one is kept
+type DuplicateGroup struct {
+	Items  []Change // Occurrences of the track, with their position (starting from 1)
+	Reason string   // DuplicateID, DuplicateISRC or DuplicateName, the weakest shared by all the occurrences
+}
+
+// Extra returns the occurrences after the first one, the ones removed by default
+func (g DuplicateGroup) Extra() []Change {
+	return g.Items[1:]
+}
+
+/*
+FindDuplicates finds the duplicated tracks of a playlist: the exact duplicates (same URI or ID) and, if exact is false,
+the near duplicates (same ISRC, or same normalised title and artists with a duration that differs less than DuplicateMaxDurationDiff).
+items are the tracks of the playlist with their position
+Returns the groups of duplicates, ordered by the position of their first occurrence
+*/
+func FindDuplicates(items []Change, exact bool) []DuplicateGroup {
+	// Union-find over the indexes of the items
+	parent := make([]int, len(items))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) {
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[max(ra, rb)] = min(ra, rb)
+		}
+	}
+	unionBy := func(key func(t Track) string) {
+		first := map[string]int{}
+		for i, c := range items {
+			k := key(c.Track)
+			if k == "" {
+				continue
+			}
+			if j, ok := first[k]; ok {
+				union(j, i)
+			} else {
+				first[k] = i
+			}
+		}
+	}
+
+	unionBy(func(t Track) string { return t.Key() })
+	if !exact {
+		unionBy(func(t Track) string { return t.ISRC })
+		// Same title and artists: the duration is compared with every occurrence already seen
+		byName := map[string][]int{}
+		for i, c := range items {
+			k := nameKey(c.Track)
+			if k == "" {
+				continue
+			}
+			for _, j := range byName[k] {
+				if closeDuration(items[j].Track, c.Track) {
+					union(j, i)
+					break
+				}
+			}
+			byName[k] = append(byName[k], i)
+		}
+	}
+
+	groups := map[int][]Change{}
+	var roots []int
+	for i, c := range items {
+		r := find(i)
+		if _, ok := groups[r]; !ok {
+			roots = append(roots, r)
+		}
+		groups[r] = append(groups[r], c)
+	}
+	var res []DuplicateGroup
+	for _, r := range roots {
+		if len(groups[r]) > 1 {
+			res = append(res, DuplicateGroup{Items: groups[r], Reason: duplicateReason(groups[r])})
+		}
+	}
+	return res
+}
+
+// nameKey returns the normalised title and artists of a track, used to find the near duplicates
+func nameKey(t Track) string {
+	name := utils.Normalise(t.Name)
+	if name == "" || t.Name == string(t.ID) {
+		return ""
+	}
+	artists := make([]string, 0, len(t.Artists))
+	for _, a := range t.Artists {
+		artists = append(artists, utils.Normalise(a))
+	}
+	slices.Sort(artists)
+	return name + "|" + strings.Join(artists, ",")
+}
+
+// closeDuration returns true if the durations of the tracks differ less than DuplicateMaxDurationDiff or one of them is unknown
+func closeDuration(a, b Track) bool {
+	if a.Duration == 0 || b.Duration == 0 {
+		return true
+	}
+	return max(a.Duration-b.Duration, b.Duration-a.Duration) <= DuplicateMaxDurationDiff
+}
+
+// duplicateReason returns the strongest reason shared by all the tracks of a group
+func duplicateReason(items []Change) string {
+	sameKey, sameISRC := true, items[0].Track.ISRC != ""
+	for _, c := range items[1:] {
+		sameKey = sameKey && c.Track.Key() == items[0].Track.Key()
+		sameISRC = sameISRC && c.Track.ISRC == items[0].Track.ISRC
+	}
+	switch {
+	case sameKey:
+		return DuplicateID
+	case sameISRC:
+		return DuplicateISRC
+	default:
+		return DuplicateName
+	}
+}
@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+// track returns a track with the given ID, title, artist, ISRC and duration in seconds
+func track(id string, name string, artist string, isrc string, seconds int) Track {
+	return Track{ID: api.ID(id), URI: api.URI("spotify:track:" + id), Name: name, Artists: []string{artist}, ISRC: isrc, Duration: seconds * 1000}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	song := track("a", "Song", "Artist", "ISRC1", 200)
+	tests := []struct {
+		name   string
+		tracks []Track
+		exact  bool
+		want   [][]int // Positions of the occurrences of every group
+		reason []string
+	}{
+		{name: "no duplicates", tracks: []Track{song, track("b", "Other", "Artist", "ISRC2", 200)}},
+		{name: "same ID", tracks: []Track{song, track("b", "Other", "Artist", "", 100), song},
+			want: [][]int{{1, 3}}, reason: []string{DuplicateID}},
+		{name: "same ISRC", tracks: []Track{song, track("b", "Song (Remastered)", "Artist", "ISRC1", 300)},
+			want: [][]int{{1, 2}}, reason: []string{DuplicateISRC}},
+		{name: "same ISRC exact only", tracks: []Track{song, track("b", "Song", "Artist", "ISRC1", 200)}, exact: true},
+		{name: "same name close duration", tracks: []Track{song, track("b", "song - 2011 remaster", "artist", "", 203)},
+			want: [][]int{{1, 2}}, reason: []string{DuplicateName}},
+		{name: "same name different duration", tracks: []Track{song, track("b", "Song", "Artist", "ISRC2", 260)}},
+		{name: "same name different artist", tracks: []Track{song, track("b", "Song", "Someone else", "", 200)}},
+		{name: "groups joined by different reasons", tracks: []Track{
+			song,
+			track("x", "Filler", "Nobody", "", 100),
+			track("b", "Song", "Artist", "", 201),
+			song,
+			track("c", "Cover", "Band", "ISRC3", 150),
+			track("d", "Cover (Live)", "Band", "ISRC3", 180),
+		}, want: [][]int{{1, 3, 4}, {5, 6}}, reason: []string{DuplicateName, DuplicateISRC}},
+		{name: "only IDs are not compared by name", tracks: []Track{
+			{ID: "a", URI: "spotify:track:a", Name: "a"},
+			{ID: "b", URI: "spotify:track:b", Name: "b"},
+			{ID: "a", URI: "spotify:track:a", Name: "a"},
+		}, want: [][]int{{1, 3}}, reason: []string{DuplicateID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]Change, len(tt.tracks))
+			for i, tr := range tt.tracks {
+				items[i] = Change{Track: tr, Position: i + 1}
+			}
+			var got [][]int
+			var reasons []string
+			for _, g := range FindDuplicates(items, tt.exact) {
+				var positions []int
+				for _, c := range g.Items {
+					positions = append(positions, c.Position)
+				}
+				got = append(got, positions)
+				reasons = append(reasons, g.Reason)
+				if len(g.Extra()) != len(g.Items)-1 || g.Extra()[0].Position != g.Items[1].Position {
+					t.Errorf("Extra = %v, want the occurrences after the first", g.Extra())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groups = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(reasons, tt.reason) {
+				t.Errorf("reasons = %v, want %v", reasons, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package backup
+
+import (
+	api "github.com/zmb3/spotify/v2"
+	"playlist-manager/pkg/i18n"
+)
+
+/*
+The library of the user (liked songs, saved albums and followed artists) is saved with the same layout of the playlists,
+as a personal "playlist" named after its kind (data/backup/<userID>/<date>/liked_songs.json, ...), so the store, the archives,
+the retention policy, the diff and the offline mode work in the same way.
+For the saved albums and the followed artists TrackIDs contains their IDs and Items their details (the URI is spotify:album:<id> or spotify:artist:<id>)
+*/
+
+// Kind is the kind of content saved in a backup
+type Kind string
+
+const (
+	KindPlaylist        Kind = ""
+	KindLikedSongs      Kind = "liked_songs"
+	KindSavedAlbums     Kind = "saved_albums"
+	KindFollowedArtists Kind = "followed_artists"
+)
+
+// LibraryKinds returns the kinds of the library backups, in the order they are saved
+func LibraryKinds() []Kind {
+	return []Kind{KindLikedSongs, KindSavedAlbums, KindFollowedArtists}
+}
+
+/*
+storedNames are the names saved in the library backups: they do not depend on the language of the interface,
+otherwise changing it would make Equal see every library backup as changed. They are translated only when shown, with Kind.Name
+*/
+var storedNames = map[Kind]string{
+	KindLikedSongs:      "Brani che ti piacciono",
+	KindSavedAlbums:     "Album salvati",
+	KindFollowedArtists: "Artisti seguiti",
+}
+
+// Name returns the name shown for a library backup, in the language of the interface
+func (k Kind) Name() string {
+	switch k {
+	case KindLikedSongs:
+		return i18n.T("library.likedSongs")
+	case KindSavedAlbums:
+		return i18n.T("library.savedAlbums")
+	case KindFollowedArtists:
+		return i18n.T("library.followedArtists")
+	default:
+		return "Playlist"
+	}
+}
+
+// LibraryPlaylist returns an empty library backup of the given kind, its ID is the kind itself
+func LibraryPlaylist(k Kind) Playlist {
+	return Playlist{ID: api.ID(k), Name: storedNames[k], TrackIDs: []api.ID{}, Kind: k}
+}
+
+// DisplayName returns the name shown for the backup: the translated name of its kind for the library backups
+func (p Playlist) DisplayName() string {
+	if p.IsLibrary() {
+		return p.Kind.Name()
+	}
+	return p.Name
+}
+
+// IsLibrary returns true if the backup contains a part of the library of the user and not a playlist
+func (p Playlist) IsLibrary() bool {
+	return p.Kind != KindPlaylist
+}
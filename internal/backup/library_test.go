@@ -0,0 +1,33 @@
+package backup
+
+import (
+	"testing"
+
+	"playlist-manager/pkg/i18n"
+)
+
+func TestLibraryPlaylistName(t *testing.T) {
+	previous := i18n.Lang()
+	t.Cleanup(func() { i18n.Init(previous) })
+
+	for _, k := range LibraryKinds() {
+		t.Run(string(k), func(t *testing.T) {
+			if err := i18n.Init(i18n.Italian); err != nil {
+				t.Fatal(err)
+			}
+			italian := LibraryPlaylist(k)
+			if err := i18n.Init(i18n.English); err != nil {
+				t.Fatal(err)
+			}
+			english := LibraryPlaylist(k)
+
+			// The backups saved in different languages must not be seen as changed
+			if !Equal(italian, english) {
+				t.Errorf("LibraryPlaylist(%s) = %q in Italian and %q in English", k, italian.Name, english.Name)
+			}
+			if english.DisplayName() != k.Name() || english.DisplayName() == italian.Name {
+				t.Errorf("DisplayName = %q, want the English name %q", english.DisplayName(), k.Name())
+			}
+		})
+	}
+}
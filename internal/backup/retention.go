@@ -0,0 +1,272 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"playlist-manager/pkg/i18n"
+	"sort"
+	"time"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// DateLayout is the layout of the names of the date folders
+const DateLayout = "2006-01-02"
+
+/*
+Policy is the retention policy of the backups, applied to the versions of every playlist separately.
+A field set to 0 disables that rule, the newest version of every playlist is always kept
+*/
+type Policy struct {
+	KeepLast    int // Number of most recent versions to keep
+	KeepDaily   int // Number of days (from today) in which every version is kept
+	KeepWeekly  int // Number of weeks (from today) in which the newest version of every week is kept
+	KeepMonthly int // Number of months (from today) in which the newest version of every month is kept
+}
+
+// IsZero returns true if no rule is set, that means every backup is kept
+func (p Policy) IsZero() bool {
+	return p.KeepLast <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0 && p.KeepMonthly <= 0
+}
+
+// Version is a backup of a playlist saved in a date folder or in a manifest of the store
+type Version struct {
+	Date     time.Time
+	Path     string
+	Personal bool
+	Stored   bool // True if the version is in the store, Path is the object referenced by the manifest
+}
+
+// PruneResult contains the backup files removed (or that would be removed) by Prune
+type PruneResult struct {
+	Removed []string
+	Kept    int
+}
+
+/*
+Versions returns the versions of every playlist of the user (date folders and store), grouped by playlist ID and sorted from the newest to the oldest.
+Only the folders and manifests named after a date (see DateLayout) are considered
+Returns the versions and an error, if present
+*/
+func Versions(userID string) (map[api.ID][]Version, error) {
+	versions := map[api.ID][]Version{}
+	for _, personal := range []bool{true, false} {
+		dir := UserDir(userID, personal)
+		dates, err := Dates(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dates {
+			date, err := time.ParseInLocation(DateLayout, d, time.Local)
+			if err != nil {
+				log.Debug(i18n.T("log.retentionDirSkipped"), "dir", filepath.Join(dir, d))
+				continue
+			}
+			entries, err := os.ReadDir(filepath.Join(dir, d))
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range entries {
+				if e.IsDir() || !isJSON(e.Name()) {
+					continue
+				}
+				id := api.ID(trimExt(e.Name(), ".json"))
+				versions[id] = append(versions[id], Version{Date: date, Path: filepath.Join(dir, d, e.Name()), Personal: personal})
+			}
+		}
+	}
+
+	manifestDates, err := ManifestDates(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range manifestDates {
+		date, err := time.ParseInLocation(DateLayout, d, time.Local)
+		if err != nil {
+			log.Debug(i18n.T("log.retentionManifestSkipped"), "date", d)
+			continue
+		}
+		m, err := ReadManifest(userID, d)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range m.Entries {
+			path, err := ObjectPath(userID, e.Hash)
+			if err != nil {
+				return nil, fmt.Errorf(i18n.T("store.readState"), e.Hash, e.ID, err)
+			}
+			versions[e.ID] = append(versions[e.ID], Version{Date: date, Path: path, Personal: e.Personal, Stored: true})
+		}
+	}
+
+	for _, v := range versions {
+		sort.SliceStable(v, func(i, j int) bool { return v[i].Date.After(v[j].Date) })
+	}
+	return versions, nil
+}
+
+/*
+Keep returns, for the versions of a playlist sorted from the newest to the oldest, which ones must be kept by the policy at the time now
+*/
+func (p Policy) Keep(versions []Version, now time.Time) []bool {
+	keep := make([]bool, len(versions))
+	if len(versions) == 0 {
+		return keep
+	}
+	if p.IsZero() {
+		for i := range keep {
+			keep[i] = true
+		}
+		return keep
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dailyLimit := today.AddDate(0, 0, -p.KeepDaily+1)
+	weeklyLimit := today.AddDate(0, 0, -7*p.KeepWeekly+1)
+	monthlyLimit := time.Date(today.Year(), today.Month()-time.Month(p.KeepMonthly)+1, 1, 0, 0, 0, 0, today.Location())
+
+	// The newest version is always kept
+	keep[0] = true
+	weeks := map[string]bool{}
+	months := map[string]bool{}
+	for i, v := range versions {
+		if i < p.KeepLast {
+			keep[i] = true
+		}
+		if p.KeepDaily > 0 && !v.Date.Before(dailyLimit) {
+			keep[i] = true
+		}
+		if p.KeepWeekly > 0 && !v.Date.Before(weeklyLimit) {
+			year, week := v.Date.ISOWeek()
+			key := fmt.Sprintf("%d-%02d", year, week)
+			if !weeks[key] {
+				weeks[key] = true
+				keep[i] = true
+			}
+		}
+		if p.KeepMonthly > 0 && !v.Date.Before(monthlyLimit) {
+			key := v.Date.Format("2006-01")
+			if !months[key] {
+				months[key] = true
+				keep[i] = true
+			}
+		}
+	}
+	return keep
+}
+
+/*
+Prune removes the backups of the user that are not kept by the policy, then removes the date folders left empty.
+The versions in the store are removed from their manifest and the states no longer referenced are deleted.
+If dryRun is true nothing is removed, the result contains the files that would be removed
+Returns the result and an error, if present
+*/
+func Prune(userID string, p Policy, now time.Time, dryRun bool) (res PruneResult, err error) {
+	if p.IsZero() {
+		return res, nil
+	}
+	versions, err := Versions(userID)
+	if err != nil {
+		return res, err
+	}
+
+	dirs := map[string]bool{}
+	stored := false
+	for id, v := range versions {
+		keep := p.Keep(v, now)
+		for i := range v {
+			if keep[i] {
+				res.Kept++
+				continue
+			}
+			date := v[i].Date.Format(DateLayout)
+			if v[i].Stored {
+				res.Removed = append(res.Removed, manifestPath(userID, date)+" ("+string(id)+")")
+			} else {
+				res.Removed = append(res.Removed, v[i].Path)
+			}
+			if dryRun {
+				continue
+			}
+			if v[i].Stored {
+				err = removeFromManifest(userID, date, id)
+				if err != nil {
+					return res, err
+				}
+				stored = true
+				log.Info(i18n.T("log.retentionVersionRemoved"), "playlistID", id, "date", date)
+				continue
+			}
+			err = os.Remove(v[i].Path)
+			if err != nil {
+				return res, err
+			}
+			err = removeCover(v[i].Path)
+			if err != nil {
+				return res, err
+			}
+			dirs[filepath.Dir(v[i].Path)] = true
+			log.Info(i18n.T("log.retentionBackupRemoved"), "playlistID", id, "file", v[i].Path)
+		}
+	}
+	sort.Strings(res.Removed)
+
+	// Remove the states of the store no longer referenced
+	if stored {
+		_, err = gcObjects(userID)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	// Remove the date folders left empty
+	for dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return res, err
+		}
+		if len(entries) == 0 {
+			err = os.Remove(dir)
+			if err != nil {
+				return res, err
+			}
+			log.Info(i18n.T("log.retentionDirRemoved"), "dir", dir)
+		}
+	}
+	return res, nil
+}
+
+/*
+Latest returns the newest backup file of a playlist in a backup folder (see UserDir)
+Returns the file, false if there is no backup of the playlist, and an error, if present
+*/
+func Latest(dir string, id api.ID) (f File, found bool, err error) {
+	dates, err := Dates(dir)
+	if err != nil {
+		return f, false, err
+	}
+	for i := len(dates) - 1; i >= 0; i-- {
+		path := filepath.Join(dir, dates[i], string(id)+".json")
+		p, err := Read(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return f, false, err
+		}
+		return File{Path: path, Playlist: p}, true, nil
+	}
+	return f, false, nil
+}
+
+// Equal returns true if the two playlists have the same content, that means storing both would be a duplicate
+func Equal(a, b Playlist) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
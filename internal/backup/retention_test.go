@@ -0,0 +1,145 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// versionsAt returns the versions with the given dates (DateLayout), that must be sorted from the newest to the oldest
+func versionsAt(t *testing.T, dates ...string) []Version {
+	t.Helper()
+	versions := make([]Version, 0, len(dates))
+	for _, d := range dates {
+		date, err := time.ParseInLocation(DateLayout, d, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, Version{Date: date, Path: d})
+	}
+	return versions
+}
+
+func TestPolicyKeep(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		policy Policy
+		dates  []string
+		want   []bool
+	}{
+		{name: "no versions", policy: Policy{KeepLast: 1}, dates: nil, want: []bool{}},
+		{name: "zero policy keeps everything", policy: Policy{}, dates: []string{"2024-03-15", "2020-01-01"}, want: []bool{true, true}},
+		{name: "last", policy: Policy{KeepLast: 2},
+			dates: []string{"2024-03-15", "2024-03-14", "2024-03-13", "2024-03-12"}, want: []bool{true, true, false, false}},
+		{name: "daily", policy: Policy{KeepDaily: 3},
+			dates: []string{"2024-03-15", "2024-03-14", "2024-03-13", "2024-03-12"}, want: []bool{true, true, true, false}},
+		{name: "weekly keeps the newest of every week", policy: Policy{KeepWeekly: 2},
+			dates: []string{"2024-03-15", "2024-03-12", "2024-03-08", "2024-03-05", "2024-03-01"}, want: []bool{true, false, true, false, false}},
+		{name: "monthly keeps the newest of every month", policy: Policy{KeepMonthly: 2},
+			dates: []string{"2024-03-10", "2024-03-01", "2024-02-20", "2024-02-05", "2024-01-31"}, want: []bool{true, false, true, false, false}},
+		{name: "newest always kept", policy: Policy{KeepDaily: 1},
+			dates: []string{"2023-01-01", "2022-12-01"}, want: []bool{true, false}},
+		{name: "rules combined", policy: Policy{KeepLast: 1, KeepMonthly: 3},
+			dates: []string{"2024-03-15", "2024-03-14", "2024-02-01", "2024-01-20", "2023-12-31"}, want: []bool{true, false, true, true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Keep(versionsAt(t, tt.dates...), now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Keep = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// inTempDir runs the test inside an empty temporary folder, the backups are saved in the relative folder Dir
+func inTempDir(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Chdir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// writeTestFile writes a file creating its folder
+func writeTestFile(t *testing.T, path string) {
+	t.Helper()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(path, []byte("{}"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		dryRun  bool
+		removed bool // True if the files not kept must be removed
+	}{
+		{name: "dry run", dryRun: true, removed: false},
+		{name: "remove", dryRun: false, removed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inTempDir(t)
+			dir := UserDir("user", true)
+			oldest := filepath.Join(dir, "2024-03-13", "p1.json")
+			cover := filepath.Join(dir, "2024-03-13", "p1"+CoverExt)
+			for _, path := range []string{
+				filepath.Join(dir, "2024-03-15", "p1.json"),
+				filepath.Join(dir, "2024-03-14", "p1.json"),
+				oldest, cover,
+				filepath.Join(dir, "2024-03-13", "p2.json"),
+				filepath.Join(dir, "2024-03-01", "p2.json"),
+			} {
+				writeTestFile(t, path)
+			}
+
+			res, err := Prune("user", Policy{KeepLast: 2}, now, tt.dryRun)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []string{oldest}; !reflect.DeepEqual(res.Removed, want) {
+				t.Errorf("Removed = %v, want %v", res.Removed, want)
+			}
+			if res.Kept != 4 {
+				t.Errorf("Kept = %d, want 4", res.Kept)
+			}
+			for _, path := range []string{oldest, cover} {
+				if _, err := os.Stat(path); os.IsNotExist(err) != tt.removed {
+					t.Errorf("%s removed = %v, want %v", path, os.IsNotExist(err), tt.removed)
+				}
+			}
+			// The folder still contains the backup of the other playlist
+			if _, err := os.Stat(filepath.Join(dir, "2024-03-13", "p2.json")); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestPruneZeroPolicy(t *testing.T) {
+	inTempDir(t)
+	writeTestFile(t, filepath.Join(UserDir("user", true), "2020-01-01", "p1.json"))
+	writeTestFile(t, filepath.Join(UserDir("user", true), "2020-01-02", "p1.json"))
+	res, err := Prune("user", Policy{}, time.Now(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Removed) != 0 {
+		t.Errorf("Removed = %v, want nothing", res.Removed)
+	}
+}
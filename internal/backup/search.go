@@ -0,0 +1,212 @@
+package backup
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+// Fields where the tracks can be searched, an empty field searches in all of them (and in the ID)
+const (
+	SearchTitle  = "title"
+	SearchArtist = "artist"
+	SearchAlbum  = "album"
+	SearchISRC   = "isrc"
+)
+
+// SearchFields returns the fields where the tracks can be searched
+func SearchFields() []string {
+	return []string{SearchTitle, SearchArtist, SearchAlbum, SearchISRC}
+}
+
+// FuzzyThreshold is the minimum similarity (from 0 to 1) of a fuzzy match
+const FuzzyThreshold = 0.75
+
+// SearchOptions are the options of a search
+type SearchOptions struct {
+	// Field to search in (see SearchFields), empty for all of them
+	Field string
+	// If true also the similar texts are found (for example with a typo), not only the ones that contain the query
+	Fuzzy bool
+}
+
+// Validate returns an error if the field of the options is not valid
+func (o SearchOptions) Validate() error {
+	if o.Field != "" && !slices.Contains(SearchFields(), o.Field) {
+		return fmt.Errorf(i18n.T("search.invalidField"), o.Field, strings.Join(SearchFields(), ", "))
+	}
+	return nil
+}
+
+// Match is a track found by a search, with the playlist (and the backup) it has been found in
+type Match struct {
+	Date     string // Date of the backup, empty for the live playlists
+	Personal bool
+	File     File // Only the playlist is filled for the live playlists
+	Position int
+	Track    Track
+	// How much the track matches the query, from FuzzyThreshold to 1 (1 if a field contains the query)
+	Score float64
+}
+
+/*
+Score returns how much the track matches the query: 1 if one of the fields contains it (case insensitive), with Fuzzy the highest
+similarity between the query and the words of the fields, if it is at least FuzzyThreshold, and 0 if the track doesn't match
+*/
+func (o SearchOptions) Score(t Track, query string) float64 {
+	query = utils.Lower(strings.TrimSpace(query))
+	if query == "" {
+		return 0
+	}
+	var fields []string
+	switch o.Field {
+	case SearchTitle:
+		fields = []string{t.Name}
+	case SearchArtist:
+		fields = t.Artists
+	case SearchAlbum:
+		fields = []string{t.Album}
+	case SearchISRC:
+		// The codes are never similar by chance, so they are only compared as text
+		if strings.Contains(utils.Lower(t.ISRC), query) {
+			return 1
+		}
+		return 0
+	default:
+		fields = append([]string{t.Name, t.Album, t.ISRC, string(t.ID)}, t.Artists...)
+	}
+	best := 0.0
+	for _, f := range fields {
+		if strings.Contains(utils.Lower(f), query) {
+			return 1
+		}
+		if o.Fuzzy {
+			best = max(best, fuzzyScore(f, query))
+		}
+	}
+	if best < FuzzyThreshold {
+		return 0
+	}
+	return best
+}
+
+// fuzzyScore returns the highest similarity between the query and the groups of consecutive words of the text with the same number of words
+func fuzzyScore(text string, query string) float64 {
+	words := strings.Fields(utils.Normalise(text))
+	q := utils.Normalise(query)
+	n := len(strings.Fields(q))
+	if n == 0 || len(words) == 0 {
+		return 0
+	}
+	best := utils.Similarity(strings.Join(words, " "), q)
+	for i := 0; i+n <= len(words); i++ {
+		best = max(best, utils.Similarity(strings.Join(words[i:i+n], " "), q))
+	}
+	return best
+}
+
+/*
+SearchPlaylist looks for the tracks of a playlist that match the query (see SearchOptions.Score)
+Returns the matches, in the order of the playlist
+*/
+func SearchPlaylist(p Playlist, query string, opts SearchOptions) (matches []Match) {
+	for i, t := range p.Tracks() {
+		if score := opts.Score(t, query); score > 0 {
+			matches = append(matches, Match{File: File{Playlist: p}, Position: i + 1, Track: t, Score: score})
+		}
+	}
+	return matches
+}
+
+/*
+Search looks for the tracks that match the query (see SearchOptions.Score) in all the backups (personal and of other users) of a user
+Returns the matches, from the oldest backup, and an error, if present
+*/
+func Search(userID string, query string, opts SearchOptions) (matches []Match, err error) {
+	if strings.TrimSpace(query) == "" {
+		return matches, nil
+	}
+	for _, personal := range []bool{true, false} {
+		dates, err := UserDates(userID, personal)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dates {
+			files, err := DateFiles(userID, personal, d)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range files {
+				for _, m := range SearchPlaylist(f.Playlist, query, opts) {
+					m.Date, m.Personal, m.File = d, personal, f
+					matches = append(matches, m)
+				}
+			}
+		}
+	}
+	return matches, nil
+}
+
+// PlaylistMatches are the matches of a search in the same playlist, in its live version and in its backups
+type PlaylistMatches struct {
+	Name string // Name of the most recent version
+	// Dates of the backups with at least a match, from the oldest
+	Dates []string
+	// True if the live playlist has at least a match
+	Live bool
+	// Distinct tracks found, with their position in the most recent version where they have been found
+	Matches []Match
+}
+
+/*
+GroupMatches groups the matches by playlist (by ID, or by name for the playlists without ID).
+The playlists are sorted by best score and then by name, the tracks by score and then by position
+Returns the groups
+*/
+func GroupMatches(matches []Match) []PlaylistMatches {
+	var groups []PlaylistMatches
+	index := map[string]int{}
+	tracks := map[string]map[string]int{}
+	for _, m := range matches {
+		key := string(m.File.Playlist.ID)
+		if key == "" {
+			key = m.File.Playlist.Name
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			tracks[key] = map[string]int{}
+			groups = append(groups, PlaylistMatches{})
+		}
+		g := &groups[i]
+		// The playlist may have been renamed, the name is the one of the most recent version
+		g.Name = m.File.Playlist.Name
+		if m.Date == "" {
+			g.Live = true
+		} else if !slices.Contains(g.Dates, m.Date) {
+			g.Dates = append(g.Dates, m.Date)
+		}
+		// A track found again replaces the previous match, so the position is the one of the most recent version
+		if j, found := tracks[key][m.Track.Key()]; found {
+			g.Matches[j] = m
+			continue
+		}
+		tracks[key][m.Track.Key()] = len(g.Matches)
+		g.Matches = append(g.Matches, m)
+	}
+	for i := range groups {
+		slices.Sort(groups[i].Dates)
+		slices.SortStableFunc(groups[i].Matches, func(a, b Match) int {
+			return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Position, b.Position))
+		})
+	}
+	slices.SortStableFunc(groups, func(a, b PlaylistMatches) int {
+		return cmp.Or(cmp.Compare(b.Matches[0].Score, a.Matches[0].Score), cmp.Compare(utils.Lower(a.Name), utils.Lower(b.Name)))
+	})
+	return groups
+}
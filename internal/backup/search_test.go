@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+func TestScore(t *testing.T) {
+	track := Track{ID: "4uLU6hMCjMI75M1A2tKUQC", Name: "Bohemian Rhapsody", Artists: []string{"Queen"}, Album: "A Night at the Opera", ISRC: "GBUM71029604"}
+	tests := []struct {
+		name  string
+		opts  SearchOptions
+		query string
+		match bool
+		exact bool
+	}{
+		{name: "title", query: "bohemian", match: true, exact: true},
+		{name: "case insensitive", query: "  RHAPSODY ", match: true, exact: true},
+		{name: "artist", query: "queen", match: true, exact: true},
+		{name: "album", query: "night at", match: true, exact: true},
+		{name: "isrc", query: "gbum7102", match: true, exact: true},
+		{name: "id", query: "4uLU6hMC", match: true, exact: true},
+		{name: "empty query", query: "  "},
+		{name: "not found", query: "beatles"},
+		{name: "typo without fuzzy", query: "bohemien"},
+		{name: "typo with fuzzy", opts: SearchOptions{Fuzzy: true}, query: "bohemien rapsody", match: true},
+		{name: "different text with fuzzy", opts: SearchOptions{Fuzzy: true}, query: "beatles"},
+		{name: "title field", opts: SearchOptions{Field: SearchTitle}, query: "rhapsody", match: true, exact: true},
+		{name: "artist not in the title field", opts: SearchOptions{Field: SearchTitle}, query: "queen"},
+		{name: "artist field", opts: SearchOptions{Field: SearchArtist}, query: "que", match: true, exact: true},
+		{name: "typo in the artist field", opts: SearchOptions{Field: SearchArtist, Fuzzy: true}, query: "qeen", match: true},
+		{name: "album field", opts: SearchOptions{Field: SearchAlbum}, query: "opera", match: true, exact: true},
+		{name: "title not in the album field", opts: SearchOptions{Field: SearchAlbum}, query: "bohemian"},
+		{name: "isrc field", opts: SearchOptions{Field: SearchISRC}, query: "GBUM71029604", match: true, exact: true},
+		{name: "isrc is never fuzzy", opts: SearchOptions{Field: SearchISRC, Fuzzy: true}, query: "GBUM71029605"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := tt.opts.Score(track, tt.query)
+			switch {
+			case !tt.match && score != 0:
+				t.Errorf("Score(%q) = %v, want 0", tt.query, score)
+			case tt.exact && score != 1:
+				t.Errorf("Score(%q) = %v, want 1", tt.query, score)
+			case tt.match && !tt.exact && (score < FuzzyThreshold || score >= 1):
+				t.Errorf("Score(%q) = %v, want a fuzzy score", tt.query, score)
+			}
+		})
+	}
+}
+
+func TestSearchOptionsValidate(t *testing.T) {
+	for _, field := range append(SearchFields(), "") {
+		if err := (SearchOptions{Field: field}).Validate(); err != nil {
+			t.Errorf("Validate with field %q = %v", field, err)
+		}
+	}
+	if err := (SearchOptions{Field: "genre"}).Validate(); err == nil {
+		t.Error("Validate with an unknown field must fail")
+	}
+}
+
+func TestGroupMatches(t *testing.T) {
+	track := func(id string) Track { return Track{ID: api.ID(id), URI: api.URI("spotify:track:" + id)} }
+	rock := Playlist{ID: "p1", Name: "Rock"}
+	rockRenamed := Playlist{ID: "p1", Name: "Rock classico"}
+	jazz := Playlist{Name: "jazz"}
+	matches := []Match{
+		{Date: "2024-02-01", File: File{Playlist: rock}, Position: 5, Track: track("a"), Score: 1},
+		{Date: "2024-01-01", File: File{Playlist: rock}, Position: 2, Track: track("b"), Score: 0.8},
+		{Date: "2024-02-01", File: File{Playlist: jazz}, Position: 1, Track: track("c"), Score: 1},
+		{Date: "2024-02-01", File: File{Playlist: rock}, Position: 1, Track: track("b"), Score: 0.8},
+		// The live version of the renamed playlist
+		{File: File{Playlist: rockRenamed}, Position: 3, Track: track("a"), Score: 1},
+		{Date: "2024-01-01", File: File{Playlist: Playlist{Name: "Ballate"}}, Position: 1, Track: track("d"), Score: 0.9},
+	}
+
+	groups := GroupMatches(matches)
+	type group struct {
+		name      string
+		dates     []string
+		live      bool
+		positions []int
+	}
+	var got []group
+	for _, g := range groups {
+		var positions []int
+		for _, m := range g.Matches {
+			positions = append(positions, m.Position)
+		}
+		got = append(got, group{name: g.Name, dates: g.Dates, live: g.Live, positions: positions})
+	}
+	// Sorted by best score and then by name (case insensitive), the tracks by score and position
+	want := []group{
+		{name: "jazz", dates: []string{"2024-02-01"}, positions: []int{1}},
+		{name: "Rock classico", dates: []string{"2024-01-01", "2024-02-01"}, live: true, positions: []int{3, 1}},
+		{name: "Ballate", dates: []string{"2024-01-01"}, positions: []int{1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupMatches = %+v, want %+v", got, want)
+	}
+
+	if groups := GroupMatches(nil); len(groups) != 0 {
+		t.Errorf("GroupMatches(nil) = %+v, want no groups", groups)
+	}
+}
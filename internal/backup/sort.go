@@ -0,0 +1,189 @@
+package backup
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+// Fields by which the tracks of a playlist can be sorted
+const (
+	SortTitle       = "title"
+	SortArtist      = "artist"
+	SortAlbum       = "album"
+	SortReleaseDate = "release_date"
+	SortAddedAt     = "added_at"
+	SortDuration    = "duration"
+	SortPopularity  = "popularity"
+)
+
+// SortFields returns the fields by which the tracks can be sorted, in the order shown to the user
+func SortFields() []string {
+	return []string{SortTitle, SortArtist, SortAlbum, SortReleaseDate, SortAddedAt, SortDuration, SortPopularity}
+}
+
+// SortKey is a field by which the tracks are sorted, in ascending or descending order
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// String returns the key in the form accepted by ParseSortKeys
+func (k SortKey) String() string {
+	if k.Desc {
+		return k.Field + ":desc"
+	}
+	return k.Field
+}
+
+/*
+ParseSortKeys parses a list of sort keys separated by commas, every key is a field optionally followed by ":asc" or ":desc"
+(for example "artist,release_date:desc,title")
+Returns the keys and an error, if a field or an order is not valid
+*/
+func ParseSortKeys(s string) (keys []SortKey, err error) {
+	for _, part := range strings.Split(s, ",") {
+		part = utils.Lower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		field, order, _ := strings.Cut(part, ":")
+		if !slices.Contains(SortFields(), field) {
+			return nil, fmt.Errorf(i18n.T("sort.invalidField"), field, strings.Join(SortFields(), ", "))
+		}
+		if order != "" && order != "asc" && order != "desc" {
+			return nil, fmt.Errorf(i18n.T("sort.invalidOrder"), field, order)
+		}
+		keys = append(keys, SortKey{Field: field, Desc: order == "desc"})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New(i18n.T("sort.noField"))
+	}
+	return keys, nil
+}
+
+/*
+SortOrder sorts the tracks by the given keys, the first key is the most important. The sort is stable:
+the tracks equal for all the keys keep their current relative order. The texts are compared ignoring the case
+Returns the new order, as the current indexes of the tracks
+*/
+func SortOrder(tracks []Track, keys []SortKey) []int {
+	order := make([]int, len(tracks))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		for _, k := range keys {
+			c := compareTracks(tracks[a], tracks[b], k.Field)
+			if k.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return order
+}
+
+// compareTracks compares two tracks by a field
+func compareTracks(a, b Track, field string) int {
+	switch field {
+	case SortTitle:
+		return cmp.Compare(utils.Lower(a.Name), utils.Lower(b.Name))
+	case SortArtist:
+		return cmp.Compare(utils.Lower(strings.Join(a.Artists, ", ")), utils.Lower(strings.Join(b.Artists, ", ")))
+	case SortAlbum:
+		return cmp.Compare(utils.Lower(a.Album), utils.Lower(b.Album))
+	case SortReleaseDate:
+		return cmp.Compare(a.ReleaseDate, b.ReleaseDate)
+	case SortAddedAt:
+		return cmp.Compare(a.AddedAt, b.AddedAt)
+	case SortDuration:
+		return cmp.Compare(a.Duration, b.Duration)
+	case SortPopularity:
+		return cmp.Compare(a.Popularity, b.Popularity)
+	default:
+		return 0
+	}
+}
+
+// Reorder moves RangeLength items starting from RangeStart before the item at InsertBefore (positions starting from 0), like the Spotify API
+type Reorder struct {
+	RangeStart   int
+	RangeLength  int
+	InsertBefore int
+}
+
+/*
+ReorderMoves returns the moves that change the current order of a playlist into the given one (the current indexes in the new order).
+The items of the longest increasing subsequence of the current indexes are already in the right relative order and are never moved,
+every other item is moved after the one that precedes it in the new order, so at most n - LIS moves are needed.
+Items to move that are adjacent in both orders are moved together with a single call
+Returns the moves, to apply in order
+*/
+func ReorderMoves(order []int) (moves []Reorder) {
+	keep := longestIncreasing(order)
+	current := make([]int, len(order))
+	for i := range current {
+		current[i] = i
+	}
+	for i := 0; i < len(order); i++ {
+		if keep[i] {
+			continue
+		}
+		j := slices.Index(current, order[i])
+		insert := 0
+		if i > 0 {
+			insert = slices.Index(current, order[i-1]) + 1
+		}
+		length := 1
+		for i+length < len(order) && !keep[i+length] && j+length < len(current) && current[j+length] == order[i+length] {
+			length++
+		}
+		i += length - 1
+		if j == insert {
+			continue
+		}
+		moves = append(moves, Reorder{RangeStart: j, RangeLength: length, InsertBefore: insert})
+		block := slices.Clone(current[j : j+length])
+		current = slices.Delete(current, j, j+length)
+		if insert > j {
+			insert -= length
+		}
+		current = slices.Insert(current, insert, block...)
+	}
+	return moves
+}
+
+// longestIncreasing marks the elements of a longest strictly increasing subsequence of values (patience sorting, O(n log n))
+func longestIncreasing(values []int) []bool {
+	// tails[k] is the index of the smallest value that ends an increasing subsequence of length k+1
+	var tails []int
+	prev := make([]int, len(values))
+	for i, v := range values {
+		k, _ := slices.BinarySearchFunc(tails, v, func(t int, v int) int { return cmp.Compare(values[t], v) })
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+	keep := make([]bool, len(values))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			keep[i] = true
+		}
+	}
+	return keep
+}
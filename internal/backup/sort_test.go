@@ -0,0 +1,102 @@
+package backup
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// applyMoves applies the moves to the items 0..n-1 like the Spotify API: InsertBefore is a position before the move
+func applyMoves(n int, moves []Reorder) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	for _, m := range moves {
+		block := slices.Clone(items[m.RangeStart : m.RangeStart+m.RangeLength])
+		items = slices.Delete(items, m.RangeStart, m.RangeStart+m.RangeLength)
+		insert := m.InsertBefore
+		if insert > m.RangeStart {
+			insert -= m.RangeLength
+		}
+		items = slices.Insert(items, insert, block...)
+	}
+	return items
+}
+
+func TestReorderMoves(t *testing.T) {
+	tests := []struct {
+		name  string
+		order []int
+		moves int
+	}{
+		{name: "empty", order: []int{}, moves: 0},
+		{name: "already sorted", order: []int{0, 1, 2, 3, 4}, moves: 0},
+		{name: "last to first", order: []int{4, 0, 1, 2, 3}, moves: 1},
+		{name: "first to last", order: []int{1, 2, 3, 4, 0}, moves: 1},
+		{name: "swap", order: []int{1, 0}, moves: 1},
+		{name: "one item out of place in the middle", order: []int{0, 1, 5, 2, 3, 4, 6, 7}, moves: 1},
+		{name: "interleaved", order: []int{1, 3, 5, 0, 2, 4}, moves: 3},
+		{name: "two items swapped far apart", order: []int{5, 1, 2, 3, 4, 0}, moves: 2},
+		// 3 and 4 are adjacent in both orders and are moved together
+		{name: "block moved", order: []int{3, 4, 0, 1, 2}, moves: 1},
+		{name: "reversed", order: []int{4, 3, 2, 1, 0}, moves: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moves := ReorderMoves(tt.order)
+			if len(moves) != tt.moves {
+				t.Errorf("ReorderMoves(%v) = %d moves %v, want %d", tt.order, len(moves), moves, tt.moves)
+			}
+			if got := applyMoves(len(tt.order), moves); !slices.Equal(got, tt.order) {
+				t.Errorf("applying %v gives %v, want %v", moves, got, tt.order)
+			}
+		})
+	}
+}
+
+// TestReorderMovesRandom checks on random permutations that the order is right and at most n - LIS moves are used
+func TestReorderMovesRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		order := r.Perm(r.Intn(40))
+		moves := ReorderMoves(order)
+		if got := applyMoves(len(order), moves); !slices.Equal(got, order) {
+			t.Fatalf("applying the moves of %v gives %v", order, got)
+		}
+		lis := 0
+		for _, k := range longestIncreasing(order) {
+			if k {
+				lis++
+			}
+		}
+		if len(moves) > len(order)-lis {
+			t.Fatalf("ReorderMoves(%v) = %d moves, want at most %d", order, len(moves), len(order)-lis)
+		}
+	}
+}
+
+func TestLongestIncreasing(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   int
+	}{
+		{values: nil, want: 0},
+		{values: []int{0}, want: 1},
+		{values: []int{3, 1, 2, 0}, want: 2},
+		{values: []int{0, 5, 1, 2, 6, 3, 4}, want: 5},
+		{values: []int{4, 3, 2, 1, 0}, want: 1},
+	}
+	for _, tt := range tests {
+		keep := longestIncreasing(tt.values)
+		var kept []int
+		for i, k := range keep {
+			if k {
+				kept = append(kept, tt.values[i])
+			}
+		}
+		if len(kept) != tt.want || !slices.IsSorted(kept) {
+			t.Errorf("longestIncreasing(%v) keeps %v, want an increasing subsequence of length %d", tt.values, kept, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package backup
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+// Criteria by which a playlist can be split
+const (
+	SplitCount   = "count"   // Parts with the same number of tracks
+	SplitDecade  = "decade"  // Release decade
+	SplitInitial = "initial" // Initial of the first artist
+	SplitMonth   = "month"   // Month the track was added to the playlist
+)
+
+// SplitCriteria returns the criteria by which a playlist can be split
+func SplitCriteria() []string {
+	return []string{SplitCount, SplitDecade, SplitInitial, SplitMonth}
+}
+
+// DefaultPartName is the default template of the names of the parts: {name} is the name of the playlist, {part} the key of the part, {n} its number
+const DefaultPartName = "{name} - {part}"
+
+// unknownPart returns the key of the tracks without the information used to split the playlist
+func unknownPart() string {
+	return i18n.T("backup.unknown")
+}
+
+// Part is a part of a split playlist
+type Part struct {
+	Key    string // Part number, decade, initial or month, depending on the criterion
+	Tracks []Track
+}
+
+/*
+Split divides the tracks of a playlist by a criterion, size is the number of tracks of each part when splitting by count.
+The tracks keep their order in every part, the parts are ordered by key (the tracks without the information are in the last part)
+Returns the parts and an error, if the criterion or the size are not valid
+*/
+func Split(tracks []Track, by string, size int) ([]Part, error) {
+	var key func(i int, t Track) string
+	switch by {
+	case SplitCount:
+		if size <= 0 {
+			return nil, fmt.Errorf(i18n.T("split.invalidSize"), size)
+		}
+		key = func(i int, t Track) string { return strconv.Itoa(i/size + 1) }
+	case SplitDecade:
+		key = func(i int, t Track) string {
+			year, err := strconv.Atoi(YearOf(t.ReleaseDate))
+			if err != nil {
+				return unknownPart()
+			}
+			return strconv.Itoa(year/10*10) + "s"
+		}
+	case SplitInitial:
+		key = func(i int, t Track) string {
+			if len(t.Artists) == 0 {
+				return unknownPart()
+			}
+			r, _ := utf8.DecodeRuneInString(utils.Normalise(t.Artists[0]))
+			if unicode.IsLetter(r) {
+				return strings.ToUpper(string(r))
+			}
+			return "#"
+		}
+	case SplitMonth:
+		key = func(i int, t Track) string {
+			if len(t.AddedAt) < 7 {
+				return unknownPart()
+			}
+			return t.AddedAt[:7]
+		}
+	default:
+		return nil, fmt.Errorf(i18n.T("split.invalidCriterion"), by, strings.Join(SplitCriteria(), ", "))
+	}
+
+	index := map[string]int{}
+	var parts []Part
+	for i, t := range tracks {
+		k := key(i, t)
+		j, ok := index[k]
+		if !ok {
+			j = len(parts)
+			index[k] = j
+			parts = append(parts, Part{Key: k})
+		}
+		parts[j].Tracks = append(parts[j].Tracks, t)
+	}
+	if by != SplitCount {
+		slices.SortStableFunc(parts, func(a, b Part) int {
+			if (a.Key == unknownPart()) != (b.Key == unknownPart()) {
+				if a.Key == unknownPart() {
+					return 1
+				}
+				return -1
+			}
+			return strings.Compare(a.Key, b.Key)
+		})
+	}
+	return parts, nil
+}
+
+// PartName returns the name of a part from the template (see DefaultPartName), n is the number of the part starting from 1
+func PartName(template string, name string, part Part, n int) string {
+	return strings.NewReplacer("{name}", name, "{part}", part.Key, "{n}", strconv.Itoa(n)).Replace(template)
+}
+
+/*
+Merge joins the tracks of several playlists in their order. If dedupe is true only the first occurrence of every track is kept
+Returns the tracks and the number of duplicates skipped
+*/
+func Merge(playlists []Playlist, dedupe bool) (tracks []Track, duplicates int) {
+	seen := map[string]bool{}
+	for _, p := range playlists {
+		for _, t := range p.Tracks() {
+			if dedupe && seen[t.Key()] {
+				duplicates++
+				continue
+			}
+			seen[t.Key()] = true
+			tracks = append(tracks, t)
+		}
+	}
+	return tracks, duplicates
+}
@@ -0,0 +1,141 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+)
+
+// partKeys returns the keys of the parts and the IDs of their tracks
+func partKeys(parts []Part) map[string]string {
+	keys := map[string]string{}
+	for _, p := range parts {
+		keys[p.Key] = trackIDs(p.Tracks)
+	}
+	return keys
+}
+
+func TestSplit(t *testing.T) {
+	tracks := []Track{
+		{ID: "a", Artists: []string{"Queen"}, ReleaseDate: "1975-10-31", AddedAt: "2024-03-10T10:00:00Z"},
+		{ID: "b", Artists: []string{"beatles"}, ReleaseDate: "1960", AddedAt: "2023-12-01T10:00:00Z"},
+		{ID: "c", Artists: []string{"2Pac"}, ReleaseDate: "1996-02", AddedAt: "2024-03-20T10:00:00Z"},
+		{ID: "d", ReleaseDate: "", AddedAt: ""},
+		{ID: "e", Artists: []string{"queen"}, ReleaseDate: "1979-01-01", AddedAt: "2023-12-31T23:59:59Z"},
+	}
+	tests := []struct {
+		by    string
+		size  int
+		order []string
+		want  map[string]string
+	}{
+		{by: SplitCount, size: 2, order: []string{"1", "2", "3"}, want: map[string]string{"1": "ab", "2": "cd", "3": "e"}},
+		{by: SplitCount, size: 10, order: []string{"1"}, want: map[string]string{"1": "abcde"}},
+		{by: SplitDecade, order: []string{"1960s", "1970s", "1990s", unknownPart()},
+			want: map[string]string{"1960s": "b", "1970s": "ae", "1990s": "c", unknownPart(): "d"}},
+		{by: SplitInitial, order: []string{"#", "B", "Q", unknownPart()},
+			want: map[string]string{"#": "c", "B": "b", "Q": "ae", unknownPart(): "d"}},
+		{by: SplitMonth, order: []string{"2023-12", "2024-03", unknownPart()},
+			want: map[string]string{"2023-12": "be", "2024-03": "ac", unknownPart(): "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			parts, err := Split(tracks, tt.by, tt.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var order []string
+			for _, p := range parts {
+				order = append(order, p.Key)
+			}
+			if !reflect.DeepEqual(order, tt.order) {
+				t.Errorf("parts = %q, want %q", order, tt.order)
+			}
+			if got := partKeys(parts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tracks of the parts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	tests := []struct {
+		by   string
+		size int
+	}{
+		{by: SplitCount, size: 0},
+		{by: SplitCount, size: -1},
+		{by: "genre", size: 10},
+		{by: "", size: 10},
+	}
+	for _, tt := range tests {
+		if _, err := Split(nil, tt.by, tt.size); err == nil {
+			t.Errorf("Split by %q with size %d must fail", tt.by, tt.size)
+		}
+	}
+	if parts, err := Split(nil, SplitDecade, 0); err != nil || len(parts) != 0 {
+		t.Errorf("Split of no tracks = %v, %v, want no parts", parts, err)
+	}
+}
+
+func TestPartName(t *testing.T) {
+	part := Part{Key: "1970s"}
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{template: DefaultPartName, want: "Rock - 1970s"},
+		{template: "{name} ({n})", want: "Rock (3)"},
+		{template: "{part} {part}", want: "1970s 1970s"},
+		{template: "Fissa", want: "Fissa"},
+	}
+	for _, tt := range tests {
+		if got := PartName(tt.template, "Rock", part, 3); got != tt.want {
+			t.Errorf("PartName(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name       string
+		playlists  []string
+		dedupe     bool
+		want       string
+		duplicates int
+	}{
+		{name: "keep duplicates", playlists: []string{"abb", "bc"}, dedupe: false, want: "abbbc"},
+		{name: "dedupe", playlists: []string{"abb", "bca"}, dedupe: true, want: "abc", duplicates: 3},
+		{name: "no duplicates", playlists: []string{"ab", "cd"}, dedupe: true, want: "abcd"},
+		{name: "empty", playlists: []string{"", ""}, dedupe: true, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var playlists []Playlist
+			for _, ids := range tt.playlists {
+				playlists = append(playlists, playlistOf(ids))
+			}
+			tracks, duplicates := Merge(playlists, tt.dedupe)
+			if got := trackIDs(tracks); got != tt.want || duplicates != tt.duplicates {
+				t.Errorf("Merge = %q, %d, want %q, %d", got, duplicates, tt.want, tt.duplicates)
+			}
+		})
+	}
+}
+
+func TestYearOf(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{date: "1975-10-31", want: "1975"},
+		{date: "1991-02", want: "1991"},
+		{date: "2020", want: "2020"},
+		{date: "", want: ""},
+		{date: "0", want: ""},
+	}
+	for _, tt := range tests {
+		if got := YearOf(tt.date); got != tt.want {
+			t.Errorf("YearOf(%q) = %q, want %q", tt.date, got, tt.want)
+		}
+	}
+}
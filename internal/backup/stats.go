@@ -0,0 +1,166 @@
+package backup
+
+import (
+	"cmp"
+	"playlist-manager/pkg/i18n"
+	"slices"
+)
+
+// unknownYear returns the key of the histogram for the items without a release date
+func unknownYear() string {
+	return i18n.T("backup.unknown")
+}
+
+// Count is the number of items of a playlist with the same artist, album, year or user that added them
+type Count struct {
+	Name  string `json:"name"`
+	Items int    `json:"items"`
+}
+
+// Stats are the statistics of a playlist (or of the whole library)
+type Stats struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Items       int    `json:"items"`
+	Tracks      int    `json:"tracks"`
+	Episodes    int    `json:"episodes"`
+	Local       int    `json:"local"`
+	Unavailable int    `json:"unavailable"`
+	Duration    int    `json:"duration_ms"`
+	Explicit    int    `json:"explicit"`
+	// Most frequent artists and albums, in descending order
+	Artists []Count `json:"top_artists"`
+	Albums  []Count `json:"top_albums"`
+	// Number of items for each release year, from the oldest (the items without release date are the last ones)
+	Years []Count `json:"years"`
+	// Number of items added by each user, in descending order (empty for the library and the older backups)
+	AddedBy []Count `json:"added_by,omitempty"`
+}
+
+// ExplicitShare returns the percentage of explicit items
+func (s Stats) ExplicitShare() float64 {
+	if s.Items == 0 {
+		return 0
+	}
+	return float64(s.Explicit) * 100 / float64(s.Items)
+}
+
+/*
+ComputeStats computes the statistics of a playlist, top is the number of artists and albums kept (0 to keep all of them).
+An artist is counted once for every item, also when it is one of many artists of a track
+Returns the statistics
+*/
+func ComputeStats(p Playlist, top int) Stats {
+	s := Stats{ID: string(p.ID), Name: p.Name, Unavailable: p.Unavailable}
+	artists := map[string]int{}
+	albums := map[string]int{}
+	years := map[string]int{}
+	addedBy := map[string]int{}
+	for _, t := range p.Tracks() {
+		s.Items++
+		switch t.Type {
+		case ItemEpisode:
+			s.Episodes++
+		case ItemLocal:
+			s.Local++
+		default:
+			s.Tracks++
+		}
+		s.Duration += t.Duration
+		if t.Explicit {
+			s.Explicit++
+		}
+		for _, a := range t.Artists {
+			artists[a]++
+		}
+		if t.Album != "" {
+			// Albums with the same name by different artists are different albums
+			album := t.Album
+			if len(t.Artists) > 0 && t.Type != ItemEpisode {
+				album += " - " + t.Artists[0]
+			}
+			albums[album]++
+		}
+		year := YearOf(t.ReleaseDate)
+		if year == "" {
+			year = unknownYear()
+		}
+		years[year]++
+		if t.AddedBy != "" {
+			addedBy[t.AddedBy]++
+		}
+	}
+	s.Artists = topCounts(artists, top)
+	s.Albums = topCounts(albums, top)
+	s.AddedBy = topCounts(addedBy, 0)
+	s.Years = topCounts(years, 0)
+	// The years have four digits, so the unknown year is after them
+	slices.SortFunc(s.Years, func(a, b Count) int { return cmp.Compare(a.Name, b.Name) })
+	return s
+}
+
+// topCounts returns the counts of the map in descending order (by name when equal), only the first top ones if top is greater than 0
+func topCounts(m map[string]int, top int) []Count {
+	counts := make([]Count, 0, len(m))
+	for name, n := range m {
+		counts = append(counts, Count{Name: name, Items: n})
+	}
+	slices.SortFunc(counts, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Items, a.Items), cmp.Compare(a.Name, b.Name))
+	})
+	if top > 0 && len(counts) > top {
+		counts = counts[:top]
+	}
+	return counts
+}
+
+// Overlap is the number of items in common between every pair of playlists
+type Overlap struct {
+	Names []string `json:"names"`
+	// Shared[i][j] is the number of distinct items both in the playlist i and j, Shared[i][i] the distinct items of the playlist i
+	Shared [][]int `json:"shared"`
+}
+
+/*
+ComputeOverlap computes the overlap matrix of the playlists, the items are compared by URI (see Track.Key)
+Returns the overlap
+*/
+func ComputeOverlap(playlists []Playlist) Overlap {
+	o := Overlap{Names: make([]string, len(playlists)), Shared: make([][]int, len(playlists))}
+	keys := make([][]string, len(playlists))
+	for i, p := range playlists {
+		o.Names[i] = p.Name
+		for _, t := range p.Tracks() {
+			keys[i] = append(keys[i], t.Key())
+		}
+	}
+	for i := range playlists {
+		o.Shared[i] = make([]int, len(playlists))
+		for j := range i + 1 {
+			n := len(Intersection(keys[i], keys[j]))
+			o.Shared[i][j] = n
+			o.Shared[j][i] = n
+		}
+	}
+	return o
+}
+
+// OverlapPair is a pair of playlists with items in common, I and J are the indexes in Overlap.Names
+type OverlapPair struct {
+	I, J   int
+	Shared int
+}
+
+// Pairs returns the pairs of different playlists with at least an item in common, from the one with the most items in common
+func (o Overlap) Pairs() []OverlapPair {
+	var pairs []OverlapPair
+	for i := range o.Shared {
+		for j := i + 1; j < len(o.Shared); j++ {
+			if o.Shared[i][j] > 0 {
+				pairs = append(pairs, OverlapPair{I: i, J: j, Shared: o.Shared[i][j]})
+			}
+		}
+	}
+	slices.SortStableFunc(pairs, func(a, b OverlapPair) int { return cmp.Compare(b.Shared, a.Shared) })
+	return pairs
+}
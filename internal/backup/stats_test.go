@@ -0,0 +1,117 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeStats(t *testing.T) {
+	p := Playlist{ID: "p1", Name: "Rock", Unavailable: 2, Items: []Track{
+		{URI: "spotify:track:a", Artists: []string{"Queen", "David Bowie"}, Album: "Hot Space", ReleaseDate: "1982-05-21", Duration: 1000, Explicit: true, AddedBy: "mario"},
+		{URI: "spotify:track:b", Artists: []string{"Queen"}, Album: "Innuendo", ReleaseDate: "1991", Duration: 2000, AddedBy: "luigi"},
+		{URI: "spotify:track:c", Artists: []string{"Queen"}, Album: "Innuendo", ReleaseDate: "1991-02", Duration: 3000, AddedBy: "mario"},
+		{URI: "spotify:episode:d", Type: ItemEpisode, Artists: []string{"Podcast"}, Album: "Show", ReleaseDate: "2020-01-01", Duration: 500},
+		{URI: "spotify:local:e", Type: ItemLocal, Artists: []string{"Band"}, Album: "Innuendo", Duration: 100},
+	}}
+
+	tests := []struct {
+		name    string
+		top     int
+		artists []Count
+		albums  []Count
+	}{
+		{name: "all", top: 0,
+			artists: []Count{{"Queen", 3}, {"Band", 1}, {"David Bowie", 1}, {"Podcast", 1}},
+			albums:  []Count{{"Innuendo - Queen", 2}, {"Hot Space - Queen", 1}, {"Innuendo - Band", 1}, {"Show", 1}}},
+		{name: "top 2", top: 2,
+			artists: []Count{{"Queen", 3}, {"Band", 1}},
+			albums:  []Count{{"Innuendo - Queen", 2}, {"Hot Space - Queen", 1}}},
+		{name: "top greater than the counts", top: 10,
+			artists: []Count{{"Queen", 3}, {"Band", 1}, {"David Bowie", 1}, {"Podcast", 1}},
+			albums:  []Count{{"Innuendo - Queen", 2}, {"Hot Space - Queen", 1}, {"Innuendo - Band", 1}, {"Show", 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ComputeStats(p, tt.top)
+			if s.ID != "p1" || s.Name != "Rock" || s.Items != 5 || s.Tracks != 3 || s.Episodes != 1 || s.Local != 1 ||
+				s.Unavailable != 2 || s.Duration != 6600 || s.Explicit != 1 {
+				t.Errorf("ComputeStats = %+v", s)
+			}
+			if !reflect.DeepEqual(s.Artists, tt.artists) {
+				t.Errorf("artists = %v, want %v", s.Artists, tt.artists)
+			}
+			if !reflect.DeepEqual(s.Albums, tt.albums) {
+				t.Errorf("albums = %v, want %v", s.Albums, tt.albums)
+			}
+			// The years and the users are never cut by top
+			years := []Count{{"1982", 1}, {"1991", 2}, {"2020", 1}, {unknownYear(), 1}}
+			if !reflect.DeepEqual(s.Years, years) {
+				t.Errorf("years = %v, want %v", s.Years, years)
+			}
+			addedBy := []Count{{"mario", 2}, {"luigi", 1}}
+			if !reflect.DeepEqual(s.AddedBy, addedBy) {
+				t.Errorf("added by = %v, want %v", s.AddedBy, addedBy)
+			}
+		})
+	}
+}
+
+func TestComputeStatsEmpty(t *testing.T) {
+	s := ComputeStats(Playlist{Name: "Vuota"}, 5)
+	if s.Items != 0 || len(s.Artists) != 0 || len(s.Albums) != 0 || len(s.Years) != 0 || len(s.AddedBy) != 0 {
+		t.Errorf("ComputeStats of an empty playlist = %+v", s)
+	}
+	if s.ExplicitShare() != 0 {
+		t.Errorf("ExplicitShare of an empty playlist = %v, want 0", s.ExplicitShare())
+	}
+}
+
+func TestExplicitShare(t *testing.T) {
+	tests := []struct {
+		items, explicit int
+		want            float64
+	}{
+		{items: 0, explicit: 0, want: 0},
+		{items: 4, explicit: 1, want: 25},
+		{items: 3, explicit: 3, want: 100},
+	}
+	for _, tt := range tests {
+		if got := (Stats{Items: tt.items, Explicit: tt.explicit}).ExplicitShare(); got != tt.want {
+			t.Errorf("ExplicitShare with %d of %d = %v, want %v", tt.explicit, tt.items, got, tt.want)
+		}
+	}
+}
+
+func TestComputeOverlap(t *testing.T) {
+	a := playlistOf("abcc")
+	a.Name = "A"
+	b := playlistOf("bcd")
+	b.Name = "B"
+	c := playlistOf("xy")
+	c.Name = "C"
+	d := playlistOf("d")
+	d.Name = "D"
+
+	o := ComputeOverlap([]Playlist{a, b, c, d})
+	if !reflect.DeepEqual(o.Names, []string{"A", "B", "C", "D"}) {
+		t.Errorf("names = %v", o.Names)
+	}
+	// The repeated items are counted once
+	shared := [][]int{
+		{3, 2, 0, 0},
+		{2, 3, 0, 1},
+		{0, 0, 2, 0},
+		{0, 1, 0, 1},
+	}
+	if !reflect.DeepEqual(o.Shared, shared) {
+		t.Errorf("shared = %v, want %v", o.Shared, shared)
+	}
+	pairs := []OverlapPair{{I: 0, J: 1, Shared: 2}, {I: 1, J: 3, Shared: 1}}
+	if got := o.Pairs(); !reflect.DeepEqual(got, pairs) {
+		t.Errorf("pairs = %v, want %v", got, pairs)
+	}
+
+	if o := ComputeOverlap(nil); len(o.Names) != 0 || len(o.Shared) != 0 || o.Pairs() != nil {
+		t.Errorf("ComputeOverlap(nil) = %+v", o)
+	}
+}
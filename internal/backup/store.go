@@ -0,0 +1,542 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"playlist-manager/pkg/i18n"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+Content-addressed store: every state of a playlist (and every cover image) is saved only once in data/backup/<userID>/store/objects,
+named after the SHA-256 of its content, and every dated backup is a manifest (data/backup/<userID>/store/manifests/<date>.json)
+that lists the playlists saved that day with the hash of their state.
+A manifest lists only the playlists saved that day, so the state at a date is the newest entry of every playlist up to it;
+the playlists deleted on Spotify are recorded as removed in the manifest of the backup that noticed it, so their older states are left out from then on
+*/
+
+// StoreDir is the subfolder (inside the user folder) containing the content-addressed store
+const StoreDir = "store"
+
+// Manifest is a dated backup of the store, it references the states of the playlists saved that day
+type Manifest struct {
+	User    string          `json:"user"`
+	Date    string          `json:"date"`
+	Entries []ManifestEntry `json:"playlists"`
+	Removed []api.ID        `json:"removed,omitempty"` // Personal playlists no longer on Spotify at this date
+}
+
+// ManifestEntry is a playlist saved in a dated backup of the store
+type ManifestEntry struct {
+	ID       api.ID `json:"id"`
+	Name     string `json:"name"`
+	Hash     string `json:"hash"`
+	Personal bool   `json:"personal"`
+	Cover    string `json:"cover,omitempty"` // Hash of the cover image, saved as an object
+}
+
+// HistoryEntry is a dated state of a playlist in the store
+type HistoryEntry struct {
+	Date    string
+	Hash    string
+	Changed bool // True if the state is different from the previous one
+}
+
+// VerifyResult contains the problems found by Verify
+type VerifyResult struct {
+	Objects   int
+	Manifests int
+	Corrupted []string // Objects whose content doesn't match the hash
+	Missing   []string // Objects referenced by a manifest that don't exist, as "<date>: <hash>"
+	Unused    []string // Objects not referenced by any manifest
+}
+
+// OK returns true if no corrupted or missing object has been found
+func (v VerifyResult) OK() bool {
+	return len(v.Corrupted) == 0 && len(v.Missing) == 0
+}
+
+// storeDir returns the folder of the store of a user
+func storeDir(userID string) string {
+	return filepath.Join(Dir, userID, StoreDir)
+}
+
+/*
+ObjectPath returns the path of the object with the given hash in the store of a user
+Returns the path and an error, if the hash is not a valid SHA-256
+*/
+func ObjectPath(userID string, hash string) (string, error) {
+	err := checkHash(hash)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(storeDir(userID), "objects", hash[:2], hash+".json"), nil
+}
+
+// manifestPath returns the path of the manifest of a date in the store of a user
+func manifestPath(userID string, date string) string {
+	return filepath.Join(storeDir(userID), "manifests", date+".json")
+}
+
+// hashData returns the SHA-256 of the data as hex string
+func hashData(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkHash returns an error if the hash is not a SHA-256 as hex string (see hashData), for example in a manifest edited by hand
+func checkHash(hash string) error {
+	if len(hash) != 2*sha256.Size || strings.ToLower(hash) != hash {
+		return fmt.Errorf(i18n.T("store.invalidHash"), hash)
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return fmt.Errorf(i18n.T("store.invalidHash"), hash)
+	}
+	return nil
+}
+
+/*
+ManifestDates returns the dates of the manifests in the store of a user, sorted from the oldest to the newest
+Returns the dates and an error, if present
+*/
+func ManifestDates(userID string) (dates []string, err error) {
+	entries, err := os.ReadDir(filepath.Join(storeDir(userID), "manifests"))
+	if errors.Is(err, os.ErrNotExist) {
+		return dates, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
+			dates = append(dates, strings.TrimSuffix(e.Name(), ".json"))
+		}
+	}
+	sort.Strings(dates)
+	return dates, nil
+}
+
+/*
+ReadManifest reads the manifest of a date in the store of a user, if it doesn't exist an empty manifest is returned
+Returns the manifest and an error, if present
+*/
+func ReadManifest(userID string, date string) (m Manifest, err error) {
+	m = Manifest{User: userID, Date: date}
+	data, err := os.ReadFile(manifestPath(userID, date))
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	} else if err != nil {
+		return m, err
+	}
+	err = json.Unmarshal(data, &m)
+	return m, err
+}
+
+/*
+writeManifest saves the manifest in the store, removing it if it has no entries and no removed playlists
+Returns an error, if present
+*/
+func writeManifest(m Manifest) error {
+	path := manifestPath(m.User, m.Date)
+	if len(m.Entries) == 0 && len(m.Removed) == 0 {
+		err := os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	sort.Slice(m.Entries, func(i, j int) bool { return m.Entries[i].ID < m.Entries[j].ID })
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+/*
+putObject saves the playlist in the store, if an object with the same content doesn't exist yet
+Returns the hash of the object and an error, if present
+*/
+func putObject(userID string, p Playlist) (hash string, err error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	hash = hashData(data)
+	path, err := ObjectPath(userID, hash)
+	if err != nil {
+		return "", err
+	}
+	if exists(path) {
+		return hash, nil
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return "", err
+	}
+	_, err = writeFile(path, data)
+	return hash, err
+}
+
+/*
+StorePut saves the state of a playlist in the store and adds it to the manifest of the date, replacing a previous entry of the same playlist
+Returns the path of the object, true if the state is the same as the latest one saved before, and an error, if present
+*/
+func StorePut(userID string, personal bool, p Playlist, date string) (path string, unchanged bool, err error) {
+	prev, found, err := storeLatest(userID, p.ID, date)
+	if err != nil {
+		return "", false, err
+	}
+	hash, err := putObject(userID, p)
+	if err != nil {
+		return "", false, err
+	}
+	if len(p.CoverImage) > 0 {
+		path, err := coverObjectPath(userID, p.Cover)
+		if err != nil {
+			return "", false, err
+		}
+		if !exists(path) {
+			err = os.MkdirAll(filepath.Dir(path), 0755)
+			if err != nil {
+				return "", false, err
+			}
+			_, err = writeFile(path, p.CoverImage)
+			if err != nil {
+				return "", false, err
+			}
+		}
+	}
+
+	m, err := ReadManifest(userID, date)
+	if err != nil {
+		return "", false, err
+	}
+	entry := ManifestEntry{ID: p.ID, Name: p.Name, Hash: hash, Personal: personal, Cover: p.Cover}
+	replaced := false
+	for i, e := range m.Entries {
+		if e.ID == p.ID {
+			m.Entries[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		m.Entries = append(m.Entries, entry)
+	}
+	m.Removed = slices.DeleteFunc(m.Removed, func(id api.ID) bool { return id == p.ID })
+	err = writeManifest(m)
+	if err != nil {
+		return "", false, err
+	}
+	path, err = ObjectPath(userID, hash)
+	return path, found && prev.Hash == hash, err
+}
+
+/*
+storeLatest returns the newest entry of a playlist in the manifests before the given date (excluded)
+Returns the entry, false if not found, and an error, if present
+*/
+func storeLatest(userID string, id api.ID, before string) (e ManifestEntry, found bool, err error) {
+	dates, err := ManifestDates(userID)
+	if err != nil {
+		return e, false, err
+	}
+	for i := len(dates) - 1; i >= 0; i-- {
+		if dates[i] >= before {
+			continue
+		}
+		m, err := ReadManifest(userID, dates[i])
+		if err != nil {
+			return e, false, err
+		}
+		for _, entry := range m.Entries {
+			if entry.ID == id {
+				return entry, true, nil
+			}
+		}
+	}
+	return e, false, nil
+}
+
+/*
+History returns the dated states of a playlist in the store, from the oldest to the newest
+Returns the history and an error, if present
+*/
+func History(userID string, id api.ID) (history []HistoryEntry, err error) {
+	dates, err := ManifestDates(userID)
+	if err != nil {
+		return nil, err
+	}
+	prev := ""
+	for _, d := range dates {
+		m, err := ReadManifest(userID, d)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range m.Entries {
+			if e.ID == id {
+				history = append(history, HistoryEntry{Date: d, Hash: e.Hash, Changed: e.Hash != prev})
+				prev = e.Hash
+			}
+		}
+	}
+	return history, nil
+}
+
+/*
+stateAt returns the entries of the playlists in the store at the given date: the newest entry of every playlist saved on or before the date,
+without the playlists removed after it was saved
+Returns the entries and an error, if present
+*/
+func stateAt(userID string, date string) (entries []ManifestEntry, err error) {
+	dates, err := ManifestDates(userID)
+	if err != nil {
+		return nil, err
+	}
+	found := map[api.ID]bool{}
+	for i := len(dates) - 1; i >= 0; i-- {
+		if dates[i] > date {
+			continue
+		}
+		m, err := ReadManifest(userID, dates[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range m.Entries {
+			if !found[e.ID] {
+				found[e.ID] = true
+				entries = append(entries, e)
+			}
+		}
+		// A removal hides the older states of the playlist, the entries of the same manifest have already been taken
+		for _, id := range m.Removed {
+			found[id] = true
+		}
+	}
+	return entries, nil
+}
+
+/*
+PointInTime reconstructs the backup of a user at the given date: for every playlist in the store the newest state saved on or before the date,
+the playlists removed on or before the date are left out
+Returns the files (one for each playlist) and an error, if present
+*/
+func PointInTime(userID string, date string) (files []File, err error) {
+	entries, err := stateAt(userID, date)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		path, err := ObjectPath(userID, e.Hash)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("store.readState"), e.Hash, e.ID, err)
+		}
+		p, err := Read(path)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("store.readState"), e.Hash, e.ID, err)
+		}
+		files = append(files, File{Path: path, Playlist: p})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Playlist.Name < files[j].Playlist.Name })
+	return files, nil
+}
+
+/*
+StoreRemoved records in the manifest of the date the personal playlists of the store that are not among the current ones,
+so PointInTime doesn't bring them back from the older manifests. current must contain all the personal playlists of the user, the library backups are never removed
+Returns the IDs of the playlists recorded as removed and an error, if present
+*/
+func StoreRemoved(userID string, current []api.ID, date string) (removed []api.ID, err error) {
+	entries, err := stateAt(userID, date)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Personal && !slices.Contains(current, e.ID) && !slices.Contains(LibraryKinds(), Kind(e.ID)) {
+			removed = append(removed, e.ID)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	m, err := ReadManifest(userID, date)
+	if err != nil {
+		return nil, err
+	}
+	m.Removed = append(m.Removed, removed...)
+	return removed, writeManifest(m)
+}
+
+/*
+storeFiles returns the playlists saved in the manifest of a date, only the personal ones or the ones of other users
+Returns the files and an error, if present
+*/
+func storeFiles(userID string, personal bool, date string) (files []File, err error) {
+	m, err := ReadManifest(userID, date)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range m.Entries {
+		if e.Personal != personal {
+			continue
+		}
+		path, err := ObjectPath(userID, e.Hash)
+		if err != nil {
+			log.Warn(i18n.T("log.storeStateReadError"), "hash", e.Hash, "playlistID", e.ID, "error", err)
+			continue
+		}
+		p, err := Read(path)
+		if err != nil {
+			log.Warn(i18n.T("log.storeStateReadError"), "file", path, "playlistID", e.ID, "error", err)
+			continue
+		}
+		files = append(files, File{Path: path, Playlist: p})
+	}
+	return files, nil
+}
+
+/*
+Verify checks the integrity of the store of a user: the content of every object must match its hash (after the decryption, if encrypted)
+and every object referenced by a manifest must exist
+Returns the result and an error, if present
+*/
+func Verify(userID string) (res VerifyResult, err error) {
+	referenced := map[string]bool{}
+	dates, err := ManifestDates(userID)
+	if err != nil {
+		return res, err
+	}
+	for _, d := range dates {
+		m, err := ReadManifest(userID, d)
+		if err != nil {
+			return res, fmt.Errorf(i18n.T("store.readManifest"), d, err)
+		}
+		res.Manifests++
+		for _, e := range m.Entries {
+			// An invalid hash can't reference an existing object, it is reported as missing
+			referenced[e.Hash] = true
+			if path, err := ObjectPath(userID, e.Hash); err != nil || !exists(path) {
+				res.Missing = append(res.Missing, d+": "+e.Hash)
+			}
+			if e.Cover != "" {
+				referenced[e.Cover] = true
+				if path, err := coverObjectPath(userID, e.Cover); err != nil || !exists(path) {
+					res.Missing = append(res.Missing, d+": "+e.Cover+CoverExt)
+				}
+			}
+		}
+	}
+
+	err = filepath.WalkDir(filepath.Join(storeDir(userID), "objects"), func(path string, d os.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) {
+			return filepath.SkipDir
+		} else if err != nil || d.IsDir() {
+			return err
+		}
+		res.Objects++
+		hash := trimExt(d.Name(), filepath.Ext(strings.TrimSuffix(d.Name(), EncryptedExt)))
+		data, err := readFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			// The encrypted object can't be decrypted: wrong key or altered content
+			log.Warn(i18n.T("log.storeDecryptError"), "file", path, "error", err)
+			res.Corrupted = append(res.Corrupted, path)
+		} else if err != nil {
+			return err
+		} else if hashData(data) != hash {
+			res.Corrupted = append(res.Corrupted, path)
+		}
+		if !referenced[hash] {
+			res.Unused = append(res.Unused, path)
+		}
+		return nil
+	})
+	return res, err
+}
+
+/*
+gcObjects removes the objects of the store not referenced by any manifest
+Returns the number of objects removed and an error, if present
+*/
+func gcObjects(userID string) (int, error) {
+	res, err := Verify(userID)
+	if err != nil {
+		return 0, err
+	}
+	for _, path := range res.Unused {
+		err = os.Remove(path)
+		if err != nil {
+			return 0, err
+		}
+		log.Info(i18n.T("log.storeStateRemoved"), "file", path)
+	}
+	return len(res.Unused), nil
+}
+
+/*
+removeFromManifest removes a playlist from the manifest of a date
+Returns an error, if present
+*/
+func removeFromManifest(userID string, date string, id api.ID) error {
+	m, err := ReadManifest(userID, date)
+	if err != nil {
+		return err
+	}
+	entries := m.Entries[:0]
+	for _, e := range m.Entries {
+		if e.ID != id {
+			entries = append(entries, e)
+		}
+	}
+	m.Entries = entries
+	return writeManifest(m)
+}
+
+/*
+ImportToStore copies all the backup files of a user (saved in the date folders) to the store, the files are not removed
+Returns the number of files imported and an error, if present
+*/
+func ImportToStore(userID string) (imported int, err error) {
+	for _, personal := range []bool{true, false} {
+		dir := UserDir(userID, personal)
+		dates, err := Dates(dir)
+		if err != nil {
+			return imported, err
+		}
+		for _, d := range dates {
+			if _, err := time.Parse(DateLayout, d); err != nil {
+				continue
+			}
+			files, err := List(filepath.Join(dir, d))
+			if err != nil {
+				return imported, err
+			}
+			for _, f := range files {
+				f.Playlist.CoverImage, err = ReadCover(f)
+				if err != nil {
+					return imported, err
+				}
+				_, _, err = StorePut(userID, personal, f.Playlist, d)
+				if err != nil {
+					return imported, err
+				}
+				imported++
+			}
+		}
+	}
+	return imported, nil
+}
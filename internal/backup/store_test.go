@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+func TestObjectPath(t *testing.T) {
+	valid := hashData([]byte("playlist"))
+	tests := []struct {
+		name    string
+		hash    string
+		wantErr bool
+	}{
+		{name: "valid", hash: valid},
+		{name: "empty", hash: "", wantErr: true},
+		{name: "short", hash: "a", wantErr: true},
+		{name: "truncated", hash: valid[:12], wantErr: true},
+		{name: "upper case", hash: strings.ToUpper(valid), wantErr: true},
+		{name: "not hex", hash: strings.Repeat("z", len(valid)), wantErr: true},
+		{name: "path traversal", hash: "../" + valid[3:], wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ObjectPath("user", tt.hash)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ObjectPath(%q) error = %v, want error %v", tt.hash, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if want := filepath.Join(Dir, "user", StoreDir, "objects", tt.hash[:2], tt.hash+".json"); path != want {
+				t.Errorf("ObjectPath = %q, want %q", path, want)
+			}
+			if _, err := coverObjectPath("user", tt.hash); err != nil {
+				t.Errorf("coverObjectPath error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCoverPathInvalidHash(t *testing.T) {
+	f := File{Path: filepath.Join(Dir, "user", StoreDir, "objects", "ab", "abc.json"), Playlist: Playlist{Cover: "ab"}}
+	if _, err := CoverPath(f); err == nil {
+		t.Error("CoverPath with an invalid hash in the store must return an error")
+	}
+}
+
+func TestPointInTime(t *testing.T) {
+	inTempDir(t)
+	put := func(date string, p Playlist) {
+		t.Helper()
+		if _, _, err := StorePut("user", true, p, date); err != nil {
+			t.Fatal(err)
+		}
+	}
+	playlist := func(id string, name string) Playlist {
+		return Playlist{ID: api.ID(id), Name: name, TrackIDs: []api.ID{}}
+	}
+
+	put("2024-01-01", playlist("p1", "A"))
+	put("2024-01-01", playlist("p2", "B"))
+	put("2024-01-01", playlist("p3", "C"))
+	put("2024-01-01", LibraryPlaylist(KindLikedSongs))
+	// p2 is deleted on Spotify before the second backup of all the playlists
+	put("2024-02-01", playlist("p1", "A2"))
+	put("2024-02-01", playlist("p3", "C"))
+	removed, err := StoreRemoved("user", []api.ID{"p1", "p3"}, "2024-02-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(removed, []api.ID{"p2"}) {
+		t.Errorf("StoreRemoved = %v, want [p2]", removed)
+	}
+	// Only p1 is saved, p3 is missing from the newest manifest but still exists
+	put("2024-03-01", playlist("p1", "A3"))
+
+	liked := LibraryPlaylist(KindLikedSongs).Name // Sorted between B and C
+	tests := []struct {
+		date string
+		want []string
+	}{
+		{date: "2023-12-31", want: nil},
+		{date: "2024-01-01", want: []string{"A", "B", liked, "C"}},
+		{date: "2024-01-31", want: []string{"A", "B", liked, "C"}},
+		{date: "2024-02-01", want: []string{"A2", liked, "C"}},
+		{date: "2024-03-15", want: []string{"A3", liked, "C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			files, err := PointInTime("user", tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Playlist.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("PointInTime(%s) = %v, want %v", tt.date, names, tt.want)
+			}
+		})
+	}
+}
@@ -3,30 +3,61 @@ package config
 import (
 	"fmt"
 	"os"
+	"playlist-manager/pkg/i18n"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	LogLevel string
+	// Way the backups are saved: files (a JSON file for each playlist in a date folder) or store (content-addressed, see backup.ModeStore)
+	BackupMode string
+	// Format of the archive created after backing up all the playlists (tar.gz or zip), empty to not create it
+	BackupArchive string
+	// Backup retention policy, 0 disables the rule (see backup.Policy)
+	BackupKeepLast    int
+	BackupKeepDaily   int
+	BackupKeepWeekly  int
+	BackupKeepMonthly int
+	// Backup encryption (see backup.InitEncryption): passphrase, age public keys (comma separated) and file of the age private keys
+	BackupPassphrase   string
+	BackupRecipients   string
+	BackupIdentityFile string
+	// Interface of the interactive menus: tui (full-screen, see tui.Init) or classic (numbered lists)
+	TerminalUI string
+	// Language of the messages (it or en, see i18n.Init), empty to use the one of the locale
+	Language string
 }
 
-var Envs = initConfig()
+// Envs is the configuration of the program, set by Init
+var Envs Config
 
-// InitConfig initializes the configuration by reading the environment variables stored in the .env file.
-func initConfig() Config {
+// Init initializes the configuration by reading the environment variables stored in the .env file, it must be called before using Envs.
+func Init() {
 	// Load the .env file
 	err := godotenv.Load()
 	if err != nil {
-		fmt.Println("Errore durante il caricamento del file .env. Controlla che sia presente nella stessa cartella dell'eseguibile e che contenga le informazioni necessarie come da documentazione.")
+		fmt.Println(i18n.T("config.loadError"))
 		fmt.Println()
-		fmt.Print("Premi invio per uscire...")
+		fmt.Print(i18n.T("config.pressEnterExit"))
 		fmt.Scanf("\n")
 		panic("CONFIG: Error loading .env file")
 	}
 
-	return Config{
-		LogLevel: getEnv("LOG_LEVEL", "WARN"),
+	Envs = Config{
+		LogLevel:           getEnv("LOG_LEVEL", "WARN"),
+		BackupMode:         getEnv("BACKUP_MODE", "files"),
+		BackupArchive:      getEnv("BACKUP_ARCHIVE", ""),
+		BackupKeepLast:     getEnvInt("BACKUP_KEEP_LAST", 0),
+		BackupKeepDaily:    getEnvInt("BACKUP_KEEP_DAILY", 0),
+		BackupKeepWeekly:   getEnvInt("BACKUP_KEEP_WEEKLY", 0),
+		BackupKeepMonthly:  getEnvInt("BACKUP_KEEP_MONTHLY", 0),
+		BackupPassphrase:   getEnv("BACKUP_PASSPHRASE", ""),
+		BackupRecipients:   getEnv("BACKUP_RECIPIENTS", ""),
+		BackupIdentityFile: getEnv("BACKUP_IDENTITY_FILE", ""),
+		TerminalUI:         getEnv("TERMINAL_UI", "tui"),
+		Language:           getEnv("APP_LANGUAGE", ""),
 	}
 }
 
@@ -37,3 +68,17 @@ func getEnv(key string, fallback string) string {
 	}
 	return fallback
 }
+
+// GetEnvInt returns the value of an environment variable as an int if it exists and is valid, otherwise it returns the fallback value, provided as a parameter.
+func getEnvInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		fmt.Print(i18n.T("config.invalidValue", key, value, fallback))
+		return fallback
+	}
+	return i
+}
@@ -0,0 +1,285 @@
+// Contents: export of the playlists to file formats that can be read by other programs
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"playlist-manager/internal/backup"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+// Dir is the folder where the exported files are saved
+const Dir = "data/export"
+
+// artistSeparator separates the artists of a track in all the formats, that have a single text field for them.
+// Commas and "&" are part of many names (for example "Earth, Wind & Fire"), so they can't be used
+const artistSeparator = "; "
+
+// Format is a file format supported by the exporter
+type Format string
+
+const (
+	FormatM3U8 Format = "m3u8"
+	FormatXSPF Format = "xspf"
+	FormatJSPF Format = "jspf"
+	FormatCSV  Format = "csv"
+)
+
+// Formats returns the supported formats, in the order they are shown in the menus
+func Formats() []Format {
+	return []Format{FormatM3U8, FormatXSPF, FormatJSPF, FormatCSV}
+}
+
+/*
+ParseFormat converts a string (for example a file extension or a CLI flag) to a Format
+Returns the format and an error if the format is not supported
+*/
+func ParseFormat(s string) (Format, error) {
+	f := Format(utils.Lower(strings.TrimPrefix(s, ".")))
+	if f == "m3u" {
+		f = FormatM3U8
+	}
+	for _, supported := range Formats() {
+		if f == supported {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf(i18n.T("export.unsupported"), s)
+}
+
+/*
+Write writes the playlist to w in the given format
+Returns an error, if present
+*/
+func Write(w io.Writer, p backup.Playlist, f Format) error {
+	switch f {
+	case FormatM3U8:
+		return writeM3U8(w, p)
+	case FormatXSPF:
+		return writeXSPF(w, p)
+	case FormatJSPF:
+		return writeJSPF(w, p)
+	case FormatCSV:
+		return writeCSV(w, p)
+	default:
+		return fmt.Errorf(i18n.T("export.unsupported"), f)
+	}
+}
+
+/*
+ToFile exports the playlist in the given format to a file in the data/export folder (see FileName)
+Returns the path of the file and an error, if present
+*/
+func ToFile(p backup.Playlist, f Format) (path string, err error) {
+	return ToPath(p, f, filepath.Join(Dir, FileName(p, f)))
+}
+
+/*
+FileName returns the name of the file in which the playlist is exported: <name>_<playlistID>.<format>,
+so two playlists with the same name are not exported to the same file. The playlists without ID (for example imported) have only the name
+*/
+func FileName(p backup.Playlist, f Format) string {
+	name := utils.SafeFileName(p.DisplayName())
+	if p.ID != "" {
+		name += "_" + utils.SafeFileName(string(p.ID))
+	}
+	return name + "." + string(f)
+}
+
+/*
+ToPath exports the playlist in the given format to the file at path, creating its folder if needed
+Returns the path of the file and an error, if present
+*/
+func ToPath(p backup.Playlist, f Format, path string) (string, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	err = Write(file, p, f)
+	if err != nil {
+		return "", err
+	}
+	return path, file.Close()
+}
+
+// spotifyURL returns the open.spotify.com link of a track, used by the players that can't open spotify: URIs
+func spotifyURL(t backup.Track) string {
+	if t.ID == "" {
+		return ""
+	}
+	if t.Type == backup.ItemEpisode {
+		return "https://open.spotify.com/episode/" + string(t.ID)
+	}
+	return "https://open.spotify.com/track/" + string(t.ID)
+}
+
+/*
+writeM3U8 writes the playlist as extended M3U (UTF-8): every track has an #EXTINF line with duration (in seconds), artists and title,
+an #EXTALB line with the album and the Spotify URI as location
+*/
+func writeM3U8(w io.Writer, p backup.Playlist) error {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	fmt.Fprintf(&b, "#PLAYLIST:%s\n", oneLine(p.DisplayName()))
+	for _, t := range p.Tracks() {
+		fmt.Fprintf(&b, "#EXTINF:%d,%s - %s\n", t.Duration/1000, oneLine(strings.Join(t.Artists, artistSeparator)), oneLine(t.Name))
+		if t.Album != "" {
+			fmt.Fprintf(&b, "#EXTALB:%s\n", oneLine(t.Album))
+		}
+		fmt.Fprintf(&b, "%s\n", t.URI)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// oneLine replaces the line breaks, that would break the line based formats
+func oneLine(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+}
+
+// xspfPlaylist is the root element of a XSPF playlist (https://xspf.org/spec)
+type xspfPlaylist struct {
+	XMLName    xml.Name    `xml:"playlist"`
+	Version    string      `xml:"version,attr"`
+	XMLNS      string      `xml:"xmlns,attr"`
+	Title      string      `xml:"title"`
+	Identifier string      `xml:"identifier,omitempty"`
+	Tracks     []xspfTrack `xml:"trackList>track"`
+}
+
+// xspfTrack is a track of a XSPF playlist
+type xspfTrack struct {
+	Location   []string `xml:"location,omitempty"`
+	Identifier []string `xml:"identifier,omitempty"`
+	Title      string   `xml:"title,omitempty"`
+	Creator    string   `xml:"creator,omitempty"`
+	Album      string   `xml:"album,omitempty"`
+	Duration   int      `xml:"duration,omitempty"`
+}
+
+// writeXSPF writes the playlist as XSPF (XML Shareable Playlist Format)
+func writeXSPF(w io.Writer, p backup.Playlist) error {
+	pl := xspfPlaylist{Version: "1", XMLNS: "http://xspf.org/ns/0/", Title: p.DisplayName(), Identifier: playlistURI(p)}
+	for _, t := range p.Tracks() {
+		pl.Tracks = append(pl.Tracks, xspfTrack{
+			Location:   locations(t),
+			Identifier: identifiers(t),
+			Title:      t.Name,
+			Creator:    strings.Join(t.Artists, artistSeparator),
+			Album:      t.Album,
+			Duration:   t.Duration,
+		})
+	}
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(pl)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// jspfPlaylist is a JSPF playlist, the JSON version of XSPF
+type jspfPlaylist struct {
+	Playlist struct {
+		Title      string      `json:"title"`
+		Identifier string      `json:"identifier,omitempty"`
+		Tracks     []jspfTrack `json:"track"`
+	} `json:"playlist"`
+}
+
+// jspfTrack is a track of a JSPF playlist
+type jspfTrack struct {
+	Location   []string `json:"location,omitempty"`
+	Identifier []string `json:"identifier,omitempty"`
+	Title      string   `json:"title,omitempty"`
+	Creator    string   `json:"creator,omitempty"`
+	Album      string   `json:"album,omitempty"`
+	Duration   int      `json:"duration,omitempty"`
+}
+
+// writeJSPF writes the playlist as JSPF (JSON Shareable Playlist Format)
+func writeJSPF(w io.Writer, p backup.Playlist) error {
+	var pl jspfPlaylist
+	pl.Playlist.Title = p.DisplayName()
+	pl.Playlist.Identifier = playlistURI(p)
+	pl.Playlist.Tracks = []jspfTrack{}
+	for _, t := range p.Tracks() {
+		pl.Playlist.Tracks = append(pl.Playlist.Tracks, jspfTrack{
+			Location:   locations(t),
+			Identifier: identifiers(t),
+			Title:      t.Name,
+			Creator:    strings.Join(t.Artists, artistSeparator),
+			Album:      t.Album,
+			Duration:   t.Duration,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(pl)
+}
+
+// playlistURI returns the Spotify URI of the playlist, empty for the library backups that are not playlists on Spotify
+func playlistURI(p backup.Playlist) string {
+	if p.ID == "" || p.IsLibrary() {
+		return ""
+	}
+	return "spotify:playlist:" + string(p.ID)
+}
+
+// locations returns the locations of a track for XSPF and JSPF: the Spotify URI and the open.spotify.com link
+func locations(t backup.Track) (l []string) {
+	if t.URI != "" {
+		l = append(l, string(t.URI))
+	}
+	if url := spotifyURL(t); url != "" {
+		l = append(l, url)
+	}
+	return l
+}
+
+// identifiers returns the identifiers of a track for XSPF and JSPF, the ISRC as URN (urn:isrc:<code>)
+func identifiers(t backup.Track) []string {
+	if t.ISRC == "" {
+		return nil
+	}
+	return []string{"urn:isrc:" + t.ISRC}
+}
+
+// writeCSV writes the tracks of the playlist as CSV, with a header row
+func writeCSV(w io.Writer, p backup.Playlist) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"title", "artists", "album", "isrc", "duration_ms", "uri"})
+	if err != nil {
+		return err
+	}
+	for _, t := range p.Tracks() {
+		err = cw.Write([]string{t.Name, strings.Join(t.Artists, artistSeparator), t.Album, t.ISRC, strconv.Itoa(t.Duration), string(t.URI)})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
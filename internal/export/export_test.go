@@ -0,0 +1,50 @@
+package export
+
+import (
+	"playlist-manager/internal/backup"
+	"playlist-manager/pkg/utils"
+	"testing"
+)
+
+func TestPlaylistURI(t *testing.T) {
+	tests := []struct {
+		name string
+		p    backup.Playlist
+		want string
+	}{
+		{name: "playlist", p: backup.Playlist{ID: "37i9dQZF1DXcBWIGoYBM5M"}, want: "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M"},
+		{name: "no ID", p: backup.Playlist{}, want: ""},
+		{name: "liked songs", p: backup.LibraryPlaylist(backup.KindLikedSongs), want: ""},
+		{name: "saved albums", p: backup.LibraryPlaylist(backup.KindSavedAlbums), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := playlistURI(tt.p); got != tt.want {
+				t.Errorf("playlistURI = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		p    backup.Playlist
+		f    Format
+		want string
+	}{
+		{name: "playlist", p: backup.Playlist{ID: "p1", Name: "Rock"}, f: FormatCSV, want: "Rock_p1.csv"},
+		{name: "same name", p: backup.Playlist{ID: "p2", Name: "Rock"}, f: FormatCSV, want: "Rock_p2.csv"},
+		{name: "unsafe characters", p: backup.Playlist{ID: "p3", Name: "AC/DC: best?"}, f: FormatM3U8, want: "AC_DC_ best__p3.m3u8"},
+		{name: "no ID", p: backup.Playlist{Name: "Imported"}, f: FormatXSPF, want: "Imported.xspf"},
+		{name: "library", p: backup.LibraryPlaylist(backup.KindLikedSongs), f: FormatJSPF,
+			want: utils.SafeFileName(backup.KindLikedSongs.Name()) + "_liked_songs.jspf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FileName(tt.p, tt.f); got != tt.want {
+				t.Errorf("FileName = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/link"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+/*
+ReadFile reads a playlist from a file in one of the supported formats (also written by other programs), the format is chosen by the extension.
+The tracks have the Spotify ID only if the file contains their Spotify URI or link, the others must be searched (see spotify.MatchTrack)
+Returns the playlist, named after the file if the format has no title, and an error, if present
+*/
+func ReadFile(path string) (p backup.Playlist, err error) {
+	f, err := ParseFormat(filepath.Ext(path))
+	if err != nil {
+		return p, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return p, err
+	}
+	defer file.Close()
+
+	p, err = Read(file, f)
+	if err != nil {
+		return p, err
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return p, nil
+}
+
+/*
+Read reads a playlist in the given format from r
+Returns the playlist and an error, if present
+*/
+func Read(r io.Reader, f Format) (p backup.Playlist, err error) {
+	switch f {
+	case FormatM3U8:
+		p, err = readM3U8(r)
+	case FormatXSPF:
+		p, err = readXSPF(r)
+	case FormatJSPF:
+		p, err = readJSPF(r)
+	case FormatCSV:
+		p, err = readCSV(r)
+	default:
+		return p, fmt.Errorf(i18n.T("import.unsupported"), f)
+	}
+	if err != nil {
+		return p, err
+	}
+	for _, t := range p.Items {
+		if t.ID != "" {
+			p.TrackIDs = append(p.TrackIDs, t.ID)
+		}
+	}
+	return p, nil
+}
+
+// trackWithID sets the ID and the URI of the track from its location, if it is a Spotify track
+func trackWithID(t backup.Track, locations ...string) backup.Track {
+	for _, l := range locations {
+		if id := link.ParseTrack(l); id != "" {
+			t.ID = id
+			t.URI = api.URI("spotify:track:" + string(id))
+			break
+		}
+	}
+	return t
+}
+
+// splitArtists splits a list of artists written as a single string ("A; B", see artistSeparator), the commas and "&" are kept in the names
+func splitArtists(s string) (artists []string) {
+	for _, a := range strings.Split(s, ";") {
+		if a = strings.TrimSpace(a); a != "" {
+			artists = append(artists, a)
+		}
+	}
+	return artists
+}
+
+/*
+readM3U8 reads a M3U playlist, extended or not. The title and the artists are taken from #EXTINF (<duration>,<artists> - <title>),
+the album from #EXTALB; for the plain entries the file name (without extension) is used as title
+*/
+func readM3U8(r io.Reader) (p backup.Playlist, err error) {
+	var current backup.Track
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\uFEFF"))
+		switch {
+		case line == "" || line == "#EXTM3U":
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			p.Name = strings.TrimSpace(strings.TrimPrefix(line, "#PLAYLIST:"))
+		case strings.HasPrefix(line, "#EXTINF:"):
+			info := strings.TrimPrefix(line, "#EXTINF:")
+			duration, title, _ := strings.Cut(info, ",")
+			// Attributes (tvg-id="..." etc.) can follow the duration, separated by a space
+			duration, _, _ = strings.Cut(duration, " ")
+			if seconds, err := strconv.Atoi(duration); err == nil && seconds > 0 {
+				current.Duration = seconds * 1000
+			}
+			if artists, name, ok := strings.Cut(title, " - "); ok {
+				current.Artists = splitArtists(artists)
+				current.Name = strings.TrimSpace(name)
+			} else {
+				current.Name = strings.TrimSpace(title)
+			}
+		case strings.HasPrefix(line, "#EXTALB:"):
+			current.Album = strings.TrimSpace(strings.TrimPrefix(line, "#EXTALB:"))
+		case strings.HasPrefix(line, "#EXTART:"):
+			current.Artists = splitArtists(strings.TrimPrefix(line, "#EXTART:"))
+		case strings.HasPrefix(line, "#"):
+		default:
+			if current.Name == "" && link.ParseTrack(line) == "" {
+				name := filepath.Base(strings.ReplaceAll(line, "\\", "/"))
+				current.Name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			p.Items = append(p.Items, trackWithID(current, line))
+			current = backup.Track{}
+		}
+	}
+	return p, scanner.Err()
+}
+
+// readXSPF reads a XSPF playlist
+func readXSPF(r io.Reader) (p backup.Playlist, err error) {
+	var pl xspfPlaylist
+	err = xml.NewDecoder(r).Decode(&pl)
+	if err != nil {
+		return p, err
+	}
+	p.Name = pl.Title
+	for _, t := range pl.Tracks {
+		p.Items = append(p.Items, sharedTrack(t.Title, t.Creator, t.Album, t.Duration, t.Location, t.Identifier))
+	}
+	return p, nil
+}
+
+// readJSPF reads a JSPF playlist
+func readJSPF(r io.Reader) (p backup.Playlist, err error) {
+	var pl jspfPlaylist
+	err = json.NewDecoder(r).Decode(&pl)
+	if err != nil {
+		return p, err
+	}
+	p.Name = pl.Playlist.Title
+	for _, t := range pl.Playlist.Tracks {
+		p.Items = append(p.Items, sharedTrack(t.Title, t.Creator, t.Album, t.Duration, t.Location, t.Identifier))
+	}
+	return p, nil
+}
+
+// sharedTrack converts a track of a XSPF or JSPF playlist, the ISRC is read from the identifiers in the form urn:isrc:<code>
+func sharedTrack(title string, creator string, album string, duration int, locations []string, identifiers []string) backup.Track {
+	t := backup.Track{Name: title, Artists: splitArtists(creator), Album: album, Duration: duration}
+	for _, id := range identifiers {
+		if isrc, ok := strings.CutPrefix(id, "urn:isrc:"); ok {
+			t.ISRC = isrc
+		}
+	}
+	return trackWithID(t, append(locations, identifiers...)...)
+}
+
+/*
+readCSV reads a CSV playlist with a header row. The columns are found by name (the ones written by writeCSV and common alternatives,
+for example "name"/"track name", "artist"/"artist name(s)", "spotify uri"/"track uri"), so also the exports of other tools can be read.
+The duration is in milliseconds ("duration_ms") or in seconds ("duration")
+*/
+func readCSV(r io.Reader) (p backup.Playlist, err error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return p, err
+	}
+	columns := map[string]int{}
+	for i, h := range header {
+		h = utils.Lower(strings.TrimSpace(strings.TrimPrefix(h, "\uFEFF")))
+		columns[h] = i
+	}
+	column := func(names ...string) int {
+		for _, n := range names {
+			if i, ok := columns[n]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	title := column("title", "name", "track name", "track", "titolo")
+	artists := column("artists", "artist", "artist name(s)", "artist name", "artisti", "artista")
+	album := column("album", "album name")
+	isrc := column("isrc")
+	durationMs := column("duration_ms", "duration (ms)", "track duration (ms)")
+	duration := column("duration", "durata")
+	uri := column("uri", "spotify uri", "track uri", "spotify_uri", "url")
+	if title < 0 && uri < 0 && isrc < 0 {
+		return p, errors.New(i18n.T("import.noColumn"))
+	}
+
+	field := func(record []string, i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return p, err
+		}
+		t := backup.Track{
+			Name:    field(record, title),
+			Artists: splitArtists(field(record, artists)),
+			Album:   field(record, album),
+			ISRC:    strings.ToUpper(field(record, isrc)),
+		}
+		if ms, err := strconv.Atoi(field(record, durationMs)); err == nil {
+			t.Duration = ms
+		} else if s, err := strconv.Atoi(field(record, duration)); err == nil {
+			t.Duration = s * 1000
+		}
+		p.Items = append(p.Items, trackWithID(t, field(record, uri)))
+	}
+	return p, nil
+}
@@ -0,0 +1,158 @@
+package export
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	api "github.com/zmb3/spotify/v2"
+
+	"playlist-manager/internal/backup"
+)
+
+const testID = "4uLU6hMCjMI75M1A2tKUQC"
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		input   string
+		want    backup.Playlist
+		wantErr bool
+	}{
+		{
+			name:   "m3u extended",
+			format: FormatM3U8,
+			input: "\uFEFF#EXTM3U\n#PLAYLIST:Estate\n#EXTINF:213,Rick Astley - Never Gonna Give You Up\n#EXTALB:Whenever You Need Somebody\n" +
+				"spotify:track:" + testID + "\n\n#EXTINF:-1 tvg-id=\"x\",A; B - Song\nmusic/song.mp3\n" +
+				"#EXTINF:200,Earth, Wind & Fire - September\nspotify:track:" + testID + "\n",
+			want: backup.Playlist{Name: "Estate", TrackIDs: []api.ID{testID, testID}, Items: []backup.Track{
+				{ID: testID, URI: "spotify:track:" + testID, Name: "Never Gonna Give You Up", Artists: []string{"Rick Astley"},
+					Album: "Whenever You Need Somebody", Duration: 213000},
+				{Name: "Song", Artists: []string{"A", "B"}},
+				{ID: testID, URI: "spotify:track:" + testID, Name: "September", Artists: []string{"Earth, Wind & Fire"}, Duration: 200000},
+			}},
+		},
+		{
+			name:   "m3u plain",
+			format: FormatM3U8,
+			input:  "C:\\Music\\First.mp3\nhttps://open.spotify.com/track/" + testID + "?si=abc\n",
+			want: backup.Playlist{TrackIDs: []api.ID{testID}, Items: []backup.Track{
+				{Name: "First"},
+				{ID: testID, URI: "spotify:track:" + testID},
+			}},
+		},
+		{
+			name:   "xspf",
+			format: FormatXSPF,
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<playlist version="1" xmlns="http://xspf.org/ns/0/">
+  <title>Lista</title>
+  <trackList>
+    <track>
+      <location>https://open.spotify.com/track/` + testID + `</location>
+      <identifier>urn:isrc:GBARL9300135</identifier>
+      <title>Never Gonna Give You Up</title>
+      <creator>Rick Astley</creator>
+      <album>Whenever You Need Somebody</album>
+      <duration>213573</duration>
+    </track>
+    <track>
+      <title>Local</title>
+      <creator>A; B</creator>
+    </track>
+  </trackList>
+</playlist>`,
+			want: backup.Playlist{Name: "Lista", TrackIDs: []api.ID{testID}, Items: []backup.Track{
+				{ID: testID, URI: "spotify:track:" + testID, Name: "Never Gonna Give You Up", Artists: []string{"Rick Astley"},
+					Album: "Whenever You Need Somebody", ISRC: "GBARL9300135", Duration: 213573},
+				{Name: "Local", Artists: []string{"A", "B"}},
+			}},
+		},
+		{
+			name:   "jspf",
+			format: FormatJSPF,
+			input: `{"playlist": {"title": "Lista", "track": [
+				{"title": "Never Gonna Give You Up", "creator": "Rick Astley", "duration": 213573,
+				 "identifier": ["spotify:track:` + testID + `", "urn:isrc:GBARL9300135"]}
+			]}}`,
+			want: backup.Playlist{Name: "Lista", TrackIDs: []api.ID{testID}, Items: []backup.Track{
+				{ID: testID, URI: "spotify:track:" + testID, Name: "Never Gonna Give You Up", Artists: []string{"Rick Astley"},
+					ISRC: "GBARL9300135", Duration: 213573},
+			}},
+		},
+		{
+			name:   "csv",
+			format: FormatCSV,
+			input: "\uFEFFTrack Name,Artist Name(s),Album Name,ISRC,Track Duration (ms),Track URI\n" +
+				"Never Gonna Give You Up,Rick Astley,Whenever You Need Somebody,gbarl9300135,213573,spotify:track:" + testID + "\n" +
+				"Song,A; B,,,,\n" +
+				"The Boxer,Simon & Garfunkel,,,,\n",
+			want: backup.Playlist{TrackIDs: []api.ID{testID}, Items: []backup.Track{
+				{ID: testID, URI: "spotify:track:" + testID, Name: "Never Gonna Give You Up", Artists: []string{"Rick Astley"},
+					Album: "Whenever You Need Somebody", ISRC: "GBARL9300135", Duration: 213573},
+				{Name: "Song", Artists: []string{"A", "B"}},
+				{Name: "The Boxer", Artists: []string{"Simon & Garfunkel"}},
+			}},
+		},
+		{
+			name:   "csv duration in seconds",
+			format: FormatCSV,
+			input:  "titolo,artista,durata\nCanzone,Autore,180\n",
+			want:   backup.Playlist{Items: []backup.Track{{Name: "Canzone", Artists: []string{"Autore"}, Duration: 180000}}},
+		},
+		{name: "csv without known columns", format: FormatCSV, input: "a,b\n1,2\n", wantErr: true},
+		{name: "invalid xspf", format: FormatXSPF, input: "<playlist", wantErr: true},
+		{name: "invalid jspf", format: FormatJSPF, input: "{", wantErr: true},
+		{name: "unsupported format", format: "txt", input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.input), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWriteRead checks that the playlists written in every format are read back with the same tracks
+func TestWriteRead(t *testing.T) {
+	const otherID = "2grjqo0Frpf2okIBiifQKs"
+	p := backup.Playlist{ID: "37i9dQZF1DXcBWIGoYBM5M", Name: "Lista", TrackIDs: []api.ID{testID, otherID}, Items: []backup.Track{
+		{ID: testID, URI: "spotify:track:" + testID, Name: "Never Gonna Give You Up", Artists: []string{"Rick Astley"},
+			Album: "Whenever You Need Somebody", ISRC: "GBARL9300135", Duration: 213000},
+		{ID: otherID, URI: "spotify:track:" + otherID, Name: "Collaborazione", Artists: []string{"Earth, Wind & Fire", "Simon & Garfunkel"}, Duration: 180000},
+	}}
+	for _, f := range Formats() {
+		t.Run(string(f), func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Write(&buf, p, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Read(&buf, f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.TrackIDs, p.TrackIDs) {
+				t.Errorf("TrackIDs = %v, want %v", got.TrackIDs, p.TrackIDs)
+			}
+			if len(got.Items) != len(p.Items) {
+				t.Fatalf("Items = %+v, want %+v", got.Items, p.Items)
+			}
+			for i, item := range got.Items {
+				if item.Name != p.Items[i].Name || !reflect.DeepEqual(item.Artists, p.Items[i].Artists) {
+					t.Errorf("Items[%d] = %+v, want %+v", i, item, p.Items[i])
+				}
+			}
+		})
+	}
+}
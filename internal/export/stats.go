@@ -0,0 +1,118 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"playlist-manager/internal/backup"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+// Formats of the statistics reports
+const (
+	StatsJSON = "json"
+	StatsCSV  = "csv"
+)
+
+// StatsReport is a report with the statistics of some playlists, of the whole library and the overlap between the playlists
+type StatsReport struct {
+	Generated string          `json:"generated"`
+	Playlists []backup.Stats  `json:"playlists"`
+	Library   *backup.Stats   `json:"library,omitempty"`
+	Overlap   *backup.Overlap `json:"overlap,omitempty"`
+}
+
+/*
+WriteStats writes the report to w as JSON or CSV.
+The CSV has a row for every value, with the columns playlist, metric, name and value, so it can be read as a pivot table
+Returns an error, if present
+*/
+func WriteStats(w io.Writer, r StatsReport, format string) error {
+	switch utils.Lower(format) {
+	case StatsJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		return enc.Encode(r)
+	case StatsCSV:
+		return writeStatsCSV(w, r)
+	default:
+		return fmt.Errorf(i18n.T("stats.unsupportedReport"), format, StatsJSON, StatsCSV)
+	}
+}
+
+/*
+StatsToPath writes the report to the file at path, by default data/export/statistiche-<date>.<format>, creating its folder if needed
+Returns the path of the file and an error, if present
+*/
+func StatsToPath(r StatsReport, format string, path string) (string, error) {
+	if path == "" {
+		path = filepath.Join(Dir, "statistiche-"+time.Now().Format(backup.DateLayout)+"."+utils.Lower(format))
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	err = WriteStats(file, r, format)
+	if err != nil {
+		return "", err
+	}
+	return path, file.Close()
+}
+
+// writeStatsCSV writes the report as CSV, see WriteStats
+func writeStatsCSV(w io.Writer, r StatsReport) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"playlist", "metric", "name", "value"})
+	stats := r.Playlists
+	if r.Library != nil {
+		stats = append(stats[:len(stats):len(stats)], *r.Library)
+	}
+	for _, s := range stats {
+		for _, v := range []struct {
+			metric string
+			value  int
+		}{
+			{"items", s.Items}, {"tracks", s.Tracks}, {"episodes", s.Episodes}, {"local", s.Local},
+			{"unavailable", s.Unavailable}, {"duration_ms", s.Duration}, {"explicit", s.Explicit},
+		} {
+			cw.Write([]string{s.Name, v.metric, "", strconv.Itoa(v.value)})
+		}
+		cw.Write([]string{s.Name, "explicit_share", "", strconv.FormatFloat(s.ExplicitShare(), 'f', 1, 64)})
+		writeCounts(cw, s.Name, "artist", s.Artists)
+		writeCounts(cw, s.Name, "album", s.Albums)
+		writeCounts(cw, s.Name, "year", s.Years)
+		writeCounts(cw, s.Name, "added_by", s.AddedBy)
+	}
+	if r.Overlap != nil {
+		for i, row := range r.Overlap.Shared {
+			for j, n := range row {
+				if i != j {
+					cw.Write([]string{r.Overlap.Names[i], "overlap", r.Overlap.Names[j], strconv.Itoa(n)})
+				}
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeCounts writes a row for every count of a playlist, the names are on one line (see oneLine)
+func writeCounts(cw *csv.Writer, playlist string, metric string, counts []backup.Count) {
+	for _, c := range counts {
+		cw.Write([]string{playlist, metric, oneLine(c.Name), strconv.Itoa(c.Items)})
+	}
+}
@@ -0,0 +1,163 @@
+// Contents: parsing of the Spotify links (https://open.spotify.com/...), URIs (spotify:...) and bare IDs
+package link
+
+import (
+	"fmt"
+	"net/url"
+	"playlist-manager/pkg/i18n"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+// Kind is the type of a Spotify resource, as written in its links and URIs
+type Kind string
+
+const (
+	Track    Kind = "track"
+	Episode  Kind = "episode"
+	Playlist Kind = "playlist"
+	Album    Kind = "album"
+	Artist   Kind = "artist"
+	Show     Kind = "show"
+	User     Kind = "user"
+)
+
+// kindNames are the keys of the names of the types of resources that can be parsed, shown in the errors
+var kindNames = map[Kind]string{
+	Track:    "link.track",
+	Episode:  "link.episode",
+	Playlist: "link.playlist",
+	Album:    "link.album",
+	Artist:   "link.artist",
+	Show:     "link.show",
+	User:     "link.user",
+}
+
+// Resource is a Spotify resource read from a link, an URI or a bare ID (in that case the kind is empty)
+type Resource struct {
+	Kind Kind
+	ID   api.ID
+}
+
+// URI returns the Spotify URI of the resource (spotify:<kind>:<id>)
+func (r Resource) URI() api.URI {
+	return api.URI("spotify:" + string(r.Kind) + ":" + string(r.ID))
+}
+
+// URL returns the open.spotify.com link of the resource
+func (r Resource) URL() string {
+	return "https://open.spotify.com/" + string(r.Kind) + "/" + string(r.ID)
+}
+
+/*
+Parse reads a Spotify resource from:
+  - a link, also with the language (https://open.spotify.com/intl-it/track/<id>?si=...) or from the embed player
+  - an URI (spotify:track:<id>), also in the old format of the playlists (spotify:user:<user>:playlist:<id>)
+  - a bare ID (22 letters and numbers), whose kind is unknown
+
+Returns the resource and an error if the text is not one of them
+*/
+func Parse(s string) (Resource, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "spotify:"):
+		return parseURI(s)
+	case strings.Contains(s, "open.spotify.com/"):
+		return parseURL(s)
+	case IsID(s):
+		return Resource{ID: api.ID(s)}, nil
+	default:
+		return Resource{}, fmt.Errorf(i18n.T("link.notLink"), s)
+	}
+}
+
+/*
+ParseKind reads the ID of a resource of the given kind (see Parse), a bare ID is considered of that kind
+Returns the ID and an error if the text is not valid or is a resource of another kind
+*/
+func ParseKind(s string, kind Kind) (api.ID, error) {
+	r, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	if r.Kind != "" && r.Kind != kind {
+		return "", fmt.Errorf(i18n.T("link.wrongKind"), strings.TrimSpace(s), i18n.T(kindNames[r.Kind]), i18n.T(kindNames[kind]))
+	}
+	return r.ID, nil
+}
+
+/*
+ParseTrack returns the ID of a track from its link or URI, the bare IDs are not accepted.
+It is used for the locations of the playlist files, where a bare text is not a Spotify track
+Returns the ID, empty if the text is not a link or an URI of a track
+*/
+func ParseTrack(s string) api.ID {
+	r, err := Parse(s)
+	if err != nil || r.Kind != Track {
+		return ""
+	}
+	return r.ID
+}
+
+// IsID returns true if the text is a Spotify ID: 22 characters in base 62
+func IsID(s string) bool {
+	if len(s) != 22 {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseURI parses a spotify: URI, see Parse
+func parseURI(s string) (Resource, error) {
+	parts := strings.Split(s, ":")
+	// spotify:user:<user>:playlist:<id> is the old format of the playlists
+	if len(parts) == 5 && parts[1] == string(User) && parts[3] == string(Playlist) {
+		parts = []string{parts[0], parts[3], parts[4]}
+	}
+	if len(parts) != 3 {
+		return Resource{}, fmt.Errorf(i18n.T("link.invalidURI"), s)
+	}
+	return resource(parts[1], parts[2], s)
+}
+
+// parseURL parses an open.spotify.com link, see Parse
+func parseURL(s string) (Resource, error) {
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host != "open.spotify.com" {
+		return Resource{}, fmt.Errorf(i18n.T("link.invalidLink"), s)
+	}
+	var parts []string
+	for _, p := range strings.Split(u.Path, "/") {
+		// The language (intl-it) and the embed player are not part of the resource
+		if p != "" && !strings.HasPrefix(p, "intl-") && p != "embed" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 4 && parts[0] == string(User) && parts[2] == string(Playlist) {
+		parts = parts[2:]
+	}
+	if len(parts) != 2 {
+		return Resource{}, fmt.Errorf(i18n.T("link.invalidLink"), s)
+	}
+	return resource(parts[0], parts[1], s)
+}
+
+// resource returns the resource with the kind and the ID read from a link or an URI, if they are valid
+func resource(kind string, id string, s string) (Resource, error) {
+	k := Kind(kind)
+	_, valid := kindNames[k]
+	// The IDs of the users are their names, not in base 62
+	if !valid || id == "" || (k != User && !IsID(id)) {
+		return Resource{}, fmt.Errorf(i18n.T("link.invalid"), s)
+	}
+	return Resource{Kind: k, ID: api.ID(id)}, nil
+}
@@ -0,0 +1,107 @@
+package link
+
+import (
+	"testing"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+const id = "4uLU6hMCjMI75M1A2tKUQC"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Resource
+		wantErr bool
+	}{
+		{name: "link", input: "https://open.spotify.com/track/" + id, want: Resource{Kind: Track, ID: id}},
+		{name: "link with query", input: "https://open.spotify.com/track/" + id + "?si=abc123", want: Resource{Kind: Track, ID: id}},
+		{name: "link without scheme", input: "open.spotify.com/album/" + id, want: Resource{Kind: Album, ID: id}},
+		{name: "intl link", input: "https://open.spotify.com/intl-it/playlist/" + id, want: Resource{Kind: Playlist, ID: id}},
+		{name: "intl link with region", input: "https://open.spotify.com/intl-pt-br/artist/" + id, want: Resource{Kind: Artist, ID: id}},
+		{name: "embed link", input: "https://open.spotify.com/embed/episode/" + id + "?utm_source=generator", want: Resource{Kind: Episode, ID: id}},
+		{name: "old playlist link", input: "https://open.spotify.com/user/mario/playlist/" + id, want: Resource{Kind: Playlist, ID: id}},
+		{name: "user link", input: "https://open.spotify.com/user/mario.rossi", want: Resource{Kind: User, ID: "mario.rossi"}},
+		{name: "uri", input: "spotify:show:" + id, want: Resource{Kind: Show, ID: id}},
+		{name: "old playlist uri", input: "spotify:user:mario:playlist:" + id, want: Resource{Kind: Playlist, ID: id}},
+		{name: "bare id", input: "  " + id + " ", want: Resource{ID: id}},
+		{name: "text", input: "daft punk", wantErr: true},
+		{name: "short id", input: id[:21], wantErr: true},
+		{name: "invalid host", input: "https://example.com/open.spotify.com/track/" + id, wantErr: true},
+		{name: "lookalike host", input: "https://open.spotify.com.example.com/track/" + id, wantErr: true},
+		{name: "unknown kind in link", input: "https://open.spotify.com/concert/" + id, wantErr: true},
+		{name: "link without id", input: "https://open.spotify.com/track/", wantErr: true},
+		{name: "link with invalid id", input: "https://open.spotify.com/track/abc", wantErr: true},
+		{name: "unknown kind in uri", input: "spotify:concert:" + id, wantErr: true},
+		{name: "uri with invalid id", input: "spotify:track:abc", wantErr: true},
+		{name: "local file uri", input: "spotify:local:Artist:Album:Title:180", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	tests := []struct {
+		input   string
+		kind    Kind
+		want    api.ID
+		wantErr bool
+	}{
+		{input: "spotify:track:" + id, kind: Track, want: id},
+		{input: id, kind: Playlist, want: id},
+		{input: "https://open.spotify.com/intl-it/playlist/" + id, kind: Playlist, want: id},
+		{input: "https://open.spotify.com/album/" + id, kind: Track, wantErr: true},
+		{input: "spotify:playlist:" + id, kind: Track, wantErr: true},
+		{input: "not a link", kind: Track, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseKind(tt.input, tt.kind)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseKind(%q, %s) = %q, %v, want %q, error %v", tt.input, tt.kind, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseTrack(t *testing.T) {
+	tests := []struct {
+		input string
+		want  api.ID
+	}{
+		{input: "https://open.spotify.com/track/" + id, want: id},
+		{input: "spotify:track:" + id, want: id},
+		{input: id, want: ""},
+		{input: "spotify:episode:" + id, want: ""},
+		{input: "spotify:local:Artist:Album:Title:180", want: ""},
+		{input: "Music/song.mp3", want: ""},
+	}
+	for _, tt := range tests {
+		if got := ParseTrack(tt.input); got != tt.want {
+			t.Errorf("ParseTrack(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestResourceURIAndURL(t *testing.T) {
+	r := Resource{Kind: Playlist, ID: id}
+	if r.URI() != "spotify:playlist:"+id {
+		t.Errorf("URI = %q", r.URI())
+	}
+	if r.URL() != "https://open.spotify.com/playlist/"+id {
+		t.Errorf("URL = %q", r.URL())
+	}
+	for _, s := range []string{string(r.URI()), r.URL()} {
+		if got, err := Parse(s); err != nil || got != r {
+			t.Errorf("Parse(%q) = %+v, %v, want %+v", s, got, err, r)
+		}
+	}
+}
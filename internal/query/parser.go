@@ -0,0 +1,215 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+// Kinds of the tokens of a query
+const (
+	tokenEnd = iota
+	tokenWord
+	tokenString // Quoted text, never a keyword
+	tokenOperator
+	tokenOpen
+	tokenClose
+	tokenComma
+)
+
+type token struct {
+	kind int
+	text string
+	pos  int // Position in the query (starting from 1), shown in the errors
+}
+
+// tokenize splits a query in tokens
+func tokenize(text string) (tokens []token, err error) {
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenOpen, "(", i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenClose, ")", i + 1})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokenComma, ",", i + 1})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf(i18n.T("query.unclosedQuote"), i+1)
+			}
+			tokens = append(tokens, token{tokenString, string(runes[i+1 : end]), i + 1})
+			i = end + 1
+		case strings.ContainsRune(":=<>!", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != ':' && r != '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, fmt.Errorf(i18n.T("query.bang"), i+1)
+			}
+			tokens = append(tokens, token{tokenOperator, op, i + 1})
+			i += len(op)
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("(),\":=<>!", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[i:end]), i + 1})
+			i = end
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, pos: len(runes) + 1}), nil
+}
+
+// parser is a recursive descent parser of the conditions of a query
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) atEnd() bool {
+	return p.peek().kind == tokenEnd
+}
+
+// atKeyword returns true if the next token is the keyword (not case sensitive)
+func (p *parser) atKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// atConditionEnd returns true if the next token can't start a condition (end of the query, closing bracket or keyword)
+func (p *parser) atConditionEnd() bool {
+	for _, k := range []string{"AND", "OR", "ORDER", "LIMIT"} {
+		if p.atKeyword(k) {
+			return true
+		}
+	}
+	kind := p.peek().kind
+	return kind == tokenEnd || kind == tokenClose || kind == tokenComma
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf(i18n.T("query.invalidAt"), p.peek().pos, fmt.Sprintf(format, args...))
+}
+
+// parseOr parses conditions joined by OR
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.atKeyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses conditions joined by AND or written one after the other
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if p.atKeyword("AND") {
+			p.next()
+		} else if p.atConditionEnd() {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+// parseNot parses a condition, negated if preceded by NOT
+func (p *parser) parseNot() (node, error) {
+	if p.atKeyword("NOT") {
+		p.next()
+		n, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a condition in brackets, a comparison or a flag
+func (p *parser) parsePrimary() (node, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenOpen:
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenClose {
+			return nil, p.errorf(i18n.T("query.missingParen"))
+		}
+		p.next()
+		return n, nil
+	case t.kind == tokenWord && !p.atConditionEnd():
+		p.next()
+		field := utils.Lower(t.text)
+		if p.peek().kind != tokenOperator {
+			// A field without operator is a flag
+			if field != fieldExplicit && field != fieldEpisode && field != fieldLocal {
+				return nil, fmt.Errorf(i18n.T("query.missingOperator"), t.pos, t.text, t.text)
+			}
+			n, err := condition(field, ":", "true")
+			if err != nil {
+				return nil, fmt.Errorf(i18n.T("query.invalidAtWrap"), t.pos, err)
+			}
+			return n, nil
+		}
+		op := p.next().text
+		value := p.next()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, p.errorf(i18n.T("query.missingValue"), field)
+		}
+		n, err := condition(field, op, value.text)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("query.invalidAtWrap"), t.pos, err)
+		}
+		return n, nil
+	case t.kind == tokenEnd:
+		return nil, p.errorf(i18n.T("query.missingCondition"))
+	default:
+		return nil, p.errorf(i18n.T("query.expectedCondition"), t.text)
+	}
+}
@@ -0,0 +1,306 @@
+// Package query implements the query language of the smart playlists, used to filter and sort tracks
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"playlist-manager/internal/backup"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+/*
+Query is a parsed query. The syntax is:
+
+	[condition] [ORDER BY field [ASC|DESC], ...] [LIMIT n]
+
+A condition compares a field with a value (field:value, field=value, field!=value, field<value, field<=value, field>value, field>=value)
+or is a flag (explicit, episode, local), and the conditions can be combined with AND, OR, NOT and brackets.
+Two conditions one after the other are joined with AND. Values with spaces must be quoted ("...").
+For example: artist:"Daft Punk" AND year>=2015 AND NOT explicit ORDER BY added_at DESC LIMIT 50
+*/
+type Query struct {
+	text   string
+	filter node // nil if the query has no condition: every track matches
+	Order  []backup.SortKey
+	Limit  int // 0 if there is no limit
+}
+
+// Fields that can be used in the conditions
+const (
+	fieldTitle      = "title"
+	fieldArtist     = "artist"
+	fieldAlbum      = "album"
+	fieldISRC       = "isrc"
+	fieldYear       = "year"
+	fieldAdded      = "added"
+	fieldDuration   = "duration"
+	fieldPopularity = "popularity"
+	fieldExplicit   = "explicit"
+	fieldEpisode    = "episode"
+	fieldLocal      = "local"
+)
+
+// Fields returns the fields that can be used in the conditions, for the help shown to the user
+func Fields() []string {
+	return []string{fieldTitle, fieldArtist, fieldAlbum, fieldISRC, fieldYear, fieldAdded, fieldDuration, fieldPopularity, fieldExplicit, fieldEpisode, fieldLocal}
+}
+
+// orderFields maps the fields accepted after ORDER BY to the sort fields, the names of the sort fields are accepted too
+var orderFields = map[string]string{
+	fieldYear:  backup.SortReleaseDate,
+	fieldAdded: backup.SortAddedAt,
+}
+
+/*
+Parse parses a query (see Query for the syntax)
+Returns the query and an error, if the query is not valid
+*/
+func Parse(text string) (*Query, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	q := &Query{text: strings.TrimSpace(text)}
+	if !p.atKeyword("ORDER") && !p.atKeyword("LIMIT") && !p.atEnd() {
+		q.filter, err = p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.atKeyword("ORDER") {
+		p.next()
+		if !p.atKeyword("BY") {
+			return nil, p.errorf(i18n.T("query.missingBy"))
+		}
+		p.next()
+		for {
+			t := p.next()
+			if t.kind != tokenWord {
+				return nil, p.errorf(i18n.T("query.missingSortField"))
+			}
+			field := utils.Lower(t.text)
+			if f, ok := orderFields[field]; ok {
+				field = f
+			}
+			key, err := backup.ParseSortKeys(field)
+			if err != nil {
+				return nil, err
+			}
+			if p.atKeyword("DESC") {
+				key[0].Desc = true
+				p.next()
+			} else if p.atKeyword("ASC") {
+				p.next()
+			}
+			q.Order = append(q.Order, key[0])
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if p.atKeyword("LIMIT") {
+		p.next()
+		t := p.next()
+		n, err := strconv.Atoi(t.text)
+		if t.kind != tokenWord || err != nil || n <= 0 {
+			return nil, p.errorf(i18n.T("query.limit"))
+		}
+		q.Limit = n
+	}
+	if !p.atEnd() {
+		return nil, p.errorf(i18n.T("query.unexpected"), p.peek().text)
+	}
+	return q, nil
+}
+
+// String returns the text of the query
+func (q *Query) String() string {
+	return q.text
+}
+
+// Match returns true if the track satisfies the conditions of the query
+func (q *Query) Match(t backup.Track) bool {
+	return q.filter == nil || q.filter.match(t)
+}
+
+/*
+Apply filters the tracks with the conditions of the query, sorts them (keeping the original order of the tracks equal for the sort fields)
+and keeps at most Limit tracks
+Returns the resulting tracks
+*/
+func (q *Query) Apply(tracks []backup.Track) []backup.Track {
+	var res []backup.Track
+	for _, t := range tracks {
+		if q.Match(t) {
+			res = append(res, t)
+		}
+	}
+	if len(q.Order) > 0 {
+		sorted := make([]backup.Track, 0, len(res))
+		for _, i := range backup.SortOrder(res, q.Order) {
+			sorted = append(sorted, res[i])
+		}
+		res = sorted
+	}
+	if q.Limit > 0 && len(res) > q.Limit {
+		res = res[:q.Limit]
+	}
+	return res
+}
+
+// node is a condition of a query
+type node interface {
+	match(t backup.Track) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(t backup.Track) bool { return n.left.match(t) && n.right.match(t) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(t backup.Track) bool { return n.left.match(t) || n.right.match(t) }
+
+type notNode struct{ node node }
+
+func (n notNode) match(t backup.Track) bool { return !n.node.match(t) }
+
+// textCondition compares a text field ignoring the case: ':' checks if it contains the value, '=' and '!=' if it is equal
+type textCondition struct {
+	field string
+	op    string
+	value string
+}
+
+func (c textCondition) match(t backup.Track) bool {
+	var values []string
+	switch c.field {
+	case fieldTitle:
+		values = []string{t.Name}
+	case fieldArtist:
+		values = t.Artists
+	case fieldAlbum:
+		values = []string{t.Album}
+	case fieldISRC:
+		values = []string{t.ISRC}
+	}
+	found := false
+	for _, v := range values {
+		v = utils.Lower(v)
+		if (c.op == ":" && strings.Contains(v, c.value)) || (c.op != ":" && v == c.value) {
+			found = true
+			break
+		}
+	}
+	if c.op == "!=" {
+		return !found
+	}
+	return found
+}
+
+// numberCondition compares a numeric field: year of release, duration (in seconds) or popularity
+type numberCondition struct {
+	field string
+	op    string
+	value float64
+}
+
+func (c numberCondition) match(t backup.Track) bool {
+	var v float64
+	switch c.field {
+	case fieldYear:
+		year, err := strconv.Atoi(backup.YearOf(t.ReleaseDate))
+		if err != nil {
+			return false
+		}
+		v = float64(year)
+	case fieldDuration:
+		v = float64(t.Duration) / 1000
+	case fieldPopularity:
+		v = float64(t.Popularity)
+	}
+	return compare(v, c.value, c.op)
+}
+
+// dateCondition compares the date a track was added (AAAA, AAAA-MM or AAAA-MM-GG), only the part of the date given in the value
+type dateCondition struct {
+	op    string
+	value string
+}
+
+func (c dateCondition) match(t backup.Track) bool {
+	if t.AddedAt == "" {
+		return false
+	}
+	added := t.AddedAt[:min(len(c.value), len(t.AddedAt))]
+	return compare(strings.Compare(added, c.value), 0, c.op)
+}
+
+// flagCondition checks a boolean field, value is the expected value
+type flagCondition struct {
+	field string
+	value bool
+}
+
+func (c flagCondition) match(t backup.Track) bool {
+	var v bool
+	switch c.field {
+	case fieldExplicit:
+		v = t.Explicit
+	case fieldEpisode:
+		v = t.Type == backup.ItemEpisode
+	case fieldLocal:
+		v = t.Type == backup.ItemLocal
+	}
+	return v == c.value
+}
+
+// compare compares two values with an operator, ':' is the same as '='
+func compare[T int | float64](a, b T, op string) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "!=":
+		return a != b
+	default:
+		return a == b
+	}
+}
+
+// condition builds the condition on a field with an operator and a value
+func condition(field string, op string, value string) (node, error) {
+	switch field {
+	case fieldTitle, fieldArtist, fieldAlbum, fieldISRC:
+		if op != ":" && op != "=" && op != "!=" {
+			return nil, fmt.Errorf(i18n.T("query.textOperators"), field)
+		}
+		return textCondition{field: field, op: op, value: utils.Lower(value)}, nil
+	case fieldYear, fieldDuration, fieldPopularity:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("query.numberRequired"), field, value)
+		}
+		return numberCondition{field: field, op: op, value: n}, nil
+	case fieldAdded:
+		return dateCondition{op: op, value: value}, nil
+	case fieldExplicit, fieldEpisode, fieldLocal:
+		b, err := strconv.ParseBool(value)
+		if err != nil || (op != ":" && op != "=" && op != "!=") {
+			return nil, fmt.Errorf(i18n.T("query.boolOperators"), field)
+		}
+		return flagCondition{field: field, value: b == (op != "!=")}, nil
+	default:
+		return nil, fmt.Errorf(i18n.T("query.invalidField"), field, strings.Join(Fields(), ", "))
+	}
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"playlist-manager/internal/backup"
+)
+
+var tracks = []backup.Track{
+	{Name: "One More Time", Artists: []string{"Daft Punk"}, Album: "Discovery", ISRC: "GBDUW0000053", Duration: 320000,
+		ReleaseDate: "2001-03-07", AddedAt: "2023-05-10T10:00:00Z", Popularity: 80},
+	{Name: "Get Lucky", Artists: []string{"Daft Punk", "Pharrell Williams"}, Album: "Random Access Memories", Duration: 369000,
+		ReleaseDate: "2013-05-17", AddedAt: "2024-01-02T10:00:00Z", Popularity: 85, Explicit: false},
+	{Name: "Lose Yourself", Artists: []string{"Eminem"}, Album: "8 Mile", Duration: 326000,
+		ReleaseDate: "2002", AddedAt: "2024-02-20T10:00:00Z", Popularity: 90, Explicit: true},
+	{Name: "Podcast", Artists: []string{"Host"}, Duration: 1800000, AddedAt: "2022-12-31T10:00:00Z", Type: backup.ItemEpisode},
+	{Name: "Demo", Artists: []string{"Me"}, Duration: 60000, Type: backup.ItemLocal},
+}
+
+// names returns the names of the tracks, in order
+func names(tracks []backup.Track) (n []string) {
+	for _, t := range tracks {
+		n = append(n, t.Name)
+	}
+	return n
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "", want: []string{"One More Time", "Get Lucky", "Lose Yourself", "Podcast", "Demo"}},
+		{query: `artist:"daft punk"`, want: []string{"One More Time", "Get Lucky"}},
+		{query: `artist="Pharrell Williams"`, want: []string{"Get Lucky"}},
+		{query: `artist!=eminem`, want: []string{"One More Time", "Get Lucky", "Podcast", "Demo"}},
+		{query: `title:lu`, want: []string{"Get Lucky"}},
+		{query: `isrc=gbduw0000053`, want: []string{"One More Time"}},
+		{query: `year>=2002`, want: []string{"Get Lucky", "Lose Yourself"}},
+		{query: `year<2002 OR year=2013`, want: []string{"One More Time", "Get Lucky"}},
+		{query: `duration>600`, want: []string{"Podcast"}},
+		{query: `popularity>80 AND NOT explicit`, want: []string{"Get Lucky"}},
+		{query: `explicit`, want: []string{"Lose Yourself"}},
+		{query: `episode OR local`, want: []string{"Podcast", "Demo"}},
+		{query: `explicit=false local!=true episode:false`, want: []string{"One More Time", "Get Lucky"}},
+		{query: `added:2024`, want: []string{"Get Lucky", "Lose Yourself"}},
+		{query: `added<2024-02`, want: []string{"One More Time", "Get Lucky", "Podcast"}},
+		{query: `NOT (artist:daft OR episode) AND NOT local`, want: []string{"Lose Yourself"}},
+		{query: `ORDER BY popularity DESC LIMIT 2`, want: []string{"Lose Yourself", "Get Lucky"}},
+		{query: `artist:daft order by title`, want: []string{"Get Lucky", "One More Time"}},
+		{query: `duration<1000 ORDER BY year DESC, title ASC`, want: []string{"Get Lucky", "Lose Yourself", "One More Time", "Demo"}},
+		{query: `LIMIT 1`, want: []string{"One More Time"}},
+		{query: `artist:nobody`, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+			if got := names(q.Apply(tracks)); !slices.Equal(got, tt.want) {
+				t.Errorf("Apply = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query   string
+		limit   int
+		order   []backup.SortKey
+		wantErr bool
+	}{
+		{query: "  artist:x  ", limit: 0},
+		{query: "ORDER BY added DESC, title LIMIT 5", limit: 5,
+			order: []backup.SortKey{{Field: backup.SortAddedAt, Desc: true}, {Field: backup.SortTitle}}},
+		{query: "order by release_date", order: []backup.SortKey{{Field: backup.SortReleaseDate}}},
+		{query: `artist:"unclosed`, wantErr: true},
+		{query: "artist!x", wantErr: true},
+		{query: "artist", wantErr: true},
+		{query: "artist:", wantErr: true},
+		{query: "colour:red", wantErr: true},
+		{query: "title<abc", wantErr: true},
+		{query: "year>recent", wantErr: true},
+		{query: "explicit>true", wantErr: true},
+		{query: "(artist:x", wantErr: true},
+		{query: "artist:x AND", wantErr: true},
+		{query: "artist:x )", wantErr: true},
+		{query: "ORDER title", wantErr: true},
+		{query: "ORDER BY colour", wantErr: true},
+		{query: "LIMIT 0", wantErr: true},
+		{query: "LIMIT many", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %v", tt.query, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if q.Limit != tt.limit {
+				t.Errorf("Limit = %d, want %d", q.Limit, tt.limit)
+			}
+			if !slices.Equal(q.Order, tt.order) {
+				t.Errorf("Order = %v, want %v", q.Order, tt.order)
+			}
+			if q.String() != q.text || q.text != strings.TrimSpace(tt.query) {
+				t.Errorf("String = %q", q.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Contents: parsing of the selections of more elements of a numbered list (1,4,7-12, all, mine, name filters)
+package selection
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+// Keywords that select all the elements or only the ones of the user, in English and in Italian
+var (
+	allWords  = []string{"all", "tutte", "tutti", "*"}
+	mineWords = []string{"mine", "mie", "miei"}
+)
+
+// Item is an element of the list the user selects from
+type Item struct {
+	Name string
+	// True if the element belongs to the user, selected by "mine"
+	Mine bool
+}
+
+/*
+Parse reads a selection of elements of a list numbered from 1. The parts of the selection are separated by commas and can be:
+  - a number (4) or a range (7-12), also more of them separated by spaces (1 4 7-12)
+  - all, to select all the elements
+  - mine, to select the elements of the user
+  - a text contained in the name (case insensitive), or a pattern with * and ? (rock*)
+  - one of the previous ones preceded by !, to exclude its elements (all,!3); with only exclusions they are removed from all the elements
+
+Returns the indexes (from 0) of the selected elements, in the order of the list, and an error if a part is not valid or doesn't select anything
+*/
+func Parse(text string, items []Item) ([]int, error) {
+	selected := make([]bool, len(items))
+	excluded := make([]bool, len(items))
+	included, parts := false, 0
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		parts++
+		target := selected
+		if strings.HasPrefix(part, "!") {
+			target = excluded
+			part = strings.TrimSpace(part[1:])
+		} else {
+			included = true
+		}
+		indexes, err := parsePart(part, items)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range indexes {
+			target[i] = true
+		}
+	}
+	var res []int
+	for i := range items {
+		// An empty selection selects nothing, not all the elements like the one with only exclusions
+		if (selected[i] || (!included && parts > 0)) && !excluded[i] {
+			res = append(res, i)
+		}
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf(i18n.T("selection.none"), strings.TrimSpace(text))
+	}
+	return res, nil
+}
+
+// parsePart returns the indexes of the elements selected by a part of a selection, see Parse
+func parsePart(part string, items []Item) (res []int, err error) {
+	lower := utils.Lower(part)
+	switch {
+	case slices.Contains(allWords, lower):
+		for i := range items {
+			res = append(res, i)
+		}
+		return res, nil
+	case slices.Contains(mineWords, lower):
+		for i, it := range items {
+			if it.Mine {
+				res = append(res, i)
+			}
+		}
+		if len(res) == 0 {
+			return nil, errors.New(i18n.T("selection.noneMine"))
+		}
+		return res, nil
+	}
+	if numbers, ok := parseNumbers(part); ok {
+		for _, r := range numbers {
+			if r[0] < 1 || r[1] > len(items) {
+				return nil, fmt.Errorf(i18n.T("selection.outOfRange"), part, len(items))
+			}
+			for n := r[0]; n <= r[1]; n++ {
+				res = append(res, n-1)
+			}
+		}
+		return res, nil
+	}
+	pattern := strings.ContainsAny(lower, "*?")
+	for i, it := range items {
+		name := utils.Lower(it.Name)
+		if pattern {
+			if ok, _ := path.Match(lower, name); ok {
+				res = append(res, i)
+			}
+		} else if strings.Contains(name, lower) {
+			res = append(res, i)
+		}
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf(i18n.T("selection.noMatch"), part)
+	}
+	return res, nil
+}
+
+// parseNumbers reads the numbers and the ranges separated by spaces of a part as [first, last] pairs, false if the part contains something else
+func parseNumbers(part string) (ranges [][2]int, ok bool) {
+	for _, f := range strings.Fields(part) {
+		first, last, isRange := strings.Cut(f, "-")
+		a, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, false
+		}
+		b := a
+		if isRange {
+			b, err = strconv.Atoi(last)
+			if err != nil {
+				return nil, false
+			}
+		}
+		if a > b {
+			a, b = b, a
+		}
+		ranges = append(ranges, [2]int{a, b})
+	}
+	return ranges, len(ranges) > 0
+}
@@ -0,0 +1,67 @@
+package selection
+
+import (
+	"slices"
+	"testing"
+)
+
+var items = []Item{
+	{Name: "Rock classics", Mine: true},
+	{Name: "Jazz"},
+	{Name: "Rock 2024", Mine: true},
+	{Name: "Chill"},
+	{Name: "Running"},
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    []int
+		wantErr bool
+	}{
+		{text: "1", want: []int{0}},
+		{text: "1,4", want: []int{0, 3}},
+		{text: " 1 4  5 ", want: []int{0, 3, 4}},
+		{text: "2-4", want: []int{1, 2, 3}},
+		{text: "4-2", want: []int{1, 2, 3}},
+		{text: "5,1,1", want: []int{0, 4}},
+		{text: "all", want: []int{0, 1, 2, 3, 4}},
+		{text: "TUTTE", want: []int{0, 1, 2, 3, 4}},
+		{text: "*", want: []int{0, 1, 2, 3, 4}},
+		{text: "mine", want: []int{0, 2}},
+		{text: "mie,2", want: []int{0, 1, 2}},
+		{text: "rock", want: []int{0, 2}},
+		{text: "r*", want: []int{0, 2, 4}},
+		{text: "ru*", want: []int{4}},
+		{text: "?azz", want: []int{1}},
+		{text: "all,!3", want: []int{0, 1, 3, 4}},
+		{text: "!rock", want: []int{1, 3, 4}},
+		{text: "!1-4", want: []int{4}},
+		{text: "mine,!rock 2024", want: []int{0}},
+		{text: "1,,2", want: []int{0, 1}},
+		{text: "0", wantErr: true},
+		{text: "6", wantErr: true},
+		{text: "3-9", wantErr: true},
+		{text: "metal", wantErr: true},
+		{text: "all,!all", wantErr: true},
+		{text: "", wantErr: true},
+		{text: " , ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := Parse(tt.text, items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %v", tt.text, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMineWithoutItemsOfTheUser(t *testing.T) {
+	if _, err := Parse("mine", []Item{{Name: "Jazz"}}); err == nil {
+		t.Error("Parse(mine) without elements of the user must return an error")
+	}
+}
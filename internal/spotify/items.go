@@ -0,0 +1,304 @@
+package spotify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"playlist-manager/pkg/i18n"
+	"slices"
+	"strconv"
+	"time"
+
+	api "github.com/zmb3/spotify/v2"
+
+	"playlist-manager/internal/backup"
+	log "playlist-manager/pkg/logger"
+)
+
+// playlistItemsURL is the endpoint used to add items of any type (tracks and episodes) to a playlist
+const playlistItemsURL = "https://api.spotify.com/v1/playlists/%s/tracks"
+
+/*
+PlaylistItemDetails converts an item of a playlist returned by the API (a track, a podcast episode or a local file)
+to the item stored in the backup files
+Returns the item and false if it is not available on Spotify (nothing is returned by the API)
+*/
+func PlaylistItemDetails(item api.PlaylistItem) (backup.Track, bool) {
+	t, ok := itemDetails(item)
+	t.AddedBy = item.AddedBy.ID
+	return t, ok
+}
+
+// itemDetails converts an item of a playlist depending on its type, see PlaylistItemDetails
+func itemDetails(item api.PlaylistItem) (backup.Track, bool) {
+	switch {
+	case item.Track.Episode != nil:
+		e := item.Track.Episode
+		return backup.Track{
+			ID:       e.ID,
+			URI:      e.URI,
+			Name:     e.Name,
+			Artists:  []string{e.Show.Name},
+			Album:    e.Show.Name,
+			Duration: int(e.Duration_ms),
+			AddedAt:  item.AddedAt,
+			Type:     backup.ItemEpisode,
+
+			ReleaseDate: e.ReleaseDate,
+			Explicit:    e.Explicit,
+		}, true
+	case item.Track.Track == nil:
+		return backup.Track{}, false
+	case item.IsLocal || item.Track.Track.ID == "":
+		// Local files have no ID, only their details and an URI like spotify:local:<artist>:<album>:<title>:<seconds>
+		t := backupTrack(item.Track.Track, item.AddedAt)
+		t.Type = backup.ItemLocal
+		return t, t.URI != "" || t.Name != ""
+	default:
+		return backupTrack(item.Track.Track, item.AddedAt), true
+	}
+}
+
+/*
+AddItemsToPlaylist adds the items (tracks and podcast episodes) given their URI to a playlist, in batches of 100 keeping their order.
+The api package only adds tracks, so the request is made directly
+Returns the snapshot ID of the new version of the playlist and an error, if present
+*/
+func AddItemsToPlaylist(uris []api.URI, playlistID api.ID) (snapshotID string, err error) {
+	for i := 0; i < len(uris); i += 100 {
+		snapshotID, err = playlistItemsRequest(http.MethodPost, playlistID, map[string][]api.URI{"uris": uris[i:min(i+100, len(uris))]})
+		if err != nil {
+			return snapshotID, err
+		}
+	}
+	return snapshotID, nil
+}
+
+/*
+playlistItemsRequest sends a request with a JSON body to the endpoint of the items of a playlist
+Returns the snapshot ID of the new version of the playlist and an error, if present
+*/
+func playlistItemsRequest(method string, playlistID api.ID, body any) (string, error) {
+	var snapshot struct {
+		SnapshotID string `json:"snapshot_id"`
+	}
+	err := jsonRequest(method, fmt.Sprintf(playlistItemsURL, playlistID), body, &snapshot)
+	return snapshot.SnapshotID, err
+}
+
+/*
+jsonRequest sends a request with a JSON body to the API and decodes the response in result, if not nil.
+When the rate limit is exceeded (429) it waits as long as the Retry-After header says and tries again, as the api client does with WithRetry
+Returns an error, if present
+*/
+func jsonRequest(method string, url string, body any, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	for {
+		req, err := http.NewRequestWithContext(context, method, url, bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		res, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		if res.StatusCode == http.StatusTooManyRequests {
+			res.Body.Close()
+			wait := retryAfter(res)
+			log.Warn(i18n.T("log.rateLimited"), "url", url, "wait", wait)
+			time.Sleep(wait)
+			continue
+		}
+		defer res.Body.Close()
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			var e struct {
+				Error api.Error `json:"error"`
+			}
+			json.NewDecoder(res.Body).Decode(&e)
+			if e.Error.Message == "" {
+				e.Error.Message = res.Status
+			}
+			e.Error.Status = res.StatusCode
+			return e.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.NewDecoder(res.Body).Decode(result)
+	}
+}
+
+// retryAfter returns how long to wait before sending again a request refused for the rate limit, one second if the API doesn't say
+func retryAfter(res *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || seconds < 1 {
+		return time.Second
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+/*
+GetPositionedItems returns the items of a playlist with their position (starting from 1) and the snapshot ID of the version read,
+so specific occurrences can be removed with RemovePositions. The items not available on Spotify are skipped, the positions of the others are kept
+Returns the items, the snapshot ID and an error, if present
+*/
+func GetPositionedItems(playlistID api.ID) (items []backup.Change, snapshotID string, err error) {
+	// The snapshot is read before the items: if the playlist changes in the meantime the removal fails instead of removing the wrong items
+	p, err := client.GetPlaylist(context, playlistID, api.Fields("snapshot_id"))
+	if err != nil {
+		return nil, "", err
+	}
+	tracks, err := GetTracks(playlistID)
+	if err != nil {
+		return nil, "", err
+	}
+	for i, t := range tracks {
+		if item, ok := PlaylistItemDetails(t); ok {
+			items = append(items, backup.Change{Track: item, Position: i + 1})
+		}
+	}
+	return items, p.SnapshotID, nil
+}
+
+// TrackRemoval is an item to remove from a playlist: all its occurrences or, if Positions is not empty, only the ones at those positions (starting from 0)
+type TrackRemoval struct {
+	URI       api.URI
+	Positions []int
+}
+
+/*
+RemoveFromPlaylist removes items from a playlist in batches of 100, every batch is made on the version returned by the previous one.
+If snapshotID is not empty Spotify checks the positions against that version of the playlist and the batch fails, without changes,
+if an item is not where expected. The occurrences with a position are removed first, from the last one, so the removal of a batch
+doesn't change the positions of the next ones
+Returns the snapshot ID of the new version of the playlist and an error, if present
+*/
+func RemoveFromPlaylist(playlistID api.ID, snapshotID string, items []TrackRemoval) (string, error) {
+	type occurrence struct {
+		uri      api.URI
+		position int
+	}
+	var positioned []occurrence
+	var all []api.URI
+	for _, item := range items {
+		if len(item.Positions) == 0 {
+			if !slices.Contains(all, item.URI) {
+				all = append(all, item.URI)
+			}
+			continue
+		}
+		for _, pos := range item.Positions {
+			positioned = append(positioned, occurrence{item.URI, pos})
+		}
+	}
+	slices.SortFunc(positioned, func(a, b occurrence) int { return b.position - a.position })
+
+	// The request is made directly because the api package always sends the positions and only removes tracks by ID
+	type trackToRemove struct {
+		URI       api.URI `json:"uri"`
+		Positions []int   `json:"positions,omitempty"`
+	}
+	send := func(tracks []trackToRemove) error {
+		if len(tracks) == 0 {
+			return nil
+		}
+		body := struct {
+			Tracks     []trackToRemove `json:"tracks"`
+			SnapshotID string          `json:"snapshot_id,omitempty"`
+		}{tracks, snapshotID}
+		newSnapshotID, err := playlistItemsRequest(http.MethodDelete, playlistID, body)
+		if err != nil {
+			return err
+		}
+		snapshotID = newSnapshotID
+		return nil
+	}
+	var batch []trackToRemove
+	index := map[api.URI]int{}
+	count := 0
+	for _, o := range positioned {
+		if count == 100 {
+			if err := send(batch); err != nil {
+				return snapshotID, err
+			}
+			batch, index, count = nil, map[api.URI]int{}, 0
+		}
+		j, ok := index[o.uri]
+		if !ok {
+			j = len(batch)
+			index[o.uri] = j
+			batch = append(batch, trackToRemove{URI: o.uri})
+		}
+		batch[j].Positions = append(batch[j].Positions, o.position)
+		count++
+	}
+	if err := send(batch); err != nil {
+		return snapshotID, err
+	}
+	for i := 0; i < len(all); i += 100 {
+		batch = nil
+		for _, uri := range all[i:min(i+100, len(all))] {
+			batch = append(batch, trackToRemove{URI: uri})
+		}
+		if err := send(batch); err != nil {
+			return snapshotID, err
+		}
+	}
+	return snapshotID, nil
+}
+
+/*
+RemovePositions removes from a playlist only the given occurrences of its items (with the position starting from 1),
+checked by Spotify against the version of the playlist identified by snapshotID (see RemoveFromPlaylist)
+Returns the snapshot ID of the new version of the playlist and an error, if present
+*/
+func RemovePositions(playlistID api.ID, snapshotID string, items []backup.Change) (string, error) {
+	removals := make([]TrackRemoval, 0, len(items))
+	for _, c := range items {
+		removals = append(removals, TrackRemoval{URI: c.Track.URI, Positions: []int{c.Position - 1}})
+	}
+	return RemoveFromPlaylist(playlistID, snapshotID, removals)
+}
+
+/*
+ReorderPlaylist applies the moves to a playlist in order, every move is made on the version returned by the previous one
+starting from snapshotID (optional)
+Returns the snapshot ID of the new version of the playlist and an error, if present
+*/
+func ReorderPlaylist(playlistID api.ID, snapshotID string, moves []backup.Reorder) (string, error) {
+	for _, m := range moves {
+		newSnapshotID, err := client.ReorderPlaylistTracks(context, playlistID, api.PlaylistReorderOptions{
+			RangeStart:   api.Numeric(m.RangeStart),
+			RangeLength:  api.Numeric(m.RangeLength),
+			InsertBefore: api.Numeric(m.InsertBefore),
+			SnapshotID:   snapshotID,
+		})
+		if err != nil {
+			return snapshotID, err
+		}
+		snapshotID = newSnapshotID
+	}
+	return snapshotID, nil
+}
+
+/*
+ReplacePlaylistItems replaces all the items of a playlist with the given ones (tracks and podcast episodes), in their order:
+the first 100 replace the content of the playlist, the others are added in batches (see AddItemsToPlaylist)
+Returns the snapshot ID of the new version of the playlist and an error, if present
+*/
+func ReplacePlaylistItems(uris []api.URI, playlistID api.ID) (string, error) {
+	first := uris[:min(100, len(uris))]
+	if first == nil {
+		first = []api.URI{}
+	}
+	snapshotID, err := playlistItemsRequest(http.MethodPut, playlistID, map[string][]api.URI{"uris": first})
+	if err != nil || len(uris) <= 100 {
+		return snapshotID, err
+	}
+	return AddItemsToPlaylist(uris[100:], playlistID)
+}
@@ -0,0 +1,219 @@
+package spotify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"playlist-manager/pkg/i18n"
+	"slices"
+	"time"
+
+	api "github.com/zmb3/spotify/v2"
+
+	"playlist-manager/internal/backup"
+	log "playlist-manager/pkg/logger"
+)
+
+// LibraryResult contains the result of the restore of a library backup
+type LibraryResult struct {
+	Restored int // Items added to the library
+	Present  int // Items already in the library, not added again
+}
+
+/*
+GetLibrary returns the current state of a part of the library of the authenticated user (liked songs, saved albums or followed artists),
+in the same order shown by Spotify (from the most recently added)
+Returns the backup and an error, if present
+*/
+func GetLibrary(kind backup.Kind) (p backup.Playlist, err error) {
+	switch kind {
+	case backup.KindLikedSongs:
+		return getLikedSongs()
+	case backup.KindSavedAlbums:
+		return getSavedAlbums()
+	case backup.KindFollowedArtists:
+		return getFollowedArtists()
+	default:
+		return p, fmt.Errorf(i18n.T("library.invalidKind"), kind)
+	}
+}
+
+// getLikedSongs returns the liked songs of the user, with the date they were added
+func getLikedSongs() (backup.Playlist, error) {
+	p := backup.LibraryPlaylist(backup.KindLikedSongs)
+	res, err := client.CurrentUsersTracks(context, api.Limit(50))
+	if err != nil {
+		return p, err
+	}
+	for {
+		for _, t := range res.Tracks {
+			if t.ID == "" {
+				// As for the playlists, the songs no longer available are counted so the backup shows they are missing
+				log.Warn(i18n.T("log.itemNotSaved"), "playlistName", p.Name, "playlistID", p.ID, "position", len(p.TrackIDs)+p.Unavailable+1)
+				p.Unavailable++
+				continue
+			}
+			p.TrackIDs = append(p.TrackIDs, t.ID)
+			p.Items = append(p.Items, backupTrack(&t.FullTrack, t.AddedAt))
+		}
+		err = client.NextPage(context, res)
+		if errors.Is(err, api.ErrNoMorePages) {
+			return p, nil
+		} else if err != nil {
+			return p, err
+		}
+	}
+}
+
+// getSavedAlbums returns the albums saved by the user, with the date they were added
+func getSavedAlbums() (backup.Playlist, error) {
+	p := backup.LibraryPlaylist(backup.KindSavedAlbums)
+	res, err := client.CurrentUsersAlbums(context, api.Limit(50))
+	if err != nil {
+		return p, err
+	}
+	for {
+		for _, a := range res.Albums {
+			artists := make([]string, 0, len(a.Artists))
+			for _, artist := range a.Artists {
+				artists = append(artists, artist.Name)
+			}
+			p.TrackIDs = append(p.TrackIDs, a.ID)
+			p.Items = append(p.Items, backup.Track{
+				ID:      a.ID,
+				URI:     a.URI,
+				Name:    a.Name,
+				Artists: artists,
+				Album:   a.Name,
+				AddedAt: a.AddedAt,
+			})
+		}
+		err = client.NextPage(context, res)
+		if errors.Is(err, api.ErrNoMorePages) {
+			return p, nil
+		} else if err != nil {
+			return p, err
+		}
+	}
+}
+
+// getFollowedArtists returns the artists followed by the user, the API uses a cursor instead of pages
+func getFollowedArtists() (backup.Playlist, error) {
+	p := backup.LibraryPlaylist(backup.KindFollowedArtists)
+	after := ""
+	for {
+		opts := []api.RequestOption{api.Limit(50)}
+		if after != "" {
+			opts = append(opts, api.After(after))
+		}
+		res, err := client.CurrentUsersFollowedArtists(context, opts...)
+		if err != nil {
+			return p, err
+		}
+		for _, a := range res.Artists {
+			p.TrackIDs = append(p.TrackIDs, a.ID)
+			p.Items = append(p.Items, backup.Track{ID: a.ID, URI: a.URI, Name: a.Name})
+		}
+		if res.Cursor.After == "" || len(res.Artists) == 0 {
+			return p, nil
+		}
+		after = res.Cursor.After
+	}
+}
+
+// likedSongsURL is the endpoint used to add liked songs with the date they were added, not supported by the api package
+const likedSongsURL = "https://api.spotify.com/v1/me/tracks"
+
+/*
+RestoreLibrary adds the content of a library backup to the library of the authenticated user, skipping what is already present.
+The liked songs are added with the date they were added in the backup, so they are shown in the original order;
+the saved albums are added in batches from the oldest to the newest, the order inside a batch is not kept.
+progress, if not nil, is called after every batch added
+Returns the result and an error, if present
+*/
+func RestoreLibrary(p backup.Playlist, progress func(done int, total int)) (res LibraryResult, err error) {
+	var has func(ids []api.ID) ([]bool, error)
+	var add func(ids ...api.ID) error
+	batch := 50
+	switch p.Kind {
+	case backup.KindLikedSongs:
+		has = func(ids []api.ID) ([]bool, error) { return client.UserHasTracks(context, ids...) }
+		add = func(ids ...api.ID) error { return addLikedSongs(ids, addedDates(p)) }
+	case backup.KindSavedAlbums:
+		has = func(ids []api.ID) ([]bool, error) { return client.UserHasAlbums(context, ids...) }
+		add = func(ids ...api.ID) error { return client.AddAlbumsToLibrary(context, ids...) }
+		batch = 20
+	case backup.KindFollowedArtists:
+		has = func(ids []api.ID) ([]bool, error) {
+			return client.CurrentUserFollows(context, "artist", ids...)
+		}
+		add = func(ids ...api.ID) error { return client.FollowArtist(context, ids...) }
+	default:
+		return res, fmt.Errorf(i18n.T("library.notLibraryBackup"), p.Name)
+	}
+
+	// Skip the items already in the library
+	var missing []api.ID
+	for i := 0; i < len(p.TrackIDs); i += batch {
+		ids := p.TrackIDs[i:min(i+batch, len(p.TrackIDs))]
+		present, err := has(ids)
+		if err != nil {
+			return res, err
+		}
+		for j, id := range ids {
+			if j < len(present) && present[j] {
+				res.Present++
+			} else {
+				missing = append(missing, id)
+			}
+		}
+	}
+
+	// From the oldest, so the newest items are added last and shown first like in the backup
+	slices.Reverse(missing)
+	for i := 0; i < len(missing); i += batch {
+		ids := missing[i:min(i+batch, len(missing))]
+		err = add(ids...)
+		if err != nil {
+			return res, err
+		}
+		res.Restored += len(ids)
+		if progress != nil {
+			progress(res.Restored, len(missing))
+		}
+	}
+	log.Info(i18n.T("log.libraryRestored"), "kind", p.Kind, "restored", res.Restored, "present", res.Present)
+	return res, nil
+}
+
+// addedDates returns the date each item of a library backup was added, by ID
+func addedDates(p backup.Playlist) map[api.ID]string {
+	dates := make(map[api.ID]string, len(p.Items))
+	for _, t := range p.Items {
+		dates[t.ID] = t.AddedAt
+	}
+	return dates
+}
+
+/*
+addLikedSongs adds at most 50 songs, sorted from the oldest, to the liked songs of the user with the date they were added (timestamped_ids),
+a song without date gets the one of the song before it, or the current time if it is the first of the batch
+Returns an error, if present
+*/
+func addLikedSongs(ids []api.ID, dates map[api.ID]string) error {
+	type timestampedID struct {
+		ID      api.ID `json:"id"`
+		AddedAt string `json:"added_at"`
+	}
+	body := struct {
+		IDs []timestampedID `json:"timestamped_ids"`
+	}{}
+	last := time.Now().UTC().Format(time.RFC3339)
+	for _, id := range ids {
+		if date := dates[id]; date != "" {
+			last = date
+		}
+		body.IDs = append(body.IDs, timestampedID{ID: id, AddedAt: last})
+	}
+	return jsonRequest(http.MethodPut, likedSongsURL, body, nil)
+}
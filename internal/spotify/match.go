@@ -0,0 +1,178 @@
+package spotify
+
+import (
+	"math"
+	"playlist-manager/pkg/i18n"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+
+	"playlist-manager/internal/backup"
+	log "playlist-manager/pkg/logger"
+	"playlist-manager/pkg/utils"
+)
+
+// Methods used by MatchTracks to find a track on Spotify, as keys of the catalogue of the messages
+const (
+	MatchByID     = "match.uri"
+	MatchByISRC   = "match.isrc"
+	MatchBySearch = "match.search"
+)
+
+// searchLimit is the number of results of a search compared with the track to match
+const searchLimit = 10
+
+// Match is the result of the search on Spotify of a track read from a file
+type Match struct {
+	Source backup.Track
+	Track  *api.FullTrack // nil if no track has been found
+	Score  float64        // Confidence of the match, from 0 to 1
+	Method string         // How the track has been found: MatchByID, MatchByISRC or MatchBySearch
+}
+
+// Found returns true if a track has been found
+func (m Match) Found() bool {
+	return m.Track != nil
+}
+
+/*
+MatchTracks searches on Spotify the tracks read from a file: the ones with a Spotify ID are checked in batches,
+the others are searched by ISRC and then by title, artists and duration, choosing the result with the highest confidence.
+progress, if not nil, is called after every track
+Returns the matches, in the same order of the tracks, and an error, if present
+*/
+func MatchTracks(tracks []backup.Track, progress func(done int, total int)) (matches []Match, err error) {
+	matches = make([]Match, len(tracks))
+	var ids []api.ID
+	for i, t := range tracks {
+		matches[i].Source = t
+		if t.ID != "" {
+			ids = append(ids, t.ID)
+		}
+	}
+
+	// Tracks with the Spotify ID: they only need to be available
+	byID := map[api.ID]*api.FullTrack{}
+	if len(ids) > 0 {
+		details, err := GetTrackDetails(ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range details {
+			if d != nil {
+				byID[d.ID] = d
+			}
+		}
+	}
+
+	for i, t := range tracks {
+		if d, ok := byID[t.ID]; ok && t.ID != "" {
+			matches[i].Track, matches[i].Score, matches[i].Method = d, 1, MatchByID
+		} else {
+			matches[i], err = MatchTrack(t)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if progress != nil {
+			progress(i+1, len(tracks))
+		}
+	}
+	return matches, nil
+}
+
+/*
+MatchTrack searches a track on Spotify by ISRC (exact match) and then by title, artists and duration
+Returns the best match, without track if nothing similar has been found, and an error, if present
+*/
+func MatchTrack(t backup.Track) (m Match, err error) {
+	m.Source = t
+	if t.ISRC != "" {
+		results, err := SearchTracks("isrc:"+t.ISRC, 1)
+		if err != nil {
+			return m, err
+		}
+		if len(results) > 0 {
+			m.Track, m.Score, m.Method = &results[0], 1, MatchByISRC
+			return m, nil
+		}
+		log.Debug(i18n.T("log.matchNoISRC"), "isrc", t.ISRC, "track", t.String())
+	}
+	if t.Name == "" {
+		return m, nil
+	}
+
+	queries := []string{}
+	name := strings.ReplaceAll(t.Name, "\"", "")
+	if len(t.Artists) > 0 {
+		queries = append(queries, "track:\""+name+"\" artist:\""+strings.ReplaceAll(t.Artists[0], "\"", "")+"\"")
+	}
+	queries = append(queries, strings.TrimSpace(name+" "+strings.Join(t.Artists, " ")))
+	for _, q := range queries {
+		results, err := SearchTracks(q, searchLimit)
+		if err != nil {
+			return m, err
+		}
+		for i := range results {
+			score := MatchScore(t, results[i])
+			if score > m.Score {
+				m.Track, m.Score, m.Method = &results[i], score, MatchBySearch
+			}
+		}
+		if m.Track != nil {
+			break
+		}
+	}
+	return m, nil
+}
+
+/*
+SearchTracks searches tracks on Spotify with the given query (it supports the filters of Spotify, for example isrc:, track:, artist:)
+Returns at most limit tracks and an error, if present
+*/
+func SearchTracks(query string, limit int) ([]api.FullTrack, error) {
+	res, err := client.Search(context, query, api.SearchTypeTrack, api.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+	if res.Tracks == nil {
+		return nil, nil
+	}
+	return res.Tracks.Tracks, nil
+}
+
+/*
+MatchScore returns the confidence (from 0 to 1) that the Spotify track is the same as the track read from a file,
+comparing the normalised title (50%), artists (30%) and duration (20%). Missing information is not counted
+*/
+func MatchScore(t backup.Track, candidate api.FullTrack) float64 {
+	score, weight := 0.5*utils.Similarity(utils.Normalise(t.Name), utils.Normalise(candidate.Name)), 0.5
+	if len(t.Artists) > 0 {
+		var names []string
+		for _, a := range candidate.Artists {
+			names = append(names, a.Name)
+		}
+		score += 0.3 * artistsSimilarity(t.Artists, names)
+		weight += 0.3
+	}
+	if t.Duration > 0 {
+		// Full score within 3 seconds, none over 30 seconds of difference
+		diff := math.Abs(float64(t.Duration-int(candidate.Duration))) / 1000
+		score += 0.2 * math.Max(0, math.Min(1, (30-diff)/27))
+		weight += 0.2
+	}
+	return score / weight
+}
+
+// artistsSimilarity returns, for every artist of the file, the best similarity with the artists of Spotify, averaged
+func artistsSimilarity(artists []string, candidates []string) float64 {
+	total := 0.0
+	for _, a := range artists {
+		best := 0.0
+		for _, c := range candidates {
+			best = math.Max(best, utils.Similarity(utils.Normalise(a), utils.Normalise(c)))
+		}
+		total += best
+	}
+	return total / float64(len(artists))
+}
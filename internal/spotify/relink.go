@@ -0,0 +1,167 @@
+package spotify
+
+import (
+	api "github.com/zmb3/spotify/v2"
+	"playlist-manager/pkg/i18n"
+
+	"playlist-manager/internal/backup"
+	log "playlist-manager/pkg/logger"
+)
+
+// How a track has been resolved by ResolveTracks, as keys of the catalogue of the messages
+const (
+	ResolvedPlayable = "relink.playable"    // The track is playable as it is
+	ResolvedRelinked = "relink.relinked"    // Spotify replaced the track with its playable version in the market of the user
+	ResolvedByISRC   = "relink.isrc"        // The track is not available anymore, a playable track with the same ISRC has been found
+	ResolvedNone     = "relink.unavailable" // No playable track has been found
+)
+
+// Resolution is the result of the check of a track in the market of the user
+type Resolution struct {
+	Source backup.Track
+	Track  *api.FullTrack // Playable track to use in place of the source, nil if no track has been found
+	Status string         // ResolvedPlayable, ResolvedRelinked, ResolvedByISRC or ResolvedNone
+}
+
+// Found returns true if a playable track has been found
+func (r Resolution) Found() bool {
+	return r.Track != nil
+}
+
+// Substituted returns true if the track to use is different from the source one
+func (r Resolution) Substituted() bool {
+	return r.Track != nil && r.Track.ID != r.Source.ID
+}
+
+/*
+ResolveTracks checks that the tracks are playable in the market of the user (requesting them with track relinking, in batches of 50):
+the tracks relinked by Spotify are replaced by the playable version, the ones not available anymore are searched by ISRC
+Returns the resolutions, in the same order of the tracks, and an error, if present
+*/
+func ResolveTracks(tracks []backup.Track) ([]Resolution, error) {
+	resolutions := make([]Resolution, len(tracks))
+	for i := 0; i < len(tracks); i += 50 {
+		batch := tracks[i:min(i+50, len(tracks))]
+		ids := make([]api.ID, 0, len(batch))
+		for _, t := range batch {
+			ids = append(ids, t.ID)
+		}
+		// The tracks are returned in the same order of the IDs, also when relinked to a different ID
+		full, err := client.GetTracks(context, ids, api.Market(api.MarketFromToken))
+		if err != nil {
+			return nil, err
+		}
+		for j, t := range batch {
+			var f *api.FullTrack
+			if j < len(full) {
+				f = full[j]
+			}
+			resolutions[i+j], err = resolveTrack(t, f)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resolutions, nil
+}
+
+/*
+ResolveTrackIDs is like ResolveTracks for a list of track IDs
+Returns the resolutions, in the same order of the IDs, and an error, if present
+*/
+func ResolveTrackIDs(ids []api.ID) ([]Resolution, error) {
+	tracks := make([]backup.Track, 0, len(ids))
+	for _, id := range ids {
+		tracks = append(tracks, backup.Track{ID: id, URI: api.URI("spotify:track:" + string(id)), Name: string(id)})
+	}
+	return ResolveTracks(tracks)
+}
+
+// resolveTrack returns the resolution of a track given its details requested with relinking (nil if it is not in the catalogue anymore)
+func resolveTrack(t backup.Track, full *api.FullTrack) (r Resolution, err error) {
+	if full != nil && (t.Name == "" || t.Name == string(t.ID)) {
+		t = backupTrack(full, t.AddedAt)
+		t.ID = resolvedSourceID(full)
+	}
+	r = Resolution{Source: t, Status: ResolvedNone}
+	if full != nil && playable(full) {
+		r.Track, r.Status = full, ResolvedPlayable
+		if full.ID != t.ID {
+			r.Status = ResolvedRelinked
+		}
+		return r, nil
+	}
+
+	isrc := t.ISRC
+	if isrc == "" && full != nil {
+		isrc = full.ExternalIDs["isrc"]
+	}
+	if isrc != "" {
+		res, err := client.Search(context, "isrc:"+isrc, api.SearchTypeTrack, api.Market(api.MarketFromToken), api.Limit(5))
+		if err != nil {
+			return r, err
+		}
+		if res.Tracks != nil {
+			for i := range res.Tracks.Tracks {
+				if playable(&res.Tracks.Tracks[i]) {
+					r.Track, r.Status = &res.Tracks.Tracks[i], ResolvedByISRC
+					break
+				}
+			}
+		}
+	}
+	if r.Found() {
+		log.Info(i18n.T("log.relinked"), "track", t.String(), "trackID", t.ID, "newTrackID", r.Track.ID, "status", r.Status)
+	} else {
+		log.Warn(i18n.T("log.relinkNone"), "track", t.String(), "trackID", t.ID, "isrc", isrc)
+	}
+	return r, nil
+}
+
+// resolvedSourceID returns the ID requested for a track returned with relinking
+func resolvedSourceID(full *api.FullTrack) api.ID {
+	if full.LinkedFrom != nil && full.LinkedFrom.ID != "" {
+		return api.ID(full.LinkedFrom.ID)
+	}
+	return full.ID
+}
+
+// playable returns true if the track can be played in the market of the user, the API reports it only when the market is requested
+func playable(t *api.FullTrack) bool {
+	return t.IsPlayable == nil || *t.IsPlayable
+}
+
+/*
+PlayableURIs returns the URIs to add to a playlist to restore the items, in their order: the tracks are checked with ResolveTracks
+and the ones that are not playable are replaced or, if no substitute has been found, skipped. Episodes are added as they are
+Returns the URIs, the resolutions of the tracks substituted or not found and an error, if present
+*/
+func PlayableURIs(items []backup.Track) (uris []api.URI, changed []Resolution, err error) {
+	var tracks []backup.Track
+	for _, t := range items {
+		if t.Type == backup.ItemTrack && t.ID != "" {
+			tracks = append(tracks, t)
+		}
+	}
+	resolutions, err := ResolveTracks(tracks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next := 0
+	for _, t := range items {
+		if t.Type != backup.ItemTrack || t.ID == "" {
+			uris = append(uris, t.URI)
+			continue
+		}
+		r := resolutions[next]
+		next++
+		if r.Substituted() || !r.Found() {
+			changed = append(changed, r)
+		}
+		if r.Found() {
+			uris = append(uris, r.Track.URI)
+		}
+	}
+	return uris, changed, nil
+}
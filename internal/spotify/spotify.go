@@ -1,13 +1,21 @@
 package spotify
 
 import (
+	"bytes"
 	ctx "context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
 	"net/http"
+	"net/url"
 	"os"
+	"path"
+	"playlist-manager/internal/backup"
+	"playlist-manager/pkg/i18n"
 	"playlist-manager/pkg/utils"
+	"strings"
 	"time"
 
 	log "playlist-manager/pkg/logger"
@@ -27,17 +35,14 @@ var (
 	authDone      bool
 	authenticator *apiauth.Authenticator
 	client        *api.Client
+	httpClient    *http.Client // Authenticated client used for the requests not supported by the api package
 	context       ctx.Context  = ctx.Background()
 	srv           *http.Server // Gin server used to receive the auth token
 	ch            = make(chan *api.Client)
 )
 
-// Playlist struct used to store the playlist data in json files to backup and restore them
-type Playlist struct {
-	ID       api.ID   `json:"id"`
-	Name     string   `json:"name"`
-	TrackIDs []api.ID `json:"tracks"`
-}
+// Playlist struct used to store the playlist data in json files to backup and restore them, see the backup package
+type Playlist = backup.Playlist
 
 // IsAuthenticated returns true if the user is authenticated or false otherwise
 func IsAuthenticated() bool {
@@ -50,23 +55,23 @@ func Init() {
 	//Backup dir
 	if _, err := os.Stat("data/backup"); os.IsNotExist(err) {
 		os.MkdirAll("data/backup", 0644) // Create dir if not exists
-		log.Info("Cartella data/backup creata")
+		log.Info(i18n.T("log.dirBackupCreated"))
 	}
 	//Auth dir
 	if _, err := os.Stat("data/auth"); os.IsNotExist(err) {
 		os.MkdirAll("data/auth", 0644) // Create dir if not exists
-		log.Info("Cartella data/auth creata")
+		log.Info(i18n.T("log.dirAuthCreated"))
 	}
 	//Playlists dir
 	if _, err := os.Stat("data/playlists"); os.IsNotExist(err) {
 		os.MkdirAll("data/playlists", 0644) // Create dir if not exists
-		log.Info("Cartella data/playlists creata")
+		log.Info(i18n.T("log.dirPlaylistsCreated"))
 	}
 
 	//Get or create auth vars
 	err := getAuthVars()
 	if err != nil {
-		log.Fatal("Inizializzazione Spotify: errore nel recupero delle variabili per l'autenticazione", "error", err)
+		log.Fatal(i18n.T("log.authVarsError"), "error", err)
 	}
 
 	//Gin router setup
@@ -107,7 +112,7 @@ func readAuthVars() (vars *authVarsModel, err error) {
 	vars = &authVarsModel{}
 	data, err := os.ReadFile("data/auth/auth.yaml")
 	if errors.Is(err, os.ErrNotExist) {
-		log.Warn("File data/auth/auth.yaml non esistente. Verranno generate nuove variabili per l'autenticazione.")
+		log.Warn(i18n.T("log.authVarsMissing"))
 		return
 	} else if err != nil {
 		return
@@ -117,7 +122,7 @@ func readAuthVars() (vars *authVarsModel, err error) {
 	if err != nil {
 		return
 	}
-	log.Info("Variabili per l'autenticazione lette da data/auth/auth.yaml")
+	log.Info(i18n.T("log.authVarsRead"))
 	return vars, nil
 }
 
@@ -141,7 +146,7 @@ func createAuthVars() (vars *authVarsModel, err error) {
 	if err != nil {
 		return
 	}
-	log.Info("Variabili per l'autenticazione generate e salvate in data/auth/auth.yaml")
+	log.Info(i18n.T("log.authVarsSaved"))
 	return vars, nil
 }
 
@@ -171,9 +176,9 @@ func getAuthVars() (err error) {
 func startServer() {
 	err := srv.ListenAndServe()
 	if errors.Is(err, http.ErrServerClosed) {
-		log.Info("Server per l'autenticazione chiuso con successo")
+		log.Info(i18n.T("log.authServerClosed"))
 	} else if err != nil {
-		log.Fatal("[Spotify] Chiusura server", "error", err)
+		log.Fatal(i18n.T("log.authServerCloseError"), "error", err)
 	}
 }
 
@@ -182,36 +187,39 @@ Auth authenticates the user and saves the token in the data/auth/token.json file
 Returns an error, if present
 */
 func Auth() (err error) {
-	authenticator = apiauth.New(apiauth.WithRedirectURL("http://localhost/api/auth"), apiauth.WithScopes(apiauth.ScopeUserReadPrivate, apiauth.ScopePlaylistReadPrivate, apiauth.ScopePlaylistReadCollaborative, apiauth.ScopePlaylistModifyPrivate, apiauth.ScopePlaylistModifyPublic))
+	authenticator = apiauth.New(apiauth.WithRedirectURL("http://localhost/api/auth"), apiauth.WithScopes(apiauth.ScopeUserReadPrivate, apiauth.ScopePlaylistReadPrivate, apiauth.ScopePlaylistReadCollaborative, apiauth.ScopePlaylistModifyPrivate, apiauth.ScopePlaylistModifyPublic,
+		apiauth.ScopeUserLibraryRead, apiauth.ScopeUserLibraryModify, apiauth.ScopeUserFollowRead, apiauth.ScopeUserFollowModify, apiauth.ScopeImageUpload))
 
 	token, err := readAuthToken()
 	if err == nil {
 		if token.Valid() {
 			//Token expired
-			log.Info("Token per l'autenticazione scaduto. Verrà rieffettuata l'autenticazione.")
+			log.Info(i18n.T("log.tokenExpired"))
 			token, err = authenticator.RefreshToken(context, token)
 			if err != nil {
-				log.Error("Errore nel refresh del token per l'autenticazione: " + err.Error())
+				log.Error(i18n.T("log.tokenRefreshError") + err.Error())
 				return err
 			}
 		}
-		client = api.New(authenticator.Client(context, token))
+		httpClient = authenticator.Client(context, token)
+		// The requests refused for the rate limit are sent again after the time given by Spotify, so long operations are not stopped halfway
+		client = api.New(httpClient, api.WithRetry(true))
 		authDone = true
 		return nil
 
 	} else {
 		//authURL generation
 		authURL := authenticator.AuthURL(authVars.State)
-		log.Info("URL per l'autenticazione generata")
+		log.Info(i18n.T("log.authURL"))
 
-		fmt.Println("Apri questo URL per autenticarti: " + authURL)
+		fmt.Println(i18n.T("auth.openURL") + authURL)
 		if authURL == "" {
-			fmt.Println("Errore: URL per l'autenticazione non disponibile")
-			return errors.New("URL di autenticazione non disponibile")
+			fmt.Println(i18n.T("auth.noURL"))
+			return errors.New(i18n.T("auth.noURLError"))
 		}
 
 		// Starting the server
-		log.Info("Avvio il server per l'autenticazione...")
+		log.Info(i18n.T("log.authServerStart"))
 
 		go startServer()
 
@@ -219,7 +227,7 @@ func Auth() (err error) {
 		client = <-ch
 
 		// Auth completed
-		log.Info("Autenticazione completata")
+		log.Info(i18n.T("log.authCompleted"))
 
 		// Closing the server
 		shutdownCtx, shutdownRelease := ctx.WithTimeout(ctx.Background(), 10*time.Second)
@@ -227,7 +235,7 @@ func Auth() (err error) {
 
 		err = srv.Shutdown(shutdownCtx)
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Fatal("Errore nella chiusura del server: " + err.Error())
+			log.Fatal(i18n.T("log.authServerShutdownError") + err.Error())
 		}
 
 		authDone = true
@@ -239,20 +247,26 @@ func Auth() (err error) {
 func authEndpoint(ctx *gin.Context) {
 	token, err := authenticator.Token(context, authVars.State, ctx.Request)
 	if err != nil {
-		log.Error("Ottenimento del token da Spotify: ", "error", err)
-		ctx.String(http.StatusNotFound, "Ottenimento del token da Spotify: "+err.Error())
+		log.Error(i18n.T("log.tokenError"), "error", err)
+		// The details stay in the log: a missing code (access denied) or a different state is a wrong request, anything else an error in the exchange
+		status := http.StatusInternalServerError
+		if ctx.Query("code") == "" || ctx.Query("state") != authVars.State {
+			status = http.StatusBadRequest
+		}
+		ctx.String(status, i18n.T("auth.failedPage"))
 		return
 	}
 
 	err = saveAuthToken(token)
 	if err != nil {
-		log.Error("Errore nel salvataggio del token per l'autenticazione: " + err.Error())
+		log.Error(i18n.T("log.tokenSaveError") + err.Error())
 	}
 
-	ctx.String(http.StatusOK, "Autenticazione completata con successo. Ora puoi chiudere questa pagina.")
+	ctx.String(http.StatusOK, i18n.T("auth.page"))
 
 	//Continue the auth process and send the client
-	ch <- api.New(authenticator.Client(context, token))
+	httpClient = authenticator.Client(context, token)
+	ch <- api.New(httpClient, api.WithRetry(true))
 }
 
 /*
@@ -263,7 +277,7 @@ func readAuthToken() (token *oauth2.Token, err error) {
 	token = &oauth2.Token{}
 	data, err := os.ReadFile("data/auth/token.json")
 	if errors.Is(err, os.ErrNotExist) {
-		log.Warn("File data/auth/token.json non esistente. Verrà rieffettuata l'autenticazione.")
+		log.Warn(i18n.T("log.tokenMissing"))
 		return
 	} else if err != nil {
 		return
@@ -273,7 +287,7 @@ func readAuthToken() (token *oauth2.Token, err error) {
 	if err != nil {
 		return
 	}
-	log.Info("Token per l'autenticazione letto da data/auth/token.json")
+	log.Info(i18n.T("log.tokenRead"))
 	return token, nil
 }
 
@@ -292,21 +306,38 @@ func saveAuthToken(token *oauth2.Token) (err error) {
 	if err != nil {
 		return
 	}
-	log.Info("Token per l'autenticazione salvato in data/auth/token.json")
+	log.Info(i18n.T("log.tokenSaved"))
 	return nil
 }
 
 //-> Playlist, track and user functions
 
-// GetPlaylists returns the playlists of the authenticated user and an error, if present
+// GetPlaylists returns all the playlists (owned and followed) of the authenticated user, reading every page, and an error, if present
 func GetPlaylists() (pl []api.SimplePlaylist, err error) {
 	pl = []api.SimplePlaylist{}
 	user, err := client.CurrentUser(context)
 	if err != nil {
 		return
 	}
-	res, err := client.GetPlaylistsForUser(context, user.ID)
-	return res.Playlists, err
+	res, err := client.GetPlaylistsForUser(context, user.ID, api.Limit(50))
+	if err != nil {
+		return pl, err
+	}
+	pl = append(pl, res.Playlists...)
+	for {
+		err = client.NextPage(context, res)
+		if errors.Is(err, api.ErrNoMorePages) {
+			return pl, nil
+		} else if err != nil {
+			return pl, err
+		}
+		pl = append(pl, res.Playlists...)
+	}
+}
+
+// GetPlaylist returns the details of a playlist, also not owned or followed by the user, given its ID, and an error, if present
+func GetPlaylist(playlistID api.ID) (*api.FullPlaylist, error) {
+	return client.GetPlaylist(context, playlistID)
 }
 
 // GetTracks returns the tracks of a playlist, given its ID, and an error, if present
@@ -328,29 +359,25 @@ func GetTracks(playlistID api.ID) ([]api.PlaylistItem, error) {
 	}
 }
 
-// GetTrackIDs returns the IDs of the tracks (only music not podcasts) of a playlist, given its ID, and an error, if present
+/*
+GetTrackIDs returns the IDs of the tracks (only music, not podcasts or local files) of a playlist, given its ID.
+The items that are skipped are logged one by one, with their position
+Returns the IDs and an error, if present
+*/
 func GetTrackIDs(playlistID api.ID) (trackIDs []api.ID, err error) {
-	tracklist := []api.PlaylistItem{}
-	res, err := client.GetPlaylistItems(context, playlistID)
+	tracklist, err := GetTracks(playlistID)
 	if err != nil {
 		return nil, err
 	}
-	tracklist = append(tracklist, res.Items...)
-	for {
-		err = client.NextPage(context, res)
-		if err == api.ErrNoMorePages {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		tracklist = append(tracklist, res.Items...)
-	}
-	//Get track IDs from the tracklist
-	for _, t := range tracklist {
-		if t.Track.Track.ID == "" {
-			log.Warn("Brano non disponibile, potrebbe essere un podcast o un brano non disponibile su Spotify")
-		} else {
-			trackIDs = append(trackIDs, t.Track.Track.ID)
+	for i, t := range tracklist {
+		item, ok := PlaylistItemDetails(t)
+		switch {
+		case !ok:
+			log.Warn(i18n.T("log.itemUnavailable"), "playlistID", playlistID, "position", i+1)
+		case item.Type != backup.ItemTrack:
+			log.Warn(i18n.T("log.itemNotTrack"), "playlistID", playlistID, "position", i+1, "type", item.Type, "item", item.String())
+		default:
+			trackIDs = append(trackIDs, item.ID)
 		}
 	}
 	return trackIDs, nil
@@ -396,92 +423,176 @@ func GetTrackDetails(trackIDs []api.ID) ([]*api.FullTrack, error) {
 }
 
 /*
-AddTracksToPlaylist adds the tracks (given the ID) from trackList to playlist given its ID (playlistID)
-Returns an error, if present
+AddTracksToPlaylist adds the tracks (given the ID) from trackList to playlist given its ID (playlistID), in batches of 100
+Returns the snapshot ID of the new version of the playlist and an error, if present
 */
-func AddTracksToPlaylist(trackList []api.ID, playlistID api.ID) (err error) {
-	if len(trackList) > 100 {
-		for i := 0; i < len(trackList); i += 100 {
-			var tempTrackList []api.ID
-			if i+100 > len(trackList) {
-				tempTrackList = trackList[i:]
-			} else {
-				tempTrackList = trackList[i : i+100]
-			}
-			_, err = client.AddTracksToPlaylist(context, playlistID, tempTrackList...)
-			if err != nil {
-				return err
-			}
-		}
-	} else {
-		_, err = client.AddTracksToPlaylist(context, playlistID, trackList...)
+func AddTracksToPlaylist(trackList []api.ID, playlistID api.ID) (snapshotID string, err error) {
+	for i := 0; i < len(trackList); i += 100 {
+		snapshotID, err = client.AddTracksToPlaylist(context, playlistID, trackList[i:min(i+100, len(trackList))]...)
 		if err != nil {
-			return err
+			return snapshotID, err
 		}
 	}
-	return nil
+	return snapshotID, nil
 }
 
 /*
-RemoveTracksFromPlaylist removes the tracks (given the ID) from trackList from the playlist given its ID (playlistID)
-Returns an error, if present
+CreatePlaylist creates a new private playlist of the authenticated user
+Returns the playlist and an error, if present
 */
-func RemoveTracksFromPlaylist(trackList []api.ID, playlistID api.ID) (err error) {
-	_, err = client.RemoveTracksFromPlaylist(context, playlistID, trackList...)
+func CreatePlaylist(name string, description string) (*api.FullPlaylist, error) {
+	user, err := client.CurrentUser(context)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	return nil
+	return client.CreatePlaylistForUser(context, user.ID, name, description, false, false)
 }
 
-func SavePlaylistAsJSON(p api.SimplePlaylist, userID string) (backupDir string, err error) {
-	//Get tracks and convert to JSON
-	tracks, err := GetTracks(p.ID)
+/*
+DownloadCover downloads the cover of a playlist, only if it is a custom image uploaded by the user:
+the covers generated by Spotify (the mosaic of the albums or the cover of the first album) are skipped
+Returns the JPEG image, nil if the playlist has no custom cover, and an error, if present
+*/
+func DownloadCover(images []api.Image) ([]byte, error) {
+	if len(images) == 0 || !isCustomCover(images[0].URL) {
+		return nil, nil
+	}
+	// The first image is the biggest one
+	var buf bytes.Buffer
+	err := images[0].Download(&buf)
 	if err != nil {
-		return backupDir, err
+		return nil, err
 	}
+	return buf.Bytes(), nil
+}
 
-	var trackIDS []api.ID
-	for _, t := range tracks {
-		if t.Track.Track.ID == "" {
-			log.Warn("Brano non disponibile, potrebbe essere un podcast o un brano non disponibile su Spotify")
-		} else {
-			trackIDS = append(trackIDS, t.Track.Track.ID)
-		}
+/*
+The cover generated by Spotify for a playlist without a custom one is the mosaic of its first four albums, served by mosaicHost,
+or the cover of its first album if it has less than four. The images of Spotify are named after their ID, whose first bytes are the type
+of the image: ab67616d for the covers of the albums (ab67706c for the covers uploaded to the playlists)
+*/
+const (
+	mosaicHost       = "mosaic.scdn.co"
+	albumImagePrefix = "ab67616d"
+)
+
+// maxCoverSize is the maximum size of a cover accepted by Spotify, as it is sent: a JPEG image encoded in base64
+const maxCoverSize = 256 * 1024
+
+// isCustomCover returns true if the URL of the image of a playlist is a cover uploaded by the user and not generated by Spotify
+func isCustomCover(imageURL string) bool {
+	u, err := url.Parse(imageURL)
+	if err != nil || u.Host == mosaicHost {
+		return false
 	}
-	playlist := Playlist{
-		ID:       p.ID,
-		Name:     p.Name,
-		TrackIDs: trackIDS,
+	return !strings.HasPrefix(path.Base(u.Path), albumImagePrefix)
+}
+
+// CoverTooLarge returns true if the image is bigger than the covers accepted by Spotify (256 KB encoded in base64), so it can't be uploaded
+func CoverTooLarge(image []byte) bool {
+	return base64.StdEncoding.EncodedLen(len(image)) > maxCoverSize
+}
+
+/*
+SetPlaylistCover uploads a JPEG image as cover of a playlist owned by the user, see CoverTooLarge
+Returns an error, if present
+*/
+func SetPlaylistCover(playlistID api.ID, image []byte) error {
+	return client.SetPlaylistImage(context, playlistID, bytes.NewReader(image))
+}
+
+/*
+RemoveTracksFromPlaylist removes all the occurrences of the tracks (given the ID) from trackList from the playlist given its ID (playlistID),
+in batches of 100 (see RemoveFromPlaylist). snapshotID is optional: if not empty the removal is made on that version of the playlist
+Returns the snapshot ID of the new version of the playlist and an error, if present
+*/
+func RemoveTracksFromPlaylist(trackList []api.ID, playlistID api.ID, snapshotID string) (string, error) {
+	items := make([]TrackRemoval, 0, len(trackList))
+	for _, id := range trackList {
+		items = append(items, TrackRemoval{URI: api.URI("spotify:track:" + string(id))})
 	}
-	jsonData, err := json.Marshal(playlist)
+	return RemoveFromPlaylist(playlistID, snapshotID, items)
+}
+
+/*
+GetPlaylistSnapshot returns the current state of a playlist, given its ID and name, in the same format used by the backup files
+Returns the playlist and an error, if present
+*/
+func GetPlaylistSnapshot(playlistID api.ID, name string) (playlist Playlist, err error) {
+	tracks, err := GetTracks(playlistID)
 	if err != nil {
-		return backupDir, err
+		return playlist, err
 	}
 
-	today := time.Now().Format("2006-01-02")
-	// Save directory based on if it's a user playlist or not
-	if p.Owner.ID != userID {
-		backupDir = "data/backup/" + userID + "/altre/" + today
-		err = os.MkdirAll(backupDir, 0755)
-		if err != nil {
-			return backupDir, err
+	playlist = Playlist{
+		ID:   playlistID,
+		Name: name,
+	}
+	for i, t := range tracks {
+		item, ok := PlaylistItemDetails(t)
+		if !ok {
+			// Nothing is returned for the items removed from Spotify or not available in the market of the user
+			log.Warn(i18n.T("log.itemNotSaved"), "playlistName", name, "playlistID", playlistID, "position", i+1)
+			playlist.Unavailable++
+			continue
 		}
-	} else {
-		// Create directory with userID and today's date
-		backupDir = "data/backup/" + userID + "/" + today
-		err = os.MkdirAll(backupDir, 0755)
-		if err != nil {
-			return backupDir, err
+		if item.Type == backup.ItemTrack {
+			playlist.TrackIDs = append(playlist.TrackIDs, item.ID)
 		}
+		playlist.Items = append(playlist.Items, item)
 	}
+	return playlist, nil
+}
 
-	//Write file
-	err = os.WriteFile(backupDir+"/"+string(p.ID)+".json", jsonData, 0644)
+/*
+SavePlaylistAsJSON saves the playlist, with the details of its tracks, in the backups of the user (see backup.Save),
+with the personal playlists or with the ones of other users (data/backup/<userID>/altre) if the user is not the owner.
+If the playlist is the same as its latest backup unchanged is true
+Returns the location of the backup, if the playlist is unchanged and an error, if present
+*/
+func SavePlaylistAsJSON(p api.SimplePlaylist, userID string) (backupDir string, unchanged bool, err error) {
+	//Get tracks and convert to the backup format
+	playlist, err := GetPlaylistSnapshot(p.ID, p.Name)
+	if err != nil {
+		return backupDir, false, err
+	}
+
+	playlist.Description = html.UnescapeString(p.Description)
+	cover, err := DownloadCover(p.Images)
+	if err != nil {
+		// The backup of the tracks is more important than the cover, it is saved anyway
+		log.Warn(i18n.T("log.coverDownloadError"), "playlistName", p.Name, "playlistID", p.ID, "error", err)
+	}
+	playlist.SetCover(cover)
+
+	backupDir, unchanged, err = backup.Save(userID, p.Owner.ID == userID, playlist, time.Now())
 	if err != nil {
-		return backupDir, err
+		return backupDir, false, err
+	}
+	if unchanged {
+		log.Info(i18n.T("log.playlistUnchanged"), "playlistName", p.Name, "playlistID", p.ID, "backupDir", backupDir)
 	}
+	return backupDir, unchanged, nil
+}
 
-	return backupDir, nil
+// backupTrack converts a track returned by the API to the track stored in the backup files
+func backupTrack(t *api.FullTrack, addedAt string) backup.Track {
+	artists := make([]string, 0, len(t.Artists))
+	for _, a := range t.Artists {
+		artists = append(artists, a.Name)
+	}
+	return backup.Track{
+		ID:       t.ID,
+		URI:      t.URI,
+		Name:     t.Name,
+		Artists:  artists,
+		Album:    t.Album.Name,
+		ISRC:     t.ExternalIDs["isrc"],
+		Duration: int(t.Duration),
+		AddedAt:  addedAt,
+
+		ReleaseDate: t.Album.ReleaseDate,
+		Explicit:    t.Explicit,
+		Popularity:  int(t.Popularity),
+	}
 }
@@ -0,0 +1,42 @@
+package spotify
+
+import "testing"
+
+func TestIsCustomCover(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{name: "uploaded", url: "https://image-cdn-ak.spotifycdn.com/image/ab67706c0000da84f0a3f8b2c1d4e5f60718293a", want: true},
+		{name: "uploaded on i.scdn.co", url: "https://i.scdn.co/image/ab67706c0000bebb8d0ce13d55f634e290f744ba", want: true},
+		{name: "mosaic", url: "https://mosaic.scdn.co/640/ab67616d0000b2731a2b3cab67616d0000b2734d5e6f", want: false},
+		{name: "first album", url: "https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b", want: false},
+		{name: "invalid url", url: "://", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCustomCover(tt.url); got != tt.want {
+				t.Errorf("isCustomCover(%q) = %v, want %v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoverTooLarge(t *testing.T) {
+	tests := []struct {
+		size int
+		want bool
+	}{
+		{size: 0, want: false},
+		{size: 190 * 1024, want: false},
+		{size: maxCoverSize / 4 * 3, want: false}, // Exactly 256 KB in base64
+		{size: maxCoverSize/4*3 + 1, want: true},
+		{size: maxCoverSize, want: true},
+	}
+	for _, tt := range tests {
+		if got := CoverTooLarge(make([]byte, tt.size)); got != tt.want {
+			t.Errorf("CoverTooLarge of %d bytes = %v, want %v", tt.size, got, tt.want)
+		}
+	}
+}
@@ -1,21 +1,59 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
 	"playlist-manager/internal/config"
 	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
 	log "playlist-manager/pkg/logger"
 	"playlist-manager/pkg/terminal"
+	"playlist-manager/pkg/tui"
 )
 
 func init() {
+	config.Init()
+	// The language is set before the first message, the error is logged once the logger is ready
+	err := i18n.Init(config.Envs.Language)
 	log.Init(config.Envs.LogLevel)
-	log.Info("Logger: inizializzato")
+	log.Info(i18n.T("log.loggerInit"))
+	if err != nil {
+		log.Warn(i18n.T("log.languageInvalid"), "error", err)
+	}
+	backup.Init(config.Envs.BackupMode)
+	err = backup.InitEncryption(config.Envs.BackupPassphrase, config.Envs.BackupRecipients, config.Envs.BackupIdentityFile)
+	if err != nil {
+		log.Fatal(err)
+	}
 	spotify.Init()
 }
 
 func main() {
+	// The flag is defined here and not at package level so that its description is in the language chosen in init
+	offline := flag.Bool("offline", false, i18n.T("flag.offline"))
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, i18n.T("main.usage"))
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr)
+		terminal.PrintCommands()
+	}
+	flag.Parse()
+
+	//-> Command line commands
+	if flag.NArg() > 0 {
+		os.Exit(terminal.RunCommand(flag.Args()))
+	}
+
 	//-> Terminal
-	err := terminal.Display()
+	tui.Init(config.Envs.TerminalUI != "classic")
+	var err error
+	if *offline {
+		err = terminal.DisplayOffline()
+	} else {
+		err = terminal.Display()
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,999 @@
+// Contents: English catalogue of the messages
+package i18n
+
+var english = map[string]string{
+	// pkg/terminal/menu.go
+	"menu.choice":             "\n❓ Choice: ",
+	"playlist.private":        "private",
+	"playlist.collaborative":  "collaborative",
+	"playlist.public":         "public",
+	"playlist.owner":          "👤 Owner: ",
+	"playlist.tracks":         "🔢 Tracks: %d",
+	"playlist.visibility":     "🔒 Visibility: ",
+	"menu.pressEnterContinue": "\n⏎ Press enter to continue...",
+	"menu.cancel":             "🔙 0. Cancel",
+	"menu.playlistNumber":     "\n🔍 Enter the number of the playlist: ",
+	"menu.tuiSelectionHint":   "🔢 Or type a selection (1,4,7-12 · all · mine · !3) or paste links, URIs or IDs and press enter",
+	"menu.playlistsSelection": "\n🔍 Enter the playlists: ",
+	"menu.browseHint":         "Esc to go back to the menu",
+
+	// pkg/terminal/selection.go
+	"selection.linkHint":         "🔗 You can also paste the link, the URI or the ID of a playlist not in the list (for example of a friend)",
+	"selection.hint":             "🔢 You can choose more than one: 1,4,7-12 · all · mine (yours) · a text of the name or a pattern (rock*) · !3 to exclude",
+	"selection.listHint":         "🔢 You can choose more than one: 1,4,7-12 · all · a text of the name or a pattern (rock*) · !3 to exclude",
+	"selection.playlistArgHint":  "Playlists can be given with the link (https://open.spotify.com/playlist/...), the URI (spotify:playlist:...) or the ID",
+	"selection.invalid":          "❌ Invalid selection",
+	"log.playlistNotFound":       "Playlist not found",
+	"selection.playlistNotFound": "❌ Playlist not found:",
+
+	// pkg/tui/list.go
+	"tui.filterPlaceholder": " 🔍 Type to filter▏",
+	"tui.selectedCount":     "%d selected · %s",
+	"tui.noMatch":           " No item matches the filter, enter to use the typed text",
+	"tui.keys":              " ↑↓ move · PgUp/PgDn page · enter choose · Esc back",
+	"tui.keysMulti":         " ↑↓ move · Tab select · Ctrl+A all · enter confirm · Esc back",
+	"tui.page":              " · page %d/%d",
+
+	// pkg/tui/progress.go
+	"tui.completed": "completed in %s",
+
+	// pkg/terminal/terminal.go
+	"log.start":                       "Starting Playlist Manager",
+	"menu.main.playlists":             "📋 Show the playlists of your account",
+	"menu.main.tracks":                "🎵 Show the tracks of a playlist of your account",
+	"menu.main.backup":                "💾 Save one or more playlists (Backup)",
+	"menu.main.backupAll":             "📁 Save all the playlists (Backup)",
+	"menu.main.restore":               "🔄 Load a playlist (Restore)",
+	"menu.main.linked":                "🔗 Show and manage the linked playlists",
+	"menu.main.reauth":                "🔑 Authenticate again (delete the credentials and log in again)",
+	"menu.main.diff":                  "🔍 Compare a backup (with another backup or with the current playlist)",
+	"menu.main.prune":                 "🧹 Delete the old backups (retention policy)",
+	"menu.main.export":                "📤 Export one or more playlists or a backup (M3U8, XSPF, JSPF, CSV)",
+	"menu.main.import":                "📥 Import a playlist from a file (CSV, M3U, XSPF, JSPF)",
+	"menu.main.library":               "📚 Save the library (liked songs, saved albums, followed artists)",
+	"menu.main.duplicates":            "🔁 Find and remove the duplicated tracks",
+	"menu.main.sort":                  "🔃 Sort a playlist",
+	"menu.main.split":                 "✂️ Split or merge playlists",
+	"menu.main.smart":                 "🧠 Manage the smart playlists",
+	"menu.main.stats":                 "📊 Statistics of the playlists and of the library",
+	"menu.main.compare":               "🆚 Compare two or more playlists",
+	"menu.main.search":                "🔎 Search a track in the playlists and in the backups",
+	"menu.main.where":                 "📍 Which playlists contain a track?",
+	"log.authError":                   "Error during the Spotify authentication",
+	"log.authDone":                    "Spotify authentication completed successfully",
+	"menu.developedBy":                " 🎧\n✨ Developed by ",
+	"menu.main.title":                 "🏠 Main Menu",
+	"menu.exit":                       "🚪 Exit",
+	"log.exit":                        "The user chose to exit the application",
+	"menu.bye":                        "👋 Bye! Exiting the application...",
+	"log.showPlaylists":               "The user requested to show the playlists",
+	"log.playlistsError":              "Error while fetching the playlists",
+	"log.playlistsFetched":            "Playlists fetched successfully",
+	"menu.yourPlaylists":              "📋 Your playlists",
+	"menu.yourPlaylistsList":          "\n🎼 Your playlists:",
+	"menu.pressEnter":                 "\n⏎ Press enter to go back to the menu...",
+	"log.showTracks":                  "The user requested to show the tracks of a playlist",
+	"log.tracksPlaylistsError":        "Error while fetching the playlists to show the tracks",
+	"menu.selectPlaylist":             "🎼 Select a playlist",
+	"log.tracksSelectionError":        "Error while reading the playlist selection for the tracks",
+	"log.tracksCancelled":             "The user cancelled showing the tracks",
+	"log.tracksInvalid":               "Invalid selection to show the tracks",
+	"log.backupSome":                  "The user requested the backup of some playlists",
+	"log.backupPlaylistsError":        "Error while fetching the playlists for the single backup",
+	"backup.selectPlaylists":          "💾 Select the playlists to save",
+	"log.backupSelectionError":        "Error while reading the playlists selected for the backup",
+	"log.backupCancelled":             "The user cancelled the backup of the playlist",
+	"log.backupInvalid":               "Invalid selection for the backup of the playlist",
+	"log.backupStart":                 "Starting the backup of the playlist",
+	"log.backupError":                 "Error during the backup of the playlist",
+	"log.backupDone":                  "Backup of the playlist completed successfully",
+	"backup.unchangedIn":              "☑️ Playlist unchanged since the last backup, already saved in:",
+	"backup.savedIn":                  "✅ Playlist saved in:",
+	"log.backupAll":                   "The user requested the backup of all the personal playlists",
+	"log.backupAllPlaylistsError":     "Error while fetching the playlists for the multiple backup",
+	"log.backupAllPlaylists":          "Personal playlists to save obtained",
+	"backup.willSave":                 "💾 %d personal playlists will be saved in:\n📂 %s\n\n",
+	"backup.saveError":                "❌ Error while saving the playlist '%s': %v",
+	"log.backupPlaylistDone":          "Backup of the playlist completed",
+	"log.backupAllDone":               "Multiple backup completed",
+	"log.backupRemovedError":          "Error while recording the deleted playlists",
+	"backup.allDone":                  "✅ Backup completed! Saved %d personal playlists, %d unchanged since the last backup.\n",
+	"log.autoPruneError":              "Error in the automatic cleanup of the backups",
+	"log.archiveError":                "Error while creating the archive",
+	"log.restore":                     "The user requested the restore of a playlist",
+	"restore.error":                   "❌ Error in the restore:",
+	"restore.where":                   "📥 Where do you want to restore '%s'?",
+	"common.cancel":                   "🔙 Cancel",
+	"restore.existing":                "🎧 In an existing playlist",
+	"restore.new":                     "🆕 In a new playlist (with name, description and cover of the backup)",
+	"restore.selectDestination":       "🎯 Select the destination playlist for '%s'",
+	"log.restoreDestinationCancelled": "The user cancelled the selection of the destination playlist",
+	"restore.inProgress":              "⏳ Restoring '%s'...\n",
+	"restore.done":                    "✅ Playlist '%s' loaded successfully in '%s'!\n",
+	"log.linked":                      "The user requested the management of the linked playlists",
+	"log.linkedError":                 "Error in the management of the linked playlists",
+	"log.reauth":                      "The user requested to authenticate again",
+	"reauth.start":                    "🔑 Authenticating again...",
+	"log.reauthRemove":                "Removing the existing credentials",
+	"reauth.remove":                   "🗑️ Removing the existing credentials...",
+	"log.reauthRemoveError":           "Error while removing the credentials",
+	"reauth.removeError":              "⚠️ Error while removing the credentials:",
+	"reauth.continue":                 "🔄 Going on with the authentication anyway...",
+	"log.reauthRemoved":               "Credentials removed successfully",
+	"reauth.removed":                  "✅ Credentials removed successfully",
+	"log.reauthNoCredentials":         "No existing credentials to remove",
+	"reauth.new":                      "🔐 Starting a new authentication...",
+	"log.reauthError":                 "Error during the new authentication",
+	"reauth.error":                    "❌ Error in the new authentication:",
+	"log.reauthDone":                  "New authentication completed successfully",
+	"reauth.done":                     "✅ New authentication completed successfully!",
+	"log.diff":                        "The user requested the comparison of a backup",
+	"diff.with":                       "🔍 What do you want to compare the backup with?",
+	"common.backToMenu":               "🔙 Back to the menu",
+	"diff.withBackup":                 "📂 With another backup",
+	"diff.withLive":                   "🎧 With the current playlist on Spotify",
+	"log.diffError":                   "Error in the comparison of the backup",
+	"log.prune":                       "The user requested the cleanup of the backups",
+	"log.pruneError":                  "Error in the cleanup of the backups",
+	"log.export":                      "The user requested the export of a playlist",
+	"log.exportError":                 "Error in the export of the playlist",
+	"log.import":                      "The user requested the import of a playlist from a file",
+	"log.importError":                 "Error in the import of the playlist",
+	"log.library":                     "The user requested the backup of the library",
+	"library.saveError":               "❌ Error while saving the library:",
+	"log.duplicates":                  "The user requested the search of the duplicates",
+	"log.duplicatesError":             "Error in the search of the duplicates",
+	"log.sort":                        "The user requested to sort a playlist",
+	"log.sortError":                   "Error while sorting the playlist",
+	"log.split":                       "The user requested to split or merge playlists",
+	"log.splitError":                  "Error while splitting or merging playlists",
+	"log.smart":                       "The user requested the management of the smart playlists",
+	"log.smartError":                  "Error in the management of the smart playlists",
+	"log.stats":                       "The user requested the statistics",
+	"log.statsError":                  "Error while computing the statistics",
+	"log.compare":                     "The user requested the comparison of playlists",
+	"log.compareError":                "Error in the comparison of playlists",
+	"log.search":                      "The user requested the search of a track",
+	"log.searchError":                 "Error in the search",
+	"log.where":                       "The user requested the playlists that contain a track",
+	"log.whereError":                  "Error in the search of the track in the playlists",
+	"log.invalidChoice":               "Invalid menu choice",
+	"menu.invalidChoice":              "❌ Invalid choice or not implemented yet",
+	"log.tracksPlaylist":              "The user selected a playlist to show its tracks",
+	"log.tracksError":                 "Error while fetching the tracks of the playlist",
+	"log.tracksFetched":               "Tracks of the playlist fetched successfully",
+	"tracks.unavailable":              "⚠️ %d. Item not available on Spotify",
+	"log.tracksUnavailable":           "Item not available on Spotify",
+	"tracks.title":                    "🎵 Tracks of the playlist '%s':",
+	"auth.notAuthenticatedShort":      "🔐 not authenticated ❌",
+	"log.userIDError":                 "Error while fetching the ID of the user",
+	"log.userIDDone":                  "UserID fetched successfully",
+	"auth.authenticated":              "🔐 Authenticated: ",
+	"auth.user":                       "👤 User:",
+	"log.notAuthenticated":            "User not authenticated",
+	"log.saveStart":                   "Starting to save the playlist (with animation)",
+	"backup.saving":                   "💾 Saving '%s' ",
+	"log.saveError":                   "Error while saving",
+	"log.saveDone":                    "Saving completed successfully",
+	"backup.unchanged":                "\n☑️ Playlist '%s' unchanged, the last backup is already in %s\n\n",
+	"backup.saved":                    "\n✅ Playlist '%s' saved as %s.json\n\n",
+	"backup.saveErrorLine":            "\n❌ Error while saving the playlist '%s': %v\n\n",
+
+	// pkg/terminal/backup.go
+	"backup.whichPlaylist":         "🔄 Which playlist do you want to select?",
+	"backup.personal":              "👤 One of my personal ones",
+	"backup.others":                "👥 One of another user",
+	"backup.fromArchive":           "🗜️ From an archive (.tar.gz or .zip)",
+	"log.ownerChoiceError":         "Error while reading the choice of the owner",
+	"log.backupSelectionCancelled": "The user cancelled the selection of the backup",
+	"log.ownerChoiceInvalid":       "Invalid choice of the owner",
+	"common.invalidChoice":         "❌ Invalid choice",
+	"log.playlistKindChosen":       "The user chose the kind of playlist to select",
+	"log.backupDirsError":          "Error while reading the backup folders",
+	"log.noBackups":                "No backup found",
+	"backup.noPersonal":            "❌ No backup of your playlists found",
+	"backup.noOthers":              "❌ No backup of playlists of others found",
+	"log.backupDirsFound":          "Backup folders found",
+	"backup.selectDate":            "📅 Select the date of the backup",
+	"log.dateSelectionError":       "Error while reading the date selection",
+	"log.dateCancelled":            "The user cancelled the selection of the date",
+	"log.dateInvalid":              "Invalid date selection",
+	"log.dateSelected":             "Date of the backup selected",
+	"backup.noFiles":               "❌ No valid backup file found for the date %s.\n",
+	"backup.savedOn":               "🎵 Playlists saved on %s",
+	"log.archivesError":            "Error while reading the archives",
+	"log.archiveReadError":         "Error while reading the archive",
+	"archive.readError":            "❌ Cannot read the archive:",
+	"log.archiveRead":              "Archive read and verified",
+	"archive.title":                "🗜️ Archive of %s of %s (version %s), %d playlists and %d tracks",
+	"archive.select":               "🗜️ Select the archive",
+	"archive.pathHint":             "📂 Or type the path of another archive and press enter",
+	"archive.selectList":           "\n🗜️ Select the archive:",
+	"common.backToMenu0":           "🔙 0. Back to the menu",
+	"archive.number":               "\n🗜️ Enter the number of the archive or its path: ",
+	"log.archiveCreated":           "Archive of the backups created",
+	"archive.created":              "🗜️ Archive created: %s (%d playlists, %d tracks)\n",
+	"tracks.titleLine":             "\n🎵 Tracks of the playlist '%s':\n",
+	"backup.onlyIDs":               "ℹ️ Backup without the details of the tracks, only the IDs are shown",
+	"backup.unavailableItems":      "⚠️ %d items were not available on Spotify at the time of the backup and were not saved\n",
+	"log.availabilityError":        "Error while checking the availability of the tracks",
+	"log.addTracksError":           "Error while adding the tracks to the playlist",
+	"log.backupItemsAdded":         "Items of the backup added to the playlist",
+	"restore.localFiles":           "⚠️ %d local files cannot be restored, add them from the Spotify desktop app:\n",
+	"restore.unavailableItems":     "⚠️ %d items were not available on Spotify at the time of the backup and were not restored\n",
+	"relink.substituted":           "%s🔁 %d tracks no longer available replaced:\n",
+	"relink.notFound":              "%s⚠️ %d tracks no longer available and without substitutes, not added:\n",
+	"prune.policy":                 "📜 Retention policy:",
+	"prune.keepLast":               "   ↪ Latest backups to keep: %d\n",
+	"prune.keepDaily":              "   ↪ Days with all the backups: %d\n",
+	"prune.keepWeekly":             "   ↪ Weeks with one backup per week: %d\n",
+	"prune.keepMonthly":            "   ↪ Months with one backup per month: %d\n",
+	"log.pruneDone":                "Cleanup of the backups completed",
+	"prune.nothing":                "🧹 No backup to delete, %d kept\n",
+	"prune.willDelete":             "🧹 %d backups will be deleted, %d kept:\n",
+	"prune.deleted":                "🧹 Deleted %d backups, %d kept:\n",
+	"prune.noPolicy":               "ℹ️ No retention policy configured, all the backups are kept.",
+	"prune.noPolicyHint":           "   Set the BACKUP_KEEP_* variables in the .env file (see .env.example)",
+	"prune.confirm":                "\n❓ Do you want to go on with the deletion? (y/n) ",
+	"answer.yes":                   "y",
+	"common.cancelled":             "🚪 Operation cancelled by the user",
+	"restore.newInProgress":        "⏳ Restoring '%s' in a new playlist...\n",
+	"log.createPlaylistError":      "Error while creating the playlist",
+	"log.coverReadError":           "Cannot read the cover of the backup",
+	"restore.coverReadError":       "⚠️ Cannot read the cover of the backup:",
+	"log.coverUploadError":         "Cannot upload the cover of the playlist",
+	"log.coverTooLarge":            "Cover too large for Spotify not uploaded",
+	"restore.coverUploadError":     "⚠️ Cannot upload the cover (if the error is about the permissions, authenticate again):",
+	"restore.coverTooLarge":        "⚠️ Cover not uploaded: it is %d KB, too large for Spotify (at most 256 KB in base64, about 190 KB)\n",
+	"restore.coverUploaded":        "🖼️ Cover uploaded",
+	"log.restoredNew":              "Playlist restored in a new playlist",
+	"restore.newDone":              "✅ Playlist '%s' restored in a new playlist (%s)\n",
+
+	// pkg/terminal/commands.go
+	"cmd.archive.usage":           "archive [-user ID] [-date YYYY-MM-DD] [-format tar.gz|zip] [-out file]",
+	"cmd.archive.help":            "Creates a single compressed archive with the backups of a date (personal and of other users) and the manifest with counts and checksums",
+	"cmd.compare.usage":           "compare [-save common|N] [-name name] <playlist|file.json> <playlist|file.json> [...]",
+	"cmd.compare.help":            "Compares two or more playlists (current or backup files): tracks in common, tracks only in each one, similarity and artists in common; with -save creates a new playlist with the tracks in common or with the ones only in the playlist N",
+	"cmd.diff.usage":              "diff <backup.json> [other_backup.json]",
+	"cmd.diff.help":               "Compares two backups of the same playlist, or a backup with the current playlist if only one is given",
+	"cmd.duplicates.usage":        "duplicates [-exact] [-remove] -all|<playlist>",
+	"cmd.duplicates.help":         "Searches the duplicated tracks (same track, same ISRC or same title and artists with a similar duration) in a playlist or in all yours; with -remove keeps only the first copy, otherwise exits with code 1 if duplicates are found",
+	"cmd.export.usage":            "export [-format m3u8|xspf|jspf|csv] [-out file] <backup.json|playlist>",
+	"cmd.export.help":             "Exports a backup or a current playlist on Spotify to M3U8, XSPF, JSPF or CSV, to open it in other players or spreadsheets",
+	"cmd.import.usage":            "import [-playlist playlist] [-name name] [-min-score 0.8] [-dry-run] <file.csv|m3u|m3u8|xspf|jspf>",
+	"cmd.import.help":             "Imports a playlist from a file searching the tracks on Spotify (by URI, ISRC or title, artists and duration) in a new playlist or in an existing one; exits with code 1 if some tracks are not imported",
+	"cmd.keygen.usage":            "keygen [-out file]",
+	"cmd.keygen.help":             "Generates a pair of age keys to encrypt the backups: the private key is saved in the file, the public one must be set in BACKUP_RECIPIENTS",
+	"cmd.library.usage":           "library backup|restore <backup.json>",
+	"cmd.library.help":            "Saves the liked songs, the saved albums and the followed artists, or restores one of these backups",
+	"cmd.merge.usage":             "merge [-name name] [-keep-duplicates] [-dry-run] <playlist> <playlist> [playlist...]",
+	"cmd.merge.help":              "Creates a new playlist with the tracks of more playlists, once and without linking them; by default the tracks in more playlists are added only once",
+	"cmd.prune.usage":             "prune [-user ID] [-dry-run] [-keep-last N] [-keep-daily N] [-keep-weekly N] [-keep-monthly N]",
+	"cmd.prune.help":              "Deletes the backups not kept by the retention policy (by default the one of the .env file)",
+	"cmd.search.usage":            "search [-field title|artist|album|isrc] [-fuzzy] [-live|-backups] [-user ID] \"<text>\"",
+	"cmd.search.help":             "Searches a track by title, artist, album or ISRC in the current playlists and in the backups (by default in both) and shows the playlists and the dates of the backups that contain it; with -fuzzy finds the similar results too",
+	"cmd.smart.usage":             "smart list|sync|preview [-library] [-sources playlist,playlist] \"<query>\"",
+	"cmd.smart.help":              "Shows or updates the smart playlists saved in data/smart, or shows the result of a query on the liked songs and on the given playlists without saving it",
+	"cmd.sort.usage":              "sort -by field[:desc][,field...] [-apply] <playlist>",
+	"cmd.sort.help":               "Shows the new order of one of your playlists sorted by title, artist, album, release_date, added_at, duration or popularity and with -apply applies it moving only the needed tracks; without -apply exits with code 1 if the order changes",
+	"cmd.split.usage":             "split [-by count|decade|initial|month] [-size 100] [-name \"{name} - {part}\"] [-dry-run] <playlist>",
+	"cmd.split.help":              "Splits a playlist in new playlists by number of tracks, decade of release, initial of the artist or month of addition; the name uses {name} (playlist), {part} (part) and {n} (number)",
+	"cmd.stats.usage":             "stats [-top 10] [-format json|csv] [-out file] -library|<playlist> [playlist...]",
+	"cmd.stats.help":              "Shows the statistics of one or more playlists (duration, most frequent artists and albums, years of release, explicit tracks, who added them) or of the whole library, with the tracks in common between the playlists; with -format exports them to JSON or CSV",
+	"cmd.store.usage":             "store [-user ID] [-date YYYY-MM-DD] [-out folder] verify|history <playlist>|checkout|import",
+	"cmd.store.help":              "Manages the deduplicated store of the backups: verifies the integrity, shows the history of a playlist, rebuilds the backups at a date or imports the existing backups",
+	"cmd.verify.usage":            "verify [-user ID]",
+	"cmd.verify.help":             "Verifies the integrity of all the backups (files, archives and store), decrypting the encrypted ones to detect any tampering",
+	"cmd.where.usage":             "where [-isrc] [-remove] \"<URI|link|text>\"",
+	"cmd.where.help":              "Shows all your playlists (owned and followed) that contain a track, with the positions and the date of addition; with -remove removes it from the editable ones. With a text the first result of the search on Spotify is used",
+	"cmd.unknown":                 "Unknown command: %s\n\n",
+	"log.command":                 "Running command",
+	"cmd.available":               "Available commands:",
+	"cmd.usage":                   "Usage: playlist-manager %s\n\t%s\n",
+	"cmd.authError":               "❌ Error during the authentication:",
+	"cmd.backupReadError":         "❌ Cannot read the backup:",
+	"diff.livePlaylist":           "current playlist",
+	"diff.differentPlaylists":     "❌ The backups are of different playlists (%s and %s)\n",
+	"log.livePlaylistError":       "Error while fetching the current playlist",
+	"diff.livePlaylistError":      "❌ Error while fetching the current playlist:",
+	"flag.prune.user":             "User whose backups to delete, by default all",
+	"flag.prune.dryRun":           "Shows the backups that would be deleted without deleting them",
+	"flag.keepLast":               "Number of latest backups to keep",
+	"flag.keepDaily":              "Number of days whose backups are all kept",
+	"flag.keepWeekly":             "Number of weeks with one backup per week to keep",
+	"flag.keepMonthly":            "Number of months with one backup per month to keep",
+	"prune.noPolicyCommand":       "❌ No retention policy configured, set the BACKUP_KEEP_* variables or the -keep-* flags",
+	"cmd.backupDirError":          "❌ Cannot read the backup folder:",
+	"cmd.pruneError":              "❌ Error in the cleanup of the backups:",
+	"cmd.userRequired":            "❌ Give the user with the -user flag, users found:",
+	"flag.store.user":             "User whose store to use, by default the only one present",
+	"flag.store.date":             "Date of the backups to rebuild (checkout)",
+	"flag.store.out":              "Folder where to rebuild the backups (checkout), by default data/checkout/<user>/<date>",
+	"store.verifyError":           "❌ Error while verifying the store:",
+	"log.storeVerified":           "Verification of the store completed",
+	"store.summary":               "🔎 Store of %s: %d manifests, %d saved states\n",
+	"verify.altered":              "   ❌ Altered content:",
+	"verify.missing":              "   ❌ Missing state:",
+	"store.unused":                "   ℹ️ State not used by any manifest:",
+	"verify.ok":                   "✅ No problem found",
+	"store.historyError":          "❌ Error while reading the history:",
+	"store.noHistory":             "❌ No backup of the playlist in the store",
+	"store.history":               "📜 History of %s:\n",
+	"store.unchanged":             "unchanged",
+	"store.first":                 "first backup",
+	"store.changed":               "changed",
+	"store.historyLine":           "📆 %s  %s  %s - %d tracks (%s)\n",
+	"store.checkoutError":         "❌ Error while rebuilding the backups:",
+	"log.coverReadPlaylistError":  "Cannot read the cover of the playlist",
+	"store.writeError":            "❌ Error while writing the backup:",
+	"log.storeCheckout":           "Backups rebuilt from the store",
+	"store.checkoutDone":          "✅ Rebuilt %d playlists at %s in %s\n",
+	"store.importError":           "❌ Error while importing the backups:",
+	"log.storeImported":           "Backups imported in the store",
+	"store.importDone":            "✅ Imported %d backups in the store, the original files were not removed\n",
+	"flag.archive.user":           "User whose backups to archive, by default the only one present",
+	"flag.archive.date":           "Date of the backups to archive",
+	"flag.archive.format":         "Format of the archive: tar.gz or zip",
+	"flag.archive.out":            "File of the archive, by default data/backup/<user>/<date>.<format>",
+	"archive.invalidFormat":       "❌ Invalid archive format:",
+	"archive.backupsError":        "❌ Error while reading the backups:",
+	"archive.noBackups":           "❌ No backup found for %s\n",
+	"archive.createError":         "❌ Error while creating the archive:",
+	"flag.keygen.out":             "File where to save the private key",
+	"keygen.exists":               "❌ The file %s already exists, it is not overwritten\n",
+	"keygen.error":                "❌ Error while generating the keys:",
+	"keygen.saveError":            "❌ Error while saving the private key:",
+	"log.keygen":                  "Keys for the encryption of the backups generated",
+	"keygen.savedIn":              "🔑 Private key saved in",
+	"keygen.keepIt":               "(keep it, without it you won't be able to decrypt the backups)",
+	"keygen.public":               "🔑 Public key:",
+	"keygen.setEnv":               "ℹ️ Set in the .env file:",
+	"flag.verify.user":            "User whose backups to verify, by default all",
+	"verify.error":                "❌ Error while verifying the backups:",
+	"log.verified":                "Verification of the backups completed",
+	"verify.summary":              "🔎 %s: %d files, %d archives, %d states in the store\n",
+	"verify.plain":                "   ℹ️ %d files not encrypted: only checked that they can be read, changes can't be detected\n",
+	"verify.okIndented":           "   ✅ No problem found",
+	"cmd.translations.usage":      "translations",
+	"cmd.translations.help":       "Checks that the catalogues of the messages have the same keys and the same placeholders in all the languages; exits with code 1 if some translation is missing",
+	"translations.problems.one":   "🌐 %d problem in the catalogues of the messages\n",
+	"translations.problems.other": "🌐 %d problems in the catalogues of the messages\n",
+	"translations.ok":             "✅ Catalogues of the messages complete (%s)\n",
+
+	// pkg/terminal/compare.go
+	"compare.title":              "\n🆚 Comparison between ",
+	"compare.common":             "🤝 Tracks in common to all (%d)",
+	"compare.only":               "🎯 Tracks only in '%s' (%d)",
+	"compare.similarity":         "📐 Similarity (Jaccard index):",
+	"compare.similarityLine":     "   '%s' and '%s': %.1f%%\n",
+	"compare.artists":            "🎤 Artists in common to all (%d)",
+	"compare.others":             " and %d more",
+	"compare.othersLine":         "   ... and %d more\n",
+	"compare.commonName":         "In common: ",
+	"compare.invalidSet":         "invalid set: %s (valid: common or the number of the playlist, from 1 to %d)",
+	"compare.onlyName":           "Only in ",
+	"compare.nothingToSave":      "❌ No track to save",
+	"compare.description":        "Created by Playlist Manager comparing the playlists",
+	"compare.created":            "✅ Playlist '%s' created with %d tracks (%s)\n",
+	"compare.notTracks":          "ℹ️ %d items that are not tracks (podcasts or local files) were not copied\n",
+	"compare.selected":           "\n🆚 Selected playlists: %d\n",
+	"compare.addLive":            "🎧 Add a current playlist on Spotify",
+	"compare.addBackup":          "📂 Add a backup",
+	"compare.compare":            "🆚 Compare",
+	"compare.menuTitle":          "🆚 Comparison of playlists (%d selected)",
+	"compare.selectPlaylist":     "🆚 Select the playlist to compare",
+	"common.playlistError":       "❌ Error while fetching the playlist:",
+	"compare.saveCommon":         "🤝 The tracks in common to all (%d)",
+	"compare.saveOnly":           "🎯 The tracks only in '%s' (%d)",
+	"compare.save":               "💾 Do you want to save a set in a new playlist?",
+	"common.no":                  "🔙 No",
+	"compare.name":               "🎵 Name of the new playlist (enter for '%s'): ",
+	"common.createPlaylistError": "❌ Error while creating the playlist:",
+	"log.playlistError":          "Error while fetching the playlist",
+	"flag.compare.save":          "Creates a new playlist with a set: common (tracks in common) or the number of the playlist (tracks only in that one)",
+	"flag.compare.name":          "Name of the new playlist created with -save",
+	"common.readFileError":       "❌ Error while reading %s: %v\n",
+
+	// pkg/terminal/diff.go
+	"diff.title":        "\n🔍 Differences of '%s' (%s ➜ %s)\n",
+	"diff.none":         "✅ No difference",
+	"diff.added":        "➕ Added (%d):\n",
+	"diff.removed":      "➖ Removed (%d):\n",
+	"diff.moved":        "🔀 Moved (%d):\n",
+	"diff.selectBackup": "📂 Select the backup to compare",
+	"log.diffLive":      "Comparing backup with the current playlist",
+	"diff.selectNewer":  "📂 Select the most recent backup to compare it with",
+	"log.diffDifferent": "Comparison between backups of different playlists",
+	"diff.different":    "⚠️ The two backups are of different playlists",
+	"log.diffDone":      "Comparison completed",
+
+	// pkg/terminal/duplicates.go
+	"duplicates.none":           "✅ No duplicate in '%s'\n",
+	"duplicates.title":          "\n🔁 Duplicates in '%s' (%d groups):\n",
+	"duplicates.addedOn":        ", added on ",
+	"log.duplicatesRemoveError": "Error while removing the duplicates",
+	"log.duplicatesRemoved":     "Duplicates removed",
+	"duplicates.removed.one":    "✅ Removed %d duplicate from '%s'\n",
+	"duplicates.removed.other":  "✅ Removed %d duplicates from '%s'\n",
+	"duplicates.positions":      "🔢 Enter the positions (#) of the copies to remove, separated by spaces or commas (0 to cancel): ",
+	"duplicates.notDuplicate":   "⚠️ The position %d is not among the duplicates, ignored\n",
+	"duplicates.where":          "🔁 Where do you want to search the duplicates?",
+	"duplicates.inPlaylist":     "🎧 In a playlist",
+	"duplicates.inAll":          "📁 In all your playlists",
+	"duplicates.selectPlaylist": "🔁 Select the playlist where to search the duplicates",
+	"duplicates.near":           "❓ Search also the near duplicates (same ISRC, or same title and artists with a similar duration)? (y/n) ",
+	"duplicates.searching":      "⏳ Searching the duplicates in '%s'...\n",
+	"common.tracksError":        "❌ Error while fetching the tracks:",
+	"duplicates.action":         "🧹 What do you want to do with the duplicates of '%s'?",
+	"duplicates.keep":           "⏭️ Don't remove anything",
+	"duplicates.removeCopies":   "🗑️ Remove the copies, keeping the first of each group (%d tracks)",
+	"duplicates.choose":         "🔢 Choose the positions to remove",
+	"duplicates.removeError":    "❌ Error while removing the duplicates:",
+	"flag.duplicates.all":       "Searches in all the playlists you own",
+	"flag.duplicates.exact":     "Searches only the exact duplicates (same track), without the ones with the same ISRC or the same title and artists",
+	"flag.duplicates.remove":    "Removes the copies, keeping the first of each group",
+	"common.userError":          "❌ Error while fetching the user:",
+	"common.playlistsError":     "❌ Error while fetching the playlists:",
+
+	// pkg/terminal/export.go
+	"export.what":            "📤 What do you want to export?",
+	"export.live":            "🎧 A current playlist on Spotify",
+	"export.backup":          "📂 A backup",
+	"export.selectPlaylists": "📤 Select the playlists to export",
+	"export.count":           "%d playlists",
+	"export.fetching":        "⏳ Fetching '%s'...\n",
+	"flag.export.format":     "Format: m3u8, xspf, jspf or csv",
+	"flag.export.out":        "File where to export, by default data/export/<playlist name>_<ID>.<format>",
+	"export.error":           "❌ Error during the export:",
+	"export.errorPlaylist":   "❌ Error exporting '%s':",
+	"log.exported":           "Playlist exported",
+	"export.done":            "✅ Playlist exported to:",
+
+	// pkg/terminal/import.go
+	"log.importRead":         "Playlist read from the file",
+	"import.searching":       "\r🔎 Searching the tracks on Spotify: %d/%d",
+	"import.lowScore.one":    "\n⚠️ %d track found with low confidence (less than %.0f%%):\n",
+	"import.lowScore.other":  "\n⚠️ %d tracks found with low confidence (less than %.0f%%):\n",
+	"import.right":           "❓ Is it the right track? (y/n) ",
+	"import.notFound.one":    "\n❌ %d track not found:\n",
+	"import.notFound.other":  "\n❌ %d tracks not found:\n",
+	"import.link":            "🔗 Enter the Spotify link or URI of the track, 0 to skip it: ",
+	"import.skipped":         "- track skipped",
+	"import.path":            "📥 Enter the path of the file to import (CSV, M3U/M3U8, XSPF or JSPF): ",
+	"log.importFileError":    "Error while importing the file",
+	"import.fileError":       "❌ Error while importing the file:",
+	"import.summary":         "📊 '%s': %d tracks in the file, %d to import\n",
+	"import.where":           "📥 Where do you want to import the %d tracks?",
+	"import.new":             "🆕 In a new playlist ('%s')",
+	"import.description":     "Imported from ",
+	"import.selectPlaylist":  "📥 Select the playlist where to import the tracks",
+	"log.importAddError":     "Error while adding the imported tracks",
+	"common.addTracksError":  "❌ Error while adding the tracks:",
+	"log.imported":           "Tracks imported",
+	"import.done":            "✅ Imported %d tracks out of %d in '%s'\n",
+	"flag.import.playlist":   "Link, URI or ID of the playlist where to add the tracks, by default a new one is created",
+	"flag.import.name":       "Name of the new playlist, by default the title of the playlist in the file or the name of the file",
+	"flag.import.minScore":   "Minimum confidence (from 0 to 1) to import a track found with a search",
+	"flag.import.dryRun":     "Shows the tracks found without importing them",
+	"import.lowScoreSkipped": "⚠️ Low confidence, not imported: ",
+	"import.notFoundItem":    "❌ Not found: ",
+	"import.summaryCommand":  "📊 '%s': %d tracks in the file, %d to import, %d with low confidence, %d not found\n",
+	"import.doneCommand":     "✅ Imported %d tracks in the playlist %s\n",
+
+	// pkg/terminal/library.go
+	"library.saving":          "💾 Saving '%s'...\n",
+	"log.libraryError":        "Error while fetching the library",
+	"library.permissions":     "ℹ️ If the error is about permissions, authenticate again to grant access to the library",
+	"log.librarySaveError":    "Error while saving the library",
+	"log.librarySaved":        "Library saved",
+	"library.unchanged":       "☑️ '%s' unchanged, the latest backup is already in %s\n",
+	"library.saved":           "✅ '%s' saved (%d items) in %s\n",
+	"library.restoring":       "⏳ Restoring '%s' (%d items)...\n",
+	"library.added":           "\r📥 Added %d/%d",
+	"log.libraryRestoreError": "Error while restoring the library",
+	"library.restored":        "✅ '%s' restored: %d added, %d already present\n",
+	"library.notLibrary":      "❌ '%s' is not a backup of the library\n",
+	"common.error":            "❌ Error:",
+
+	// pkg/terminal/linked.go
+	"log.trackDetailsError":       "Error while fetching the details of the tracks from the Spotify API",
+	"linked.noInfo":               "Information not available",
+	"linked.unknownArtist":        "Unknown artist",
+	"track.by":                    " by ",
+	"linked.menu.show":            "👁️ Show the linked playlists",
+	"linked.menu.add":             "➕ Add a linked playlist",
+	"linked.menu.remove":          "🗑️ Remove a linked playlist",
+	"linked.menu.sync":            "🔄 Update the songs in the linked playlists",
+	"linked.menu.title":           "🔗 Linked Playlists Menu",
+	"linked.backToMain":           "🔙 Back to the main menu",
+	"linked.returning":            "🔙 Returning to the main menu...",
+	"linked.listTitle":            "🔗 -> List of the Linked Playlists <- 🔗",
+	"linked.noneAddOne":           "🕵️ No linked playlist, add one!",
+	"linked.file":                 "   📄 File: %s\n",
+	"linked.origin":               "   📥 Origin:",
+	"linked.destination":          "   🎯 Destination:",
+	"linked.addTitle":             "➕ -> New Linked Playlist <- ➕",
+	"linked.name":                 "🎵 What name do you want to give to the link between the playlists? ",
+	"linked.selectOrigin":         "🎯 Select the playlists to add as origin",
+	"linked.anotherOrigin":        "Do you want to add another playlist as origin? (y/n) ",
+	"linked.selectDestination":    "🎯 Select the playlists to add as destination",
+	"linked.anotherDestination":   "❓ Do you want to add another playlist as destination? (y/n) ",
+	"linked.removeTitle":          "🗑️ -> Removal of a Linked Playlist <- 🗑️",
+	"linked.none":                 "❌ No linked playlist, add one!",
+	"linked.selectRemove":         "🎯 -> Select the linked playlists to remove <- 🎯",
+	"linked.cancelBack":           "🚪 0. Cancel and go back",
+	"linked.removeSelection":      "⏎ Enter the linked playlists to remove: ",
+	"linked.removeConfirm":        "❓ Remove %d linked playlists? (y/n) ",
+	"log.syncStart":               "Start of the update of the linked playlists",
+	"log.linkedDirError":          "Error while reading the playlists folder",
+	"log.linkedFound":             "Linked playlists found",
+	"linked.syncTitle":            "🔄 -> Update of the Linked Playlists <- 🔄",
+	"log.linkedNone":              "No linked playlist found",
+	"linked.syncType":             "🎯 What kind of update do you want to run?",
+	"linked.cancelBackShort":      "🚪 Cancel and go back",
+	"linked.syncAdd":              "➕ Only add songs (from origin to destination)",
+	"linked.syncRemove":           "🗑️ Only remove songs (from the destinations)",
+	"linked.syncBoth":             "🔄 Both add and remove songs",
+	"log.syncType":                "Kind of operation selected",
+	"log.linkedLoading":           "Loading linked playlist",
+	"log.linkedReadError":         "Error while reading the playlist file",
+	"log.linkedParseError":        "Error while parsing the JSON of the playlist",
+	"log.linkedLoaded":            "Linked playlist loaded",
+	"linked.progress":             "Updating",
+	"linked.playlist":             "│ 🎧 Playlist: %s (%s)\n",
+	"linked.processing":           "│ ⏳ Processing...\n",
+	"log.originStart":             "Start of the fetch of the tracks from the origin playlists",
+	"log.originFetch":             "Fetching the tracks from an origin playlist",
+	"log.originError":             "Error while fetching the tracks from an origin playlist",
+	"log.originFetched":           "Tracks fetched from an origin playlist",
+	"log.originTotal":             "Total of the origin tracks fetched",
+	"log.destinationStart":        "Start of the processing of a destination playlist",
+	"log.destinationError":        "Error while fetching the tracks from a destination playlist",
+	"log.destinationFetched":      "Tracks fetched from a destination playlist",
+	"log.toAdd":                   "Tracks to add identified",
+	"log.availabilityTracksError": "Error while checking the availability of the tracks",
+	"log.availabilityChecked":     "Availability of the tracks checked",
+	"linked.nothingToAdd":         "│ ❌ No song to add to %s\n",
+	"log.nothingToAdd":            "No track to add",
+	"log.addStart":                "Start of the addition of the tracks to the playlist",
+	"log.addError":                "ERROR while adding the tracks to the playlist",
+	"log.added":                   "Tracks added successfully",
+	"linked.addedList":            "│     🎵 Songs added:\n",
+	"log.addedDetailsError":       "Error while fetching the details of the added tracks",
+	"log.addSkipped":              "Addition of the songs skipped by the user",
+	"log.toRemove":                "Tracks to remove identified",
+	"linked.nothingToRemove":      "│ ❌ No song to remove from %s\n",
+	"log.nothingToRemove":         "No track to remove",
+	"log.removeStart":             "Start of the removal of the tracks from the playlist",
+	"log.removeError":             "ERROR while removing the tracks from the playlist",
+	"log.removed":                 "Tracks removed successfully",
+	"linked.removedList":          "│    🎵 Songs removed:\n",
+	"log.removedDetailsError":     "Error while fetching the details of the removed tracks",
+	"log.removeSkipped":           "Removal of the songs skipped by the user",
+	"linked.completed":            "║                   ✅ UPDATE COMPLETED ✅                   ║",
+	"linked.allUpdated":           "║        All the linked playlists have been updated!         ║",
+	"linked.saved":                "Playlist %s saved as data/playlists/%s.json\n",
+	"linked.removedFile":          "✅ Playlist data/playlists/%s removed successfully\n",
+	"linked.adding.one":           "│ ⏳ Adding %d song to %s...\n",
+	"linked.adding.other":         "│ ⏳ Adding %d songs to %s...\n",
+	"linked.addedSongs.one":       "│ ✅ Added %d song to %s\n",
+	"linked.addedSongs.other":     "│ ✅ Added %d songs to %s\n",
+	"linked.removing.one":         "│ ⏳ Removing %d song from %s...\n",
+	"linked.removing.other":       "│ ⏳ Removing %d songs from %s...\n",
+	"linked.removedSongs.one":     "│ ✅ Removed %d song from %s\n",
+	"linked.removedSongs.other":   "│ ✅ Removed %d songs from %s\n",
+
+	// pkg/terminal/offline.go
+	"log.startOffline":       "Starting Playlist Manager in offline mode",
+	"offline.menu.dates":     "📅 Show the dates of the backups",
+	"offline.menu.tracks":    "🎵 Show the tracks of a saved playlist",
+	"offline.menu.search":    "🔍 Search a track in the backups",
+	"offline.menu.export":    "📤 Export a saved playlist",
+	"offline.menu.diff":      "🔀 Compare two backups",
+	"offline.menu.title":     "🏠 Offline Menu",
+	"offline.mode":           "📴 Offline mode (backups only)",
+	"log.offlineDates":       "The user requested the list of the dates of the backups",
+	"log.offlineTracks":      "The user requested to show a saved playlist",
+	"log.offlineSearch":      "The user requested the search in the backups",
+	"log.offlineExport":      "The user requested the export of a saved playlist",
+	"log.offlineDiff":        "The user requested the comparison of two backups",
+	"log.backupDirError":     "Error while reading the backup folder",
+	"log.offlineNoBackups":   "No backup found for the offline mode",
+	"offline.noBackupsIn":    "❌ No backup found in ",
+	"offline.whichUser":      "👤 Which user's backups do you want to browse?",
+	"offline.personal":       "\n👤 Backups of your playlists:",
+	"offline.others":         "\n👥 Backups of the playlists of other users:",
+	"offline.noBackups":      "❌ No backup found",
+	"offline.dateLine.one":   "📆 %s - %d playlist\n",
+	"offline.dateLine.other": "📆 %s - %d playlists\n",
+	"offline.format":         "📤 In which format do you want to export %s?",
+
+	// pkg/terminal/search.go
+	"search.what":            "🔍 What do you want to search (title, artist, album, ISRC or ID)? ",
+	"search.where":           "📑 Where do you want to search?",
+	"search.allFields":       "🔎 In all the fields",
+	"search.title":           "🎵 In the title",
+	"search.artists":         "🎤 In the artists",
+	"search.album":           "💿 In the album",
+	"search.isrc":            "🏷️ In the ISRC",
+	"search.fuzzy":           "❓ Find also the similar results (e.g. with typos)? (y/n) ",
+	"search.progress":        "Searching in the playlists",
+	"search.noResults":       "\n❌ No result for '%s'\n",
+	"search.results.one":     "\n🔍 Results for '%s' in %d playlist:\n",
+	"search.results.other":   "\n🔍 Results for '%s' in %d playlists:\n",
+	"search.current":         "current",
+	"search.backupOf":        "backup of ",
+	"search.backups":         "%d backups from %s to %s",
+	"search.similar":         " (%.0f%% similar)",
+	"log.searchBackupsError": "Error while searching in the backups",
+	"log.searchBackupsDone":  "Search in the backups completed",
+	"search.source":          "📍 Where do you want to search?",
+	"search.live":            "🎧 In the current playlists on Spotify",
+	"search.inBackups":       "📂 In the backups",
+	"search.both":            "🔄 In both",
+	"search.playlistsError":  "❌ Error while searching in the playlists:",
+	"log.searchDone":         "Search completed",
+	"flag.search.field":      "Field where to search: title, artist, album or isrc (by default all)",
+	"flag.search.fuzzy":      "Finds also the similar results, for example with typos",
+	"flag.search.live":       "Searches only in the current playlists on Spotify",
+	"flag.search.backups":    "Searches only in the backups, without accessing Spotify",
+	"flag.search.user":       "User whose backups to search, by default the only one present",
+	"search.backupsError":    "❌ Error while searching in the backups:",
+
+	// pkg/terminal/smart.go
+	"smart.file":              "   📄 File: %s.json\n",
+	"smart.likedSongs":        "      ↪ Liked songs",
+	"smart.destination":       "   🎯 Destination: %s\n",
+	"log.likedSongsError":     "Error while fetching the liked songs",
+	"log.originPlaylistError": "Error while fetching the origin playlist",
+	"log.smartSynced":         "Smart playlist updated",
+	"log.smartReadError":      "Error while reading the smart playlists",
+	"smart.none":              "❌ No smart playlist, add one!",
+	"smart.syncTitle":         "🧠 Update of the smart playlists",
+	"log.smartSyncError":      "Error while updating the smart playlist",
+	"smart.syncError":         "❌ Error while updating '%s': %v",
+	"smart.synced":            "✅ '%s' updated with %d tracks",
+	"smart.upToDate":          "✅ '%s' already up to date (%d tracks)",
+	"smart.fields":            "ℹ️ Fields: ",
+	"smart.operators":         "ℹ️ Operators: field:value (contains), = != < <= > >=, AND, OR, NOT, parentheses; ORDER BY field [ASC|DESC], ... and LIMIT n at the end",
+	"smart.example":           "ℹ️ Example: artist:\"Daft Punk\" AND year>=2015 AND NOT explicit ORDER BY added_at DESC LIMIT 50",
+	"smart.addTitle":          "➕ -> New Smart Playlist <- ➕",
+	"smart.name":              "🎵 What name do you want to give to the smart playlist? ",
+	"smart.query":             "🔎 Query (enter to cancel): ",
+	"smart.library":           "❓ Search in the liked songs? (y/n) ",
+	"smart.selectOrigin":      "📥 Select an origin playlist (0 to end the selection)",
+	"smart.noOrigin":          "❌ Choose at least one origin: the liked songs or a playlist",
+	"smart.where":             "🎯 Where do you want to save the result? The content of the playlist will be replaced at every update",
+	"smart.description":       "Smart playlist: ",
+	"smart.selectDestination": "🎯 Select the destination playlist",
+	"smart.notOwner":          "❌ You can use as destination only the playlists you own",
+	"smart.destIsSource":      "❌ The destination can't also be a source playlist",
+	"smart.replaceConfirm":    "⚠️ '%s' has %d tracks, that will be replaced by the result of the query. Continue? (y/n) ",
+	"smart.savedPrefix":       "✅ Smart playlist ",
+	"smart.savedAs":           " saved as ",
+	"smart.firstSync":         "⏳ First update in progress...",
+	"smart.syncErrorShort":    "❌ Error during the update:",
+	"smart.syncedLine":        "✅ '%s' updated with %d tracks\n",
+	"smart.removeWhich":       "🗑️ Which smart playlist do you want to remove?",
+	"smart.removed":           "✅ Smart playlist %s removed, the playlist '%s' on Spotify is not changed\n",
+	"smart.menu.title":        "🧠 Smart playlists",
+	"smart.menu.show":         "👁️ Show the smart playlists",
+	"smart.menu.add":          "➕ Add a smart playlist",
+	"smart.menu.remove":       "🗑️ Remove a smart playlist",
+	"smart.menu.sync":         "🔄 Update the smart playlists",
+	"smart.noneAddOne":        "🕵️ No smart playlist, add one!",
+	"flag.smart.library":      "With preview: searches in the liked songs",
+	"flag.smart.sources":      "With preview: links, URIs or IDs of the playlists where to search, separated by commas",
+	"smart.readError":         "❌ Error while reading the smart playlists:",
+	"smart.syncErrorLine":     "❌ Error while updating '%s': %v\n",
+	"smart.syncedCommand":     "✅ %s: %d tracks (updated: %t)\n",
+	"smart.originRequired":    "❌ Give at least one origin with -library or -sources",
+	"smart.queryError":        "❌ Error while evaluating the query:",
+	"smart.destinationSource": "the destination '%s' is also a source playlist",
+	"smart.count":             "📊 %d tracks\n",
+
+	// pkg/terminal/sort.go
+	"sort.title":          "\n🔃 New order of '%s' (%s):\n",
+	"sort.unavailable":    "Item not available on Spotify",
+	"sort.alreadySorted":  "\n✅ The playlist is already in this order",
+	"sort.moves":          "\n📊 %d moves are needed to apply the new order\n",
+	"log.sortApplyError":  "Error while reordering the playlist",
+	"log.sorted":          "Playlist reordered",
+	"sort.done":           "✅ Playlist '%s' reordered with %d moves\n",
+	"sort.selectPlaylist": "🔃 Select the playlist to sort",
+	"sort.notOwner":       "❌ You can sort only the playlists you own",
+	"sort.fields":         "\n🔃 Available sort fields:",
+	"sort.fieldsHint":     "ℹ️ Separate more fields with a comma, the first is the most important; add :desc for the descending order",
+	"sort.by":             "❓ Sort by (for example artist,release_date:desc): ",
+	"sort.apply":          "\n❓ Apply the new order? (y/n) ",
+	"sort.cancelled":      "🚪 Sorting cancelled",
+	"sort.error":          "❌ Error while reordering:",
+	"flag.sort.by":        "Sort fields separated by commas, with :desc for the descending order: ",
+	"flag.sort.apply":     "Applies the new order to the playlist, by default it is only shown",
+
+	// pkg/terminal/split.go
+	"log.playlistCreated":       "Playlist created",
+	"split.preview":             "\n✂️ '%s' (%d tracks) will be split in %d playlists:\n",
+	"split.part":                "🎵 %d. %s (%d tracks)\n",
+	"split.description":         "Part of ",
+	"split.created":             "✅ Created '%s' with %d tracks (%s)\n",
+	"log.splitDone":             "Playlist split",
+	"split.selectPlaylist":      "✂️ Select the playlist to split",
+	"split.how":                 "✂️ How do you want to split the playlist?",
+	"split.byCount":             "🔢 By number of tracks",
+	"split.byDecade":            "📅 By decade of release",
+	"split.byInitial":           "🔤 By initial of the artist",
+	"split.byMonth":             "🗓️ By month of addition",
+	"split.size":                "❓ How many tracks per playlist? ",
+	"split.name":                "❓ Name of the new playlists ({name} name of the playlist, {part} part, {n} number), enter for '%s': ",
+	"split.notTracks":           "ℹ️ %d items that are not tracks (podcasts, local files or not available) will not be copied\n",
+	"split.confirm":             "\n❓ Create the playlists? (y/n) ",
+	"split.cancelled":           "🚪 Split cancelled",
+	"split.createError":         "❌ Error while creating the playlists:",
+	"merge.selectPlaylists":     "🔀 Select the playlists to merge",
+	"merge.atLeastTwo":          "❌ Select at least two playlists to merge",
+	"merge.name":                "❓ Name of the new playlist: ",
+	"merge.skipDuplicates":      "❓ Skip the tracks present in more playlists? (y/n) ",
+	"merge.summary":             "\n🔀 '%s': %d tracks from %d playlists",
+	"merge.skipped":             ", %d duplicates skipped",
+	"merge.notTracks":           "ℹ️ %d items that are not tracks (podcasts, local files or not available) were not copied\n",
+	"merge.description":         "Merge of ",
+	"log.merged":                "Playlists merged",
+	"merge.created":             "✅ Created '%s' (%s)\n",
+	"flag.split.by":             "Criterion: ",
+	"flag.split.size":           "Number of tracks per playlist, with -by count",
+	"flag.split.name":           "Name of the new playlists: {name} name of the playlist, {part} part, {n} number",
+	"flag.split.dryRun":         "Shows the playlists that would be created without creating them",
+	"flag.merge.name":           "Name of the new playlist, by default the names of the merged playlists",
+	"flag.merge.keepDuplicates": "Keeps the tracks present in more playlists, by default only the first copy is kept",
+	"flag.merge.dryRun":         "Shows the result without creating the playlist",
+	"split.what":                "✂️ What do you want to do?",
+	"split.menu.split":          "✂️ Split a playlist in more playlists",
+	"split.menu.merge":          "🔀 Merge more playlists in a new playlist",
+
+	// pkg/terminal/stats.go
+	"stats.title":             "\n📊 Statistics of '%s'\n",
+	"stats.items":             "🎵 Items: %d (tracks %d, episodes %d, local files %d)\n",
+	"stats.unavailable":       "⚠️ Not available on Spotify: %d\n",
+	"stats.duration":          "⏱️ Total duration: %s\n",
+	"stats.explicit":          "🔞 Explicit: %d (%.1f%%)\n",
+	"stats.artists":           "🎤 Most frequent artists",
+	"stats.albums":            "💿 Most frequent albums",
+	"stats.years":             "📅 Year of release:",
+	"stats.you":               " (you)",
+	"stats.addedBy":           "👥 Added by",
+	"stats.overlap":           "\n🔀 Playlists with the most tracks in common",
+	"stats.noOverlap":         "✅ No track in common between the playlists",
+	"stats.morePairs":         "... and %d more pairs (all in the exported report)\n",
+	"stats.pair":              "%d. '%s' and '%s': %d in common (%.0f%% of the smallest)\n",
+	"stats.fetchingLiked":     "⏳ Fetching the liked songs...",
+	"stats.progress":          "Fetching the playlists",
+	"stats.library":           "Library",
+	"stats.playlists":         "\n📋 Playlists:",
+	"stats.playlistLine":      "   🎵 %s: %d items, %s\n",
+	"stats.export":            "📤 Do you want to export the statistics?",
+	"log.statsExportError":    "Error while exporting the statistics",
+	"stats.exportError":       "❌ Error while exporting the statistics:",
+	"log.statsExported":       "Statistics exported",
+	"stats.exported":          "✅ Statistics exported to ",
+	"stats.what":              "📊 What do you want to see the statistics of?",
+	"stats.wholeLibrary":      "📚 The whole library (liked songs and all the playlists)",
+	"stats.selectPlaylist":    "📊 Select the playlist",
+	"stats.libraryError":      "❌ Error while fetching the library:",
+	"flag.stats.library":      "Statistics of the whole library: liked songs and all the playlists",
+	"flag.stats.top":          "Number of most frequent artists and albums to show (0 for all)",
+	"flag.stats.format":       "Exports the statistics instead of showing them: json or csv",
+	"flag.stats.out":          "File where to export, by default data/export/statistiche-<date>.<format>",
+	"stats.unsupportedFormat": "❌ Unsupported format: %s (valid: %s, %s)\n",
+
+	// pkg/terminal/where.go
+	"where.which":          "🎵 Which track do you mean?",
+	"where.reading":        "\r⏳ Reading the playlists: %d/%d",
+	"log.whereDone":        "Search of the track in the playlists completed",
+	"where.none":           "❌ '%s' is not in any of your playlists\n",
+	"where.found.one":      "\n📍 '%s' is in %d playlist:\n",
+	"where.found.other":    "\n📍 '%s' is in %d playlists:\n",
+	"where.sameISRC":       " (same ISRC)",
+	"where.notEditable":    "🔒 '%s' is not editable, skipped\n",
+	"log.whereRemoveError": "Error while removing the track",
+	"log.whereRemoved":     "Track removed from the playlist",
+	"where.removed":        "✅ Removed from '%s' (%d)\n",
+	"where.playlists":      "🔢 Enter the playlists (0 to cancel): ",
+	"where.track":          "🎵 Enter the URI, the Spotify link or the title and the artist of the track: ",
+	"log.whereSearchError": "Error while searching the track",
+	"where.searchError":    "❌ Error while searching the track:",
+	"where.notFound":       "❌ No track found",
+	"where.isrc":           "❓ Search also the other versions with the same ISRC? (y/n) ",
+	"where.readError":      "❌ Error while reading the playlists:",
+	"where.remove":         "🗑️ Do you want to remove the track?",
+	"where.fromAll":        "📁 From all the editable playlists (%d)",
+	"where.choose":         "🔢 Choose the playlists",
+	"where.removeError":    "❌ Error during the removal:",
+	"flag.where.isrc":      "Finds also the other versions of the track with the same ISRC",
+	"flag.where.remove":    "Removes the track from all the editable playlists (yours or collaborative), the track must be given as URI, link or ID",
+	"where.noTrack":        "no track found",
+	"where.linkOnly":       "❌ With -remove the track must be given as URI, link or ID, not as text to search",
+
+	// internal/backup/archive.go
+	"archive.unsupported": "unsupported archive format: %s",
+	"archive.notFound":    "%s not found in the archive",
+	"archive.missingFile": "file %s missing in the archive",
+	"archive.badChecksum": "invalid checksum for the file %s, the archive may be damaged",
+
+	// internal/backup/backup.go
+	"log.backupModeInvalid":   "Invalid backup mode, files will be used",
+	"log.backupMode":          "Backup mode set",
+	"log.backupFileReadError": "Cannot read the backup file",
+
+	// internal/backup/crypto.go
+	"crypto.openIdentity":     "opening the private keys file: %w",
+	"crypto.readIdentity":     "reading the private keys file: %w",
+	"crypto.invalidRecipient": "invalid public key %s: %w",
+	"log.encryption":          "Encryption of the backups set",
+	"crypto.noKey":            "encrypted backup but no key set (BACKUP_PASSPHRASE or BACKUP_IDENTITY_FILE)",
+	"crypto.decryptError":     "cannot decrypt the backup, the key is wrong or the file has been altered: %w",
+	"crypto.altered":          "the encrypted backup has been altered: %w",
+	"crypto.readKey":          "reading the key of the backups %s: %w",
+	"crypto.wrongPassphrase":  "cannot decrypt the key of the backups %s, the passphrase (BACKUP_PASSPHRASE) is wrong or the file has been altered: %w",
+	"log.backupKeyCreated":    "Key of the backups created and encrypted with the passphrase",
+
+	// internal/backup/library.go
+	"library.likedSongs":      "Liked songs",
+	"library.savedAlbums":     "Saved albums",
+	"library.followedArtists": "Followed artists",
+
+	// internal/backup/retention.go
+	"log.retentionDirSkipped":      "Backup folder skipped, the name is not a date",
+	"log.retentionManifestSkipped": "Manifest skipped, the name is not a date",
+	"log.retentionVersionRemoved":  "Version removed from the store by the retention policy",
+	"log.retentionBackupRemoved":   "Backup removed by the retention policy",
+	"log.retentionDirRemoved":      "Empty backup folder removed",
+
+	// internal/backup/search.go
+	"search.invalidField": "invalid search field: %s (valid: %s)",
+
+	// internal/backup/sort.go
+	"sort.invalidField": "invalid sort field: %s (valid: %s)",
+	"sort.invalidOrder": "invalid order for %s: %s (valid: asc, desc)",
+	"sort.noField":      "no sort field given",
+
+	// internal/backup/split.go
+	"split.invalidSize":      "invalid number of tracks per part: %d",
+	"split.invalidCriterion": "invalid split criterion: %s (valid: %s)",
+	"backup.unknown":         "unknown",
+
+	// internal/backup/store.go
+	"store.readState":         "reading the state %s of %s: %w",
+	"log.storeStateReadError": "Cannot read the state of the playlist from the store",
+	"store.readManifest":      "reading the manifest %s: %w",
+	"log.storeDecryptError":   "Cannot decrypt the state of the store",
+	"store.invalidHash":       "invalid hash: %q",
+	"log.storeStateRemoved":   "State no longer used removed from the store",
+
+	// internal/backup/duplicates.go
+	"duplicates.sameTrack": "same track",
+	"duplicates.sameISRC":  "same ISRC",
+	"duplicates.sameName":  "same title and artists",
+
+	// internal/spotify/match.go
+	"match.uri":       "uri",
+	"match.isrc":      "isrc",
+	"match.search":    "search",
+	"log.matchNoISRC": "No track found by ISRC",
+
+	// internal/spotify/relink.go
+	"relink.playable":    "playable",
+	"relink.relinked":    "relinked",
+	"relink.isrc":        "isrc",
+	"relink.unavailable": "not available",
+	"log.relinked":       "Unavailable track replaced",
+	"log.relinkNone":     "Unavailable track and no replacement found",
+
+	// internal/link/link.go
+	"link.track":       "track",
+	"link.episode":     "episode",
+	"link.playlist":    "playlist",
+	"link.album":       "album",
+	"link.artist":      "artist",
+	"link.show":        "podcast",
+	"link.user":        "user",
+	"link.notLink":     "'%s' is not a Spotify link, URI or ID",
+	"link.wrongKind":   "'%s' is of type %s, not %s",
+	"link.invalidURI":  "invalid Spotify URI: %s",
+	"link.invalidLink": "invalid Spotify link: %s",
+	"link.invalid":     "'%s' is not a valid Spotify link or URI",
+
+	// internal/query/parser.go
+	"query.unclosedQuote":     "invalid query: unclosed quotes at position %d",
+	"query.bang":              "invalid query: operator ! at position %d, use != or NOT",
+	"query.invalidAt":         "invalid query at position %d: %s",
+	"query.missingParen":      "missing closing parenthesis",
+	"query.missingOperator":   "invalid query at position %d: missing operator after '%s' (for example %s:value)",
+	"query.invalidAtWrap":     "invalid query at position %d: %w",
+	"query.missingValue":      "missing value of %s",
+	"query.missingCondition":  "missing condition at the end of the query",
+	"query.expectedCondition": "condition expected instead of '%s'",
+
+	// internal/query/query.go
+	"query.missingBy":        "missing BY after ORDER",
+	"query.missingSortField": "missing sort field",
+	"query.limit":            "LIMIT must be followed by a positive number",
+	"query.unexpected":       "unexpected text: %s",
+	"query.textOperators":    "the field %s can be compared only with :, = and !=",
+	"query.numberRequired":   "the field %s requires a number, not '%s'",
+	"query.boolOperators":    "the field %s can be compared only with true or false",
+	"query.invalidField":     "invalid field: %s (valid: %s)",
+
+	// internal/selection/selection.go
+	"selection.none":       "no item selected with '%s'",
+	"selection.noneMine":   "no item in the list is yours",
+	"selection.outOfRange": "'%s' is out of the list (from 1 to %d)",
+	"selection.noMatch":    "no item matches '%s'",
+
+	// internal/export/export.go
+	"export.unsupported": "unsupported export format: %s",
+
+	// internal/export/import.go
+	"import.unsupported": "unsupported import format: %s",
+	"import.noColumn":    "the CSV file has no column with the title, the URI or the ISRC of the tracks",
+
+	// internal/export/stats.go
+	"stats.unsupportedReport": "unsupported report format: %s (valid: %s, %s)",
+
+	// internal/config/env.go
+	"config.loadError":      "Error while loading the .env file. Check that it is in the same folder of the executable and that it contains the needed information as described in the documentation.",
+	"config.pressEnterExit": "Press enter to exit...",
+	"config.invalidValue":   "Invalid value for %s: %s, %d will be used\n",
+
+	// internal/spotify/library.go
+	"library.invalidKind":      "invalid library type: %s",
+	"library.notLibraryBackup": "the backup '%s' is not a backup of the library",
+	"log.libraryRestored":      "Library restored",
+
+	// internal/spotify/spotify.go
+	"log.dirBackupCreated":        "Folder data/backup created",
+	"log.dirAuthCreated":          "Folder data/auth created",
+	"log.dirPlaylistsCreated":     "Folder data/playlists created",
+	"log.authVarsError":           "Spotify initialization: error while fetching the authentication variables",
+	"log.authVarsMissing":         "File data/auth/auth.yaml does not exist. New authentication variables will be generated.",
+	"log.authVarsRead":            "Authentication variables read from data/auth/auth.yaml",
+	"log.authVarsSaved":           "Authentication variables generated and saved in data/auth/auth.yaml",
+	"log.authServerClosed":        "Authentication server closed successfully",
+	"log.authServerCloseError":    "[Spotify] Closing the server",
+	"log.tokenExpired":            "Authentication token expired. The authentication will be done again.",
+	"log.tokenRefreshError":       "Error while refreshing the authentication token: ",
+	"log.authURL":                 "Authentication URL generated",
+	"auth.openURL":                "Open this URL to authenticate: ",
+	"auth.noURL":                  "Error: authentication URL not available",
+	"auth.noURLError":             "authentication URL not available",
+	"log.authServerStart":         "Starting the authentication server...",
+	"log.authCompleted":           "Authentication completed",
+	"log.authServerShutdownError": "Error while closing the server: ",
+	"log.tokenError":              "Getting the token from Spotify: ",
+	"log.tokenSaveError":          "Error while saving the authentication token: ",
+	"auth.page":                   "Authentication completed successfully. You can now close this page.",
+	"auth.failedPage":             "Authentication failed. Close this page and try again from the program.",
+	"log.tokenMissing":            "File data/auth/token.json does not exist. The authentication will be done again.",
+	"log.tokenRead":               "Authentication token read from data/auth/token.json",
+	"log.tokenSaved":              "Authentication token saved in data/auth/token.json",
+	"log.itemUnavailable":         "Item not available on Spotify skipped",
+	"log.rateLimited":             "Spotify request limit exceeded, waiting before trying again",
+	"log.itemNotTrack":            "Item that is not a track skipped",
+	"log.itemNotSaved":            "Item not available on Spotify, it cannot be saved",
+	"log.coverDownloadError":      "Cannot download the cover of the playlist",
+	"log.playlistUnchanged":       "Playlist unchanged since the latest backup",
+
+	// pkg/utils/utils.go
+
+	// main.go
+	"flag.offline":        "Browse and export the backups without authenticating to Spotify",
+	"log.loggerInit":      "Logger: initialized",
+	"log.languageInvalid": "Invalid language, the one of the system is used",
+	"main.usage":          "Usage: playlist-manager [options] [command]",
+
+	// pkg/i18n/i18n.go
+	"i18n.unsupported": "unsupported language: %s (supported: %s)",
+	"i18n.missing":     "%s: missing key %s",
+	"i18n.verbs":       "%s: the key %s has the placeholders [%s] instead of [%s]",
+	"i18n.unknown":     "%s: the key %s does not exist in Italian",
+}
@@ -0,0 +1,158 @@
+// Contents: translations of the messages shown to the user and written in the logs, with the choice of the language and the plurals
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Languages of the catalogues: Italian is the default and the one used when a message is missing in another language
+const (
+	Italian = "it"
+	English = "en"
+)
+
+// catalogues contains the messages of each language by key. The plurals have a key for each form, ending with .one and .other (see N)
+var catalogues = map[string]map[string]string{
+	Italian: italian,
+	English: english,
+}
+
+// lang is the language of the messages, read from the locale until Init is called
+var lang = localeLanguage()
+
+/*
+Init sets the language of the messages: it or en, empty to use the one of the locale (LANGUAGE, LC_ALL, LC_MESSAGES or LANG)
+Returns an error if the language is not supported, in that case the language is not changed
+*/
+func Init(language string) error {
+	if language == "" {
+		lang = localeLanguage()
+		return nil
+	}
+	l, ok := parseLanguage(language)
+	if !ok {
+		return fmt.Errorf(T("i18n.unsupported"), language, strings.Join(Languages(), ", "))
+	}
+	lang = l
+	return nil
+}
+
+// Lang returns the language of the messages
+func Lang() string {
+	return lang
+}
+
+// Languages returns the supported languages, sorted
+func Languages() []string {
+	langs := make([]string, 0, len(catalogues))
+	for l := range catalogues {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// localeLanguage returns the language of the locale if it is supported, otherwise Italian
+func localeLanguage() string {
+	for _, env := range []string{"LANGUAGE", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(env); value != "" {
+			// LANGUAGE is a list of languages in order of preference, separated by colons
+			for _, v := range strings.Split(value, ":") {
+				if l, ok := parseLanguage(v); ok {
+					return l
+				}
+			}
+			break
+		}
+	}
+	return Italian
+}
+
+// parseLanguage reads a language as it, en, it_IT, en-GB or en_US.UTF-8, false if it is not supported
+func parseLanguage(value string) (string, bool) {
+	l := strings.ToLower(value)
+	if i := strings.IndexAny(l, "_-.@"); i >= 0 {
+		l = l[:i]
+	}
+	_, ok := catalogues[l]
+	return l, ok
+}
+
+/*
+T returns the message of the key in the current language, formatted with args as with fmt.Sprintf if there are any.
+A message missing in the language is taken from the Italian catalogue, a key missing in all of them is returned as it is
+*/
+func T(key string, args ...any) string {
+	msg, ok := catalogues[lang][key]
+	if !ok {
+		msg, ok = italian[key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N returns the message of the key in the plural form of the number n (for example "%d canzone" or "%d canzoni"), formatted with args as T
+func N(key string, n int, args ...any) string {
+	return T(key+"."+pluralForm(n), args...)
+}
+
+/*
+pluralForm returns the form of the plural of n: all the supported languages have the singular only for 1,
+a language with other rules needs its own forms here and in the catalogues
+*/
+func pluralForm(n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// verbs matches the verbs of fmt in the messages, the %% are skipped
+var verbs = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+/*
+Check compares the catalogues: each key must be in all the languages, with the same verbs of fmt in the same order
+Returns the problems found, sorted, empty if the catalogues are complete
+*/
+func Check() []string {
+	var problems []string
+	for _, l := range Languages() {
+		for key, msg := range italian {
+			other, ok := catalogues[l][key]
+			switch {
+			case !ok:
+				problems = append(problems, T("i18n.missing", l, key))
+			case !slices.Equal(formatVerbs(msg), formatVerbs(other)):
+				problems = append(problems, T("i18n.verbs", l, key, strings.Join(formatVerbs(other), " "), strings.Join(formatVerbs(msg), " ")))
+			}
+		}
+		for key := range catalogues[l] {
+			if _, ok := italian[key]; !ok {
+				problems = append(problems, T("i18n.unknown", l, key))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// formatVerbs returns the verbs of fmt in a message, without the %%
+func formatVerbs(msg string) []string {
+	var res []string
+	for _, v := range verbs.FindAllString(msg, -1) {
+		if v != "%%" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,55 @@
+package i18n
+
+import "testing"
+
+// TestCheck fails when a key is missing in a catalogue or its verbs of fmt differ between the languages
+func TestCheck(t *testing.T) {
+	for _, problem := range Check() {
+		t.Error(problem)
+	}
+}
+
+func TestN(t *testing.T) {
+	previous := lang
+	t.Cleanup(func() { lang = previous })
+
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{lang: Italian, n: 0, want: "✅ Rimossi 0 duplicati da 'Rock'\n"},
+		{lang: Italian, n: 1, want: "✅ Rimosso 1 duplicato da 'Rock'\n"},
+		{lang: Italian, n: 2, want: "✅ Rimossi 2 duplicati da 'Rock'\n"},
+		{lang: English, n: 1, want: "✅ Removed 1 duplicate from 'Rock'\n"},
+		{lang: English, n: 3, want: "✅ Removed 3 duplicates from 'Rock'\n"},
+	}
+	for _, tt := range tests {
+		lang = tt.lang
+		if got := N("duplicates.removed", tt.n, tt.n, "Rock"); got != tt.want {
+			t.Errorf("N in %s with %d = %q, want %q", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{value: "it", want: Italian, ok: true},
+		{value: "EN", want: English, ok: true},
+		{value: "en_US.UTF-8", want: English, ok: true},
+		{value: "en-GB", want: English, ok: true},
+		{value: "it_IT@euro", want: Italian, ok: true},
+		{value: "fr_FR", ok: false},
+		{value: "C", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseLanguage(tt.value)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseLanguage(%q) = %q, %v, want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
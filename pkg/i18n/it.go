@@ -0,0 +1,999 @@
+// Contents: Italian catalogue of the messages, the reference for the keys and the verbs of fmt of the other languages
+package i18n
+
+var italian = map[string]string{
+	// pkg/terminal/menu.go
+	"menu.choice":             "\n❓ Scelta: ",
+	"playlist.private":        "privata",
+	"playlist.collaborative":  "collaborativa",
+	"playlist.public":         "pubblica",
+	"playlist.owner":          "👤 Proprietario: ",
+	"playlist.tracks":         "🔢 Brani: %d",
+	"playlist.visibility":     "🔒 Visibilità: ",
+	"menu.pressEnterContinue": "\n⏎ Premi invio per continuare...",
+	"menu.cancel":             "🔙 0. Annulla",
+	"menu.playlistNumber":     "\n🔍 Inserisci il numero della playlist: ",
+	"menu.tuiSelectionHint":   "🔢 Oppure scrivi una selezione (1,4,7-12 · all · mine · !3) o incolla link, URI o ID e premi invio",
+	"menu.playlistsSelection": "\n🔍 Inserisci le playlist: ",
+	"menu.browseHint":         "Esc per tornare al menu",
+
+	// pkg/terminal/selection.go
+	"selection.linkHint":         "🔗 Puoi anche incollare il link, l'URI o l'ID di una playlist non in elenco (ad esempio di un amico)",
+	"selection.hint":             "🔢 Puoi sceglierne più di una: 1,4,7-12 · all (tutte) · mine (le tue) · un testo del nome o un modello (rock*) · !3 per escludere",
+	"selection.listHint":         "🔢 Puoi sceglierne più di una: 1,4,7-12 · all (tutte) · un testo del nome o un modello (rock*) · !3 per escludere",
+	"selection.playlistArgHint":  "Le playlist si indicano con il link (https://open.spotify.com/playlist/...), l'URI (spotify:playlist:...) o l'ID",
+	"selection.invalid":          "❌ Selezione non valida",
+	"log.playlistNotFound":       "Playlist non trovata",
+	"selection.playlistNotFound": "❌ Playlist non trovata:",
+
+	// pkg/tui/list.go
+	"tui.filterPlaceholder": " 🔍 Scrivi per filtrare▏",
+	"tui.selectedCount":     "%d selezionate · %s",
+	"tui.noMatch":           " Nessun elemento corrisponde al filtro, invio per usare il testo scritto",
+	"tui.keys":              " ↑↓ muovi · PgUp/PgDn pagina · invio scegli · Esc indietro",
+	"tui.keysMulti":         " ↑↓ muovi · Tab seleziona · Ctrl+A tutte · invio conferma · Esc indietro",
+	"tui.page":              " · pagina %d/%d",
+
+	// pkg/tui/progress.go
+	"tui.completed": "completato in %s",
+
+	// pkg/terminal/terminal.go
+	"log.start":                       "Avvio di Playlist Manager",
+	"menu.main.playlists":             "📋 Visualizza le playlist del tuo account",
+	"menu.main.tracks":                "🎵 Visualizza i brani di una playlist del tuo account",
+	"menu.main.backup":                "💾 Salva una o più playlist (Backup)",
+	"menu.main.backupAll":             "📁 Salva tutte le playlist (Backup)",
+	"menu.main.restore":               "🔄 Carica una playlist (Restore)",
+	"menu.main.linked":                "🔗 Visualizza e gestisci le playlist collegate",
+	"menu.main.reauth":                "🔑 Riautenticati (cancella credenziali e rifai login)",
+	"menu.main.diff":                  "🔍 Confronta un backup (con un altro backup o con la playlist attuale)",
+	"menu.main.prune":                 "🧹 Elimina i backup vecchi (politica di conservazione)",
+	"menu.main.export":                "📤 Esporta una o più playlist o un backup (M3U8, XSPF, JSPF, CSV)",
+	"menu.main.import":                "📥 Importa una playlist da file (CSV, M3U, XSPF, JSPF)",
+	"menu.main.library":               "📚 Salva la libreria (brani che ti piacciono, album salvati, artisti seguiti)",
+	"menu.main.duplicates":            "🔁 Trova e rimuovi i brani duplicati",
+	"menu.main.sort":                  "🔃 Ordina una playlist",
+	"menu.main.split":                 "✂️ Dividi o unisci playlist",
+	"menu.main.smart":                 "🧠 Gestisci le playlist intelligenti",
+	"menu.main.stats":                 "📊 Statistiche delle playlist e della libreria",
+	"menu.main.compare":               "🆚 Confronta due o più playlist",
+	"menu.main.search":                "🔎 Cerca un brano nelle playlist e nei backup",
+	"menu.main.where":                 "📍 In quali playlist è un brano?",
+	"log.authError":                   "Errore durante l'autenticazione Spotify",
+	"log.authDone":                    "Autenticazione Spotify completata con successo",
+	"menu.developedBy":                " 🎧\n✨ Sviluppato da ",
+	"menu.main.title":                 "🏠 Menù Principale",
+	"menu.exit":                       "🚪 Esci",
+	"log.exit":                        "L'utente ha scelto di uscire dall'applicazione",
+	"menu.bye":                        "👋 Ciao! Esco dall'applicazione...",
+	"log.showPlaylists":               "L'utente ha richiesto la visualizzazione delle playlist",
+	"log.playlistsError":              "Errore nel recupero delle playlist",
+	"log.playlistsFetched":            "Playlist recuperate con successo",
+	"menu.yourPlaylists":              "📋 Le tue playlist",
+	"menu.yourPlaylistsList":          "\n🎼 Le tue playlist:",
+	"menu.pressEnter":                 "\n⏎ Premi invio per tornare al menu...",
+	"log.showTracks":                  "L'utente ha richiesto la visualizzazione dei brani di una playlist",
+	"log.tracksPlaylistsError":        "Errore nel recupero delle playlist per la visualizzazione dei brani",
+	"menu.selectPlaylist":             "🎼 Seleziona una playlist",
+	"log.tracksSelectionError":        "Errore nella lettura della selezione playlist per brani",
+	"log.tracksCancelled":             "L'utente ha annullato la visualizzazione dei brani",
+	"log.tracksInvalid":               "Selezione non valida per visualizzare i brani",
+	"log.backupSome":                  "L'utente ha richiesto il backup di alcune playlist",
+	"log.backupPlaylistsError":        "Errore nel recupero delle playlist per backup singolo",
+	"backup.selectPlaylists":          "💾 Seleziona le playlist da salvare",
+	"log.backupSelectionError":        "Errore nella lettura delle playlist selezionate per backup",
+	"log.backupCancelled":             "L'utente ha annullato il backup della playlist",
+	"log.backupInvalid":               "Selezione non valida per il backup della playlist",
+	"log.backupStart":                 "Inizio backup playlist",
+	"log.backupError":                 "Errore durante il backup della playlist",
+	"log.backupDone":                  "Backup playlist completato con successo",
+	"backup.unchangedIn":              "☑️ Playlist invariata dall'ultimo backup, già salvata in:",
+	"backup.savedIn":                  "✅ Playlist salvata in:",
+	"log.backupAll":                   "L'utente ha richiesto il backup di tutte le playlist personali",
+	"log.backupAllPlaylistsError":     "Errore nel recupero delle playlist per backup multiplo",
+	"log.backupAllPlaylists":          "Playlist personali da salvare ottenute",
+	"backup.willSave":                 "💾 Verranno salvate %d playlist personali in:\n📂 %s\n\n",
+	"backup.saveError":                "❌ Errore nel salvataggio della playlist '%s': %v",
+	"log.backupPlaylistDone":          "Backup playlist completato",
+	"log.backupAllDone":               "Backup multiplo completato",
+	"log.backupRemovedError":          "Errore durante la registrazione delle playlist eliminate",
+	"backup.allDone":                  "✅ Backup completato! Salvate %d playlist personali, %d invariate dall'ultimo backup.\n",
+	"log.autoPruneError":              "Errore nella pulizia automatica dei backup",
+	"log.archiveError":                "Errore nella creazione dell'archivio",
+	"log.restore":                     "L'utente ha richiesto il ripristino di una playlist",
+	"restore.error":                   "❌ Errore nel ripristino:",
+	"restore.where":                   "📥 Dove vuoi ripristinare '%s'?",
+	"common.cancel":                   "🔙 Annulla",
+	"restore.existing":                "🎧 In una playlist esistente",
+	"restore.new":                     "🆕 In una nuova playlist (con nome, descrizione e copertina del backup)",
+	"restore.selectDestination":       "🎯 Seleziona la playlist di destinazione per '%s'",
+	"log.restoreDestinationCancelled": "L'utente ha annullato la selezione della playlist di destinazione",
+	"restore.inProgress":              "⏳ Ripristino di '%s' in corso...\n",
+	"restore.done":                    "✅ Playlist '%s' caricata con successo in '%s'!\n",
+	"log.linked":                      "L'utente ha richiesto la gestione delle playlist collegate",
+	"log.linkedError":                 "Errore nella gestione delle playlist collegate",
+	"log.reauth":                      "L'utente ha richiesto la riautenticazione",
+	"reauth.start":                    "🔑 Riautenticazione in corso...",
+	"log.reauthRemove":                "Rimozione credenziali esistenti",
+	"reauth.remove":                   "🗑️ Rimozione credenziali esistenti...",
+	"log.reauthRemoveError":           "Errore nella rimozione delle credenziali",
+	"reauth.removeError":              "⚠️ Errore nella rimozione delle credenziali:",
+	"reauth.continue":                 "🔄 Procedo comunque con la riautenticazione...",
+	"log.reauthRemoved":               "Credenziali rimosse con successo",
+	"reauth.removed":                  "✅ Credenziali rimosse con successo",
+	"log.reauthNoCredentials":         "Nessuna credenziale esistente da rimuovere",
+	"reauth.new":                      "🔐 Avvio nuova autenticazione...",
+	"log.reauthError":                 "Errore durante la riautenticazione",
+	"reauth.error":                    "❌ Errore nella riautenticazione:",
+	"log.reauthDone":                  "Riautenticazione completata con successo",
+	"reauth.done":                     "✅ Riautenticazione completata con successo!",
+	"log.diff":                        "L'utente ha richiesto il confronto di un backup",
+	"diff.with":                       "🔍 Con cosa vuoi confrontare il backup?",
+	"common.backToMenu":               "🔙 Torna al menu",
+	"diff.withBackup":                 "📂 Con un altro backup",
+	"diff.withLive":                   "🎧 Con la playlist attuale su Spotify",
+	"log.diffError":                   "Errore nel confronto del backup",
+	"log.prune":                       "L'utente ha richiesto la pulizia dei backup",
+	"log.pruneError":                  "Errore nella pulizia dei backup",
+	"log.export":                      "L'utente ha richiesto l'esportazione di una playlist",
+	"log.exportError":                 "Errore nell'esportazione della playlist",
+	"log.import":                      "L'utente ha richiesto l'importazione di una playlist da file",
+	"log.importError":                 "Errore nell'importazione della playlist",
+	"log.library":                     "L'utente ha richiesto il backup della libreria",
+	"library.saveError":               "❌ Errore nel salvataggio della libreria:",
+	"log.duplicates":                  "L'utente ha richiesto la ricerca dei duplicati",
+	"log.duplicatesError":             "Errore nella ricerca dei duplicati",
+	"log.sort":                        "L'utente ha richiesto l'ordinamento di una playlist",
+	"log.sortError":                   "Errore nell'ordinamento della playlist",
+	"log.split":                       "L'utente ha richiesto la divisione o l'unione di playlist",
+	"log.splitError":                  "Errore nella divisione o unione di playlist",
+	"log.smart":                       "L'utente ha richiesto la gestione delle playlist intelligenti",
+	"log.smartError":                  "Errore nella gestione delle playlist intelligenti",
+	"log.stats":                       "L'utente ha richiesto le statistiche",
+	"log.statsError":                  "Errore nel calcolo delle statistiche",
+	"log.compare":                     "L'utente ha richiesto il confronto tra playlist",
+	"log.compareError":                "Errore nel confronto tra playlist",
+	"log.search":                      "L'utente ha richiesto la ricerca di un brano",
+	"log.searchError":                 "Errore nella ricerca",
+	"log.where":                       "L'utente ha richiesto le playlist che contengono un brano",
+	"log.whereError":                  "Errore nella ricerca del brano nelle playlist",
+	"log.invalidChoice":               "Scelta menu non valida",
+	"menu.invalidChoice":              "❌ Scelta non valida o non ancora implementata",
+	"log.tracksPlaylist":              "L'utente ha selezionato una playlist per visualizzazione brani",
+	"log.tracksError":                 "Errore nel recupero dei brani della playlist",
+	"log.tracksFetched":               "Brani della playlist recuperati con successo",
+	"tracks.unavailable":              "⚠️ %d. Elemento non disponibile su Spotify",
+	"log.tracksUnavailable":           "Elemento non disponibile su Spotify",
+	"tracks.title":                    "🎵 Brani della playlist '%s':",
+	"auth.notAuthenticatedShort":      "🔐 non autenticato ❌",
+	"log.userIDError":                 "Errore nel recupero dell'ID dell'utente",
+	"log.userIDDone":                  "UserID recuperato con successo",
+	"auth.authenticated":              "🔐 Autenticato: ",
+	"auth.user":                       "👤 Utente:",
+	"log.notAuthenticated":            "Utente non autenticato",
+	"log.saveStart":                   "Inizio salvataggio playlist (con animazione)",
+	"backup.saving":                   "💾 Salvataggio di '%s' in corso ",
+	"log.saveError":                   "Errore durante il salvataggio",
+	"log.saveDone":                    "Salvataggio completato con successo",
+	"backup.unchanged":                "\n☑️ Playlist '%s' invariata, l'ultimo backup è già in %s\n\n",
+	"backup.saved":                    "\n✅ Playlist '%s' salvata come %s.json\n\n",
+	"backup.saveErrorLine":            "\n❌ Errore nel salvataggio della playlist '%s': %v\n\n",
+
+	// pkg/terminal/backup.go
+	"backup.whichPlaylist":         "🔄 Che playlist vuoi selezionare?",
+	"backup.personal":              "👤 Una delle mie personali",
+	"backup.others":                "👥 Una di un altro utente",
+	"backup.fromArchive":           "🗜️ Da un archivio (.tar.gz o .zip)",
+	"log.ownerChoiceError":         "Errore nella lettura della scelta sul proprietario",
+	"log.backupSelectionCancelled": "L'utente ha annullato la selezione del backup",
+	"log.ownerChoiceInvalid":       "Scelta del proprietario non valida",
+	"common.invalidChoice":         "❌ Scelta non valida",
+	"log.playlistKindChosen":       "L'utente ha scelto il tipo di playlist da selezionare",
+	"log.backupDirsError":          "Errore nella lettura delle cartelle di backup",
+	"log.noBackups":                "Nessun backup trovato",
+	"backup.noPersonal":            "❌ Nessun backup di tue playlist trovato",
+	"backup.noOthers":              "❌ Nessun backup di playlist di altri trovato",
+	"log.backupDirsFound":          "Cartelle di backup trovate",
+	"backup.selectDate":            "📅 Seleziona la data del backup",
+	"log.dateSelectionError":       "Errore nella lettura della selezione data",
+	"log.dateCancelled":            "L'utente ha annullato la selezione della data",
+	"log.dateInvalid":              "Selezione data non valida",
+	"log.dateSelected":             "Data del backup selezionata",
+	"backup.noFiles":               "❌ Nessun file di backup valido trovato per la data %s.\n",
+	"backup.savedOn":               "🎵 Playlist salvate il %s",
+	"log.archivesError":            "Errore nella lettura degli archivi",
+	"log.archiveReadError":         "Errore nella lettura dell'archivio",
+	"archive.readError":            "❌ Impossibile leggere l'archivio:",
+	"log.archiveRead":              "Archivio letto e verificato",
+	"archive.title":                "🗜️ Archivio di %s del %s (versione %s), %d playlist e %d brani",
+	"archive.select":               "🗜️ Seleziona l'archivio",
+	"archive.pathHint":             "📂 Oppure scrivi il percorso di un altro archivio e premi invio",
+	"archive.selectList":           "\n🗜️ Seleziona l'archivio:",
+	"common.backToMenu0":           "🔙 0. Torna al menu",
+	"archive.number":               "\n🗜️ Inserisci il numero dell'archivio o il suo percorso: ",
+	"log.archiveCreated":           "Archivio dei backup creato",
+	"archive.created":              "🗜️ Archivio creato: %s (%d playlist, %d brani)\n",
+	"tracks.titleLine":             "\n🎵 Brani della playlist '%s':\n",
+	"backup.onlyIDs":               "ℹ️ Backup senza dettagli dei brani, vengono mostrati solo gli ID",
+	"backup.unavailableItems":      "⚠️ %d elementi non erano disponibili su Spotify al momento del backup e non sono stati salvati\n",
+	"log.availabilityError":        "Errore nella verifica della disponibilità dei brani",
+	"log.addTracksError":           "Errore nell'aggiunta dei brani alla playlist",
+	"log.backupItemsAdded":         "Elementi del backup aggiunti alla playlist",
+	"restore.localFiles":           "⚠️ %d file locali non possono essere ripristinati, aggiungili dall'app desktop di Spotify:\n",
+	"restore.unavailableItems":     "⚠️ %d elementi non erano disponibili su Spotify al momento del backup e non sono stati ripristinati\n",
+	"relink.substituted":           "%s🔁 %d brani non più disponibili sostituiti:\n",
+	"relink.notFound":              "%s⚠️ %d brani non più disponibili e senza sostituti, non aggiunti:\n",
+	"prune.policy":                 "📜 Politica di conservazione:",
+	"prune.keepLast":               "   ↪ Ultimi backup da mantenere: %d\n",
+	"prune.keepDaily":              "   ↪ Giorni con tutti i backup: %d\n",
+	"prune.keepWeekly":             "   ↪ Settimane con un backup a settimana: %d\n",
+	"prune.keepMonthly":            "   ↪ Mesi con un backup al mese: %d\n",
+	"log.pruneDone":                "Pulizia dei backup completata",
+	"prune.nothing":                "🧹 Nessun backup da eliminare, %d mantenuti\n",
+	"prune.willDelete":             "🧹 Verranno eliminati %d backup, %d mantenuti:\n",
+	"prune.deleted":                "🧹 Eliminati %d backup, %d mantenuti:\n",
+	"prune.noPolicy":               "ℹ️ Nessuna politica di conservazione configurata, i backup vengono mantenuti tutti.",
+	"prune.noPolicyHint":           "   Imposta le variabili BACKUP_KEEP_* nel file .env (vedi .env.example)",
+	"prune.confirm":                "\n❓ Vuoi procedere con l'eliminazione? (s/n) ",
+	"answer.yes":                   "s",
+	"common.cancelled":             "🚪 Operazione annullata dall'utente",
+	"restore.newInProgress":        "⏳ Ripristino di '%s' in una nuova playlist in corso...\n",
+	"log.createPlaylistError":      "Errore nella creazione della playlist",
+	"log.coverReadError":           "Impossibile leggere la copertina del backup",
+	"restore.coverReadError":       "⚠️ Impossibile leggere la copertina del backup:",
+	"log.coverUploadError":         "Impossibile caricare la copertina della playlist",
+	"log.coverTooLarge":            "Copertina troppo grande per Spotify non caricata",
+	"restore.coverUploadError":     "⚠️ Impossibile caricare la copertina (se l'errore riguarda i permessi, riautenticati):",
+	"restore.coverTooLarge":        "⚠️ Copertina non caricata: è di %d KB, troppo grande per Spotify (al massimo 256 KB in base64, circa 190 KB)\n",
+	"restore.coverUploaded":        "🖼️ Copertina caricata",
+	"log.restoredNew":              "Playlist ripristinata in una nuova playlist",
+	"restore.newDone":              "✅ Playlist '%s' ripristinata in una nuova playlist (%s)\n",
+
+	// pkg/terminal/commands.go
+	"cmd.archive.usage":           "archive [-user ID] [-date AAAA-MM-GG] [-format tar.gz|zip] [-out file]",
+	"cmd.archive.help":            "Crea un unico archivio compresso con i backup di una data (personali e di altri utenti) e il manifest con conteggi e checksum",
+	"cmd.compare.usage":           "compare [-save common|N] [-name nome] <playlist|file.json> <playlist|file.json> [...]",
+	"cmd.compare.help":            "Confronta due o più playlist (attuali o file di backup): brani in comune, brani solo in ognuna, somiglianza e artisti in comune; con -save crea una nuova playlist con i brani in comune o con quelli solo nella playlist N",
+	"cmd.diff.usage":              "diff <backup.json> [altro_backup.json]",
+	"cmd.diff.help":               "Confronta due backup della stessa playlist, o un backup con la playlist attuale se ne viene indicato solo uno",
+	"cmd.duplicates.usage":        "duplicates [-exact] [-remove] -all|<playlist>",
+	"cmd.duplicates.help":         "Cerca i brani duplicati (stesso brano, stesso ISRC o stesso titolo e artisti con durata simile) in una playlist o in tutte le tue; con -remove tiene solo la prima copia, altrimenti termina con codice 1 se trova duplicati",
+	"cmd.export.usage":            "export [-format m3u8|xspf|jspf|csv] [-out file] <backup.json|playlist>",
+	"cmd.export.help":             "Esporta un backup o una playlist attuale su Spotify in M3U8, XSPF, JSPF o CSV, per aprirla in altri lettori o fogli di calcolo",
+	"cmd.import.usage":            "import [-playlist playlist] [-name nome] [-min-score 0.8] [-dry-run] <file.csv|m3u|m3u8|xspf|jspf>",
+	"cmd.import.help":             "Importa una playlist da file cercando i brani su Spotify (per URI, ISRC o titolo, artisti e durata) in una nuova playlist o in una esistente; termina con codice 1 se alcuni brani non vengono importati",
+	"cmd.keygen.usage":            "keygen [-out file]",
+	"cmd.keygen.help":             "Genera una coppia di chiavi age per cifrare i backup: la chiave privata viene salvata nel file, la pubblica va impostata in BACKUP_RECIPIENTS",
+	"cmd.library.usage":           "library backup|restore <backup.json>",
+	"cmd.library.help":            "Salva i brani che ti piacciono, gli album salvati e gli artisti seguiti, o ripristina uno di questi backup",
+	"cmd.merge.usage":             "merge [-name nome] [-keep-duplicates] [-dry-run] <playlist> <playlist> [playlist...]",
+	"cmd.merge.help":              "Crea una nuova playlist con i brani di più playlist, una sola volta e senza collegarle; di base i brani presenti in più playlist vengono aggiunti una volta sola",
+	"cmd.prune.usage":             "prune [-user ID] [-dry-run] [-keep-last N] [-keep-daily N] [-keep-weekly N] [-keep-monthly N]",
+	"cmd.prune.help":              "Elimina i backup non mantenuti dalla politica di conservazione (di base quella del file .env)",
+	"cmd.search.usage":            "search [-field title|artist|album|isrc] [-fuzzy] [-live|-backups] [-user ID] \"<testo>\"",
+	"cmd.search.help":             "Cerca un brano per titolo, artista, album o ISRC nelle playlist attuali e nei backup (di base in entrambi) e mostra le playlist e le date dei backup che lo contengono; con -fuzzy trova anche i risultati simili",
+	"cmd.smart.usage":             "smart list|sync|preview [-library] [-sources playlist,playlist] \"<query>\"",
+	"cmd.smart.help":              "Mostra o aggiorna le playlist intelligenti salvate in data/smart, o mostra il risultato di una query sui brani che ti piacciono e sulle playlist indicate senza salvarla",
+	"cmd.sort.usage":              "sort -by campo[:desc][,campo...] [-apply] <playlist>",
+	"cmd.sort.help":               "Mostra il nuovo ordine di una tua playlist ordinata per title, artist, album, release_date, added_at, duration o popularity e con -apply lo applica spostando solo i brani necessari; senza -apply termina con codice 1 se l'ordine cambia",
+	"cmd.split.usage":             "split [-by count|decade|initial|month] [-size 100] [-name \"{name} - {part}\"] [-dry-run] <playlist>",
+	"cmd.split.help":              "Divide una playlist in nuove playlist per numero di brani, decennio di uscita, iniziale dell'artista o mese di aggiunta; il nome usa {name} (playlist), {part} (parte) e {n} (numero)",
+	"cmd.stats.usage":             "stats [-top 10] [-format json|csv] [-out file] -library|<playlist> [playlist...]",
+	"cmd.stats.help":              "Mostra le statistiche di una o più playlist (durata, artisti e album più presenti, anni di uscita, brani espliciti, chi li ha aggiunti) o dell'intera libreria, con i brani in comune tra le playlist; con -format le esporta in JSON o CSV",
+	"cmd.store.usage":             "store [-user ID] [-date AAAA-MM-GG] [-out cartella] verify|history <playlist>|checkout|import",
+	"cmd.store.help":              "Gestisce lo store deduplicato dei backup: verifica l'integrità, mostra la cronologia di una playlist, ricostruisce i backup a una data o importa i backup esistenti",
+	"cmd.verify.usage":            "verify [-user ID]",
+	"cmd.verify.help":             "Verifica l'integrità di tutti i backup (file, archivi e store), decifrando quelli cifrati per rilevare eventuali manomissioni",
+	"cmd.where.usage":             "where [-isrc] [-remove] \"<URI|link|testo>\"",
+	"cmd.where.help":              "Mostra tutte le tue playlist (proprie e seguite) che contengono un brano, con le posizioni e la data di aggiunta; con -remove lo rimuove da quelle modificabili. Con un testo viene usato il primo risultato della ricerca su Spotify",
+	"cmd.unknown":                 "Comando sconosciuto: %s\n\n",
+	"log.command":                 "Esecuzione comando",
+	"cmd.available":               "Comandi disponibili:",
+	"cmd.usage":                   "Uso: playlist-manager %s\n\t%s\n",
+	"cmd.authError":               "❌ Errore durante l'autenticazione:",
+	"cmd.backupReadError":         "❌ Impossibile leggere il backup:",
+	"diff.livePlaylist":           "playlist attuale",
+	"diff.differentPlaylists":     "❌ I backup sono di playlist diverse (%s e %s)\n",
+	"log.livePlaylistError":       "Errore nel recupero della playlist attuale",
+	"diff.livePlaylistError":      "❌ Errore nel recupero della playlist attuale:",
+	"flag.prune.user":             "Utente di cui eliminare i backup, di base tutti",
+	"flag.prune.dryRun":           "Mostra i backup che verrebbero eliminati senza eliminarli",
+	"flag.keepLast":               "Numero di backup più recenti da mantenere",
+	"flag.keepDaily":              "Numero di giorni di cui mantenere tutti i backup",
+	"flag.keepWeekly":             "Numero di settimane di cui mantenere un backup a settimana",
+	"flag.keepMonthly":            "Numero di mesi di cui mantenere un backup al mese",
+	"prune.noPolicyCommand":       "❌ Nessuna politica di conservazione configurata, imposta le variabili BACKUP_KEEP_* o i flag -keep-*",
+	"cmd.backupDirError":          "❌ Impossibile leggere la cartella dei backup:",
+	"cmd.pruneError":              "❌ Errore nella pulizia dei backup:",
+	"cmd.userRequired":            "❌ Indica l'utente con il flag -user, utenti trovati:",
+	"flag.store.user":             "Utente di cui usare lo store, di base l'unico presente",
+	"flag.store.date":             "Data dei backup da ricostruire (checkout)",
+	"flag.store.out":              "Cartella in cui ricostruire i backup (checkout), di base data/checkout/<utente>/<data>",
+	"store.verifyError":           "❌ Errore nella verifica dello store:",
+	"log.storeVerified":           "Verifica dello store completata",
+	"store.summary":               "🔎 Store di %s: %d manifest, %d stati salvati\n",
+	"verify.altered":              "   ❌ Contenuto alterato:",
+	"verify.missing":              "   ❌ Stato mancante:",
+	"store.unused":                "   ℹ️ Stato non usato da nessun manifest:",
+	"verify.ok":                   "✅ Nessun problema trovato",
+	"store.historyError":          "❌ Errore nella lettura della cronologia:",
+	"store.noHistory":             "❌ Nessun backup della playlist nello store",
+	"store.history":               "📜 Cronologia di %s:\n",
+	"store.unchanged":             "invariata",
+	"store.first":                 "primo backup",
+	"store.changed":               "modificata",
+	"store.historyLine":           "📆 %s  %s  %s - %d brani (%s)\n",
+	"store.checkoutError":         "❌ Errore nella ricostruzione dei backup:",
+	"log.coverReadPlaylistError":  "Impossibile leggere la copertina della playlist",
+	"store.writeError":            "❌ Errore nella scrittura del backup:",
+	"log.storeCheckout":           "Backup ricostruiti dallo store",
+	"store.checkoutDone":          "✅ Ricostruite %d playlist al %s in %s\n",
+	"store.importError":           "❌ Errore nell'importazione dei backup:",
+	"log.storeImported":           "Backup importati nello store",
+	"store.importDone":            "✅ Importati %d backup nello store, i file originali non sono stati rimossi\n",
+	"flag.archive.user":           "Utente di cui archiviare i backup, di base l'unico presente",
+	"flag.archive.date":           "Data dei backup da archiviare",
+	"flag.archive.format":         "Formato dell'archivio: tar.gz o zip",
+	"flag.archive.out":            "File dell'archivio, di base data/backup/<utente>/<data>.<formato>",
+	"archive.invalidFormat":       "❌ Formato di archivio non valido:",
+	"archive.backupsError":        "❌ Errore nella lettura dei backup:",
+	"archive.noBackups":           "❌ Nessun backup trovato per il %s\n",
+	"archive.createError":         "❌ Errore nella creazione dell'archivio:",
+	"flag.keygen.out":             "File in cui salvare la chiave privata",
+	"keygen.exists":               "❌ Il file %s esiste già, non viene sovrascritto\n",
+	"keygen.error":                "❌ Errore nella generazione delle chiavi:",
+	"keygen.saveError":            "❌ Errore nel salvataggio della chiave privata:",
+	"log.keygen":                  "Chiavi per la cifratura dei backup generate",
+	"keygen.savedIn":              "🔑 Chiave privata salvata in",
+	"keygen.keepIt":               "(conservala, senza non potrai decifrare i backup)",
+	"keygen.public":               "🔑 Chiave pubblica:",
+	"keygen.setEnv":               "ℹ️ Imposta nel file .env:",
+	"flag.verify.user":            "Utente di cui verificare i backup, di base tutti",
+	"verify.error":                "❌ Errore nella verifica dei backup:",
+	"log.verified":                "Verifica dei backup completata",
+	"verify.summary":              "🔎 %s: %d file, %d archivi, %d stati nello store\n",
+	"verify.plain":                "   ℹ️ %d file non cifrati: è stato controllato solo che si possano leggere, le modifiche non possono essere rilevate\n",
+	"verify.okIndented":           "   ✅ Nessun problema trovato",
+	"cmd.translations.usage":      "translations",
+	"cmd.translations.help":       "Verifica che i cataloghi dei messaggi abbiano in tutte le lingue le stesse chiavi e gli stessi segnaposto; termina con codice 1 se manca qualche traduzione",
+	"translations.problems.one":   "🌐 %d problema nei cataloghi dei messaggi\n",
+	"translations.problems.other": "🌐 %d problemi nei cataloghi dei messaggi\n",
+	"translations.ok":             "✅ Cataloghi dei messaggi completi (%s)\n",
+
+	// pkg/terminal/compare.go
+	"compare.title":              "\n🆚 Confronto tra ",
+	"compare.common":             "🤝 Brani in comune a tutte (%d)",
+	"compare.only":               "🎯 Brani solo in '%s' (%d)",
+	"compare.similarity":         "📐 Somiglianza (indice di Jaccard):",
+	"compare.similarityLine":     "   '%s' e '%s': %.1f%%\n",
+	"compare.artists":            "🎤 Artisti in comune a tutte (%d)",
+	"compare.others":             " e altri %d",
+	"compare.othersLine":         "   ... e altri %d\n",
+	"compare.commonName":         "In comune: ",
+	"compare.invalidSet":         "insieme non valido: %s (validi: common o il numero della playlist, da 1 a %d)",
+	"compare.onlyName":           "Solo in ",
+	"compare.nothingToSave":      "❌ Nessun brano da salvare",
+	"compare.description":        "Creata da Playlist Manager confrontando le playlist",
+	"compare.created":            "✅ Playlist '%s' creata con %d brani (%s)\n",
+	"compare.notTracks":          "ℹ️ %d elementi che non sono brani (podcast o file locali) non sono stati copiati\n",
+	"compare.selected":           "\n🆚 Playlist selezionate: %d\n",
+	"compare.addLive":            "🎧 Aggiungi una playlist attuale su Spotify",
+	"compare.addBackup":          "📂 Aggiungi un backup",
+	"compare.compare":            "🆚 Confronta",
+	"compare.menuTitle":          "🆚 Confronto di playlist (%d selezionate)",
+	"compare.selectPlaylist":     "🆚 Seleziona la playlist da confrontare",
+	"common.playlistError":       "❌ Errore nel recupero della playlist:",
+	"compare.saveCommon":         "🤝 I brani in comune a tutte (%d)",
+	"compare.saveOnly":           "🎯 I brani solo in '%s' (%d)",
+	"compare.save":               "💾 Vuoi salvare un insieme in una nuova playlist?",
+	"common.no":                  "🔙 No",
+	"compare.name":               "🎵 Nome della nuova playlist (invio per '%s'): ",
+	"common.createPlaylistError": "❌ Errore nella creazione della playlist:",
+	"log.playlistError":          "Errore nel recupero della playlist",
+	"flag.compare.save":          "Crea una nuova playlist con un insieme: common (brani in comune) o il numero della playlist (brani solo in quella)",
+	"flag.compare.name":          "Nome della nuova playlist creata con -save",
+	"common.readFileError":       "❌ Errore nella lettura di %s: %v\n",
+
+	// pkg/terminal/diff.go
+	"diff.title":        "\n🔍 Differenze di '%s' (%s ➜ %s)\n",
+	"diff.none":         "✅ Nessuna differenza",
+	"diff.added":        "➕ Aggiunti (%d):\n",
+	"diff.removed":      "➖ Rimossi (%d):\n",
+	"diff.moved":        "🔀 Spostati (%d):\n",
+	"diff.selectBackup": "📂 Seleziona il backup da confrontare",
+	"log.diffLive":      "Confronto backup con la playlist attuale",
+	"diff.selectNewer":  "📂 Seleziona il backup più recente con cui confrontarlo",
+	"log.diffDifferent": "Confronto tra backup di playlist diverse",
+	"diff.different":    "⚠️ I due backup sono di playlist diverse",
+	"log.diffDone":      "Confronto completato",
+
+	// pkg/terminal/duplicates.go
+	"duplicates.none":           "✅ Nessun duplicato in '%s'\n",
+	"duplicates.title":          "\n🔁 Duplicati in '%s' (%d gruppi):\n",
+	"duplicates.addedOn":        ", aggiunto il ",
+	"log.duplicatesRemoveError": "Errore nella rimozione dei duplicati",
+	"log.duplicatesRemoved":     "Duplicati rimossi",
+	"duplicates.removed.one":    "✅ Rimosso %d duplicato da '%s'\n",
+	"duplicates.removed.other":  "✅ Rimossi %d duplicati da '%s'\n",
+	"duplicates.positions":      "🔢 Inserisci le posizioni (#) delle copie da rimuovere, separate da spazi o virgole (0 per annullare): ",
+	"duplicates.notDuplicate":   "⚠️ La posizione %d non è tra i duplicati, ignorata\n",
+	"duplicates.where":          "🔁 Dove vuoi cercare i duplicati?",
+	"duplicates.inPlaylist":     "🎧 In una playlist",
+	"duplicates.inAll":          "📁 In tutte le tue playlist",
+	"duplicates.selectPlaylist": "🔁 Seleziona la playlist in cui cercare i duplicati",
+	"duplicates.near":           "❓ Cercare anche i quasi duplicati (stesso ISRC, o stesso titolo e artisti con durata simile)? (s/n) ",
+	"duplicates.searching":      "⏳ Ricerca dei duplicati in '%s'...\n",
+	"common.tracksError":        "❌ Errore nel recupero dei brani:",
+	"duplicates.action":         "🧹 Cosa vuoi fare dei duplicati di '%s'?",
+	"duplicates.keep":           "⏭️ Non rimuovere nulla",
+	"duplicates.removeCopies":   "🗑️ Rimuovi le copie, tenendo la prima di ogni gruppo (%d brani)",
+	"duplicates.choose":         "🔢 Scegli le posizioni da rimuovere",
+	"duplicates.removeError":    "❌ Errore nella rimozione dei duplicati:",
+	"flag.duplicates.all":       "Cerca in tutte le playlist di cui sei proprietario",
+	"flag.duplicates.exact":     "Cerca solo i duplicati esatti (stesso brano), senza quelli con stesso ISRC o stesso titolo e artisti",
+	"flag.duplicates.remove":    "Rimuove le copie, tenendo la prima di ogni gruppo",
+	"common.userError":          "❌ Errore nel recupero dell'utente:",
+	"common.playlistsError":     "❌ Errore nel recupero delle playlist:",
+
+	// pkg/terminal/export.go
+	"export.what":            "📤 Cosa vuoi esportare?",
+	"export.live":            "🎧 Una playlist attuale su Spotify",
+	"export.backup":          "📂 Un backup",
+	"export.selectPlaylists": "📤 Seleziona le playlist da esportare",
+	"export.count":           "%d playlist",
+	"export.fetching":        "⏳ Recupero di '%s' in corso...\n",
+	"flag.export.format":     "Formato: m3u8, xspf, jspf o csv",
+	"flag.export.out":        "File in cui esportare, di base data/export/<nome playlist>_<ID>.<formato>",
+	"export.error":           "❌ Errore nell'esportazione:",
+	"export.errorPlaylist":   "❌ Errore nell'esportazione di '%s':",
+	"log.exported":           "Playlist esportata",
+	"export.done":            "✅ Playlist esportata in:",
+
+	// pkg/terminal/import.go
+	"log.importRead":         "Playlist letta dal file",
+	"import.searching":       "\r🔎 Ricerca dei brani su Spotify: %d/%d",
+	"import.lowScore.one":    "\n⚠️ %d brano trovato con poca sicurezza (meno del %.0f%%):\n",
+	"import.lowScore.other":  "\n⚠️ %d brani trovati con poca sicurezza (meno del %.0f%%):\n",
+	"import.right":           "❓ È il brano giusto? (s/n) ",
+	"import.notFound.one":    "\n❌ %d brano non trovato:\n",
+	"import.notFound.other":  "\n❌ %d brani non trovati:\n",
+	"import.link":            "🔗 Inserisci il link o l'URI Spotify del brano, 0 per saltarlo: ",
+	"import.skipped":         "- brano saltato",
+	"import.path":            "📥 Inserisci il percorso del file da importare (CSV, M3U/M3U8, XSPF o JSPF): ",
+	"log.importFileError":    "Errore nell'importazione del file",
+	"import.fileError":       "❌ Errore nell'importazione del file:",
+	"import.summary":         "📊 '%s': %d brani nel file, %d da importare\n",
+	"import.where":           "📥 Dove vuoi importare i %d brani?",
+	"import.new":             "🆕 In una nuova playlist ('%s')",
+	"import.description":     "Importata da ",
+	"import.selectPlaylist":  "📥 Seleziona la playlist in cui importare i brani",
+	"log.importAddError":     "Errore nell'aggiunta dei brani importati",
+	"common.addTracksError":  "❌ Errore nell'aggiunta dei brani:",
+	"log.imported":           "Brani importati",
+	"import.done":            "✅ Importati %d brani su %d in '%s'\n",
+	"flag.import.playlist":   "Link, URI o ID della playlist in cui aggiungere i brani, di base ne viene creata una nuova",
+	"flag.import.name":       "Nome della nuova playlist, di base il titolo della playlist nel file o il nome del file",
+	"flag.import.minScore":   "Sicurezza minima (da 0 a 1) per importare un brano trovato tramite ricerca",
+	"flag.import.dryRun":     "Mostra i brani trovati senza importarli",
+	"import.lowScoreSkipped": "⚠️ Poca sicurezza, non importato: ",
+	"import.notFoundItem":    "❌ Non trovato: ",
+	"import.summaryCommand":  "📊 '%s': %d brani nel file, %d da importare, %d con poca sicurezza, %d non trovati\n",
+	"import.doneCommand":     "✅ Importati %d brani nella playlist %s\n",
+
+	// pkg/terminal/library.go
+	"library.saving":          "💾 Salvataggio di '%s' in corso...\n",
+	"log.libraryError":        "Errore nel recupero della libreria",
+	"library.permissions":     "ℹ️ Se l'errore riguarda i permessi, riautenticati per concedere l'accesso alla libreria",
+	"log.librarySaveError":    "Errore nel salvataggio della libreria",
+	"log.librarySaved":        "Libreria salvata",
+	"library.unchanged":       "☑️ '%s' invariati, l'ultimo backup è già in %s\n",
+	"library.saved":           "✅ '%s' salvati (%d elementi) in %s\n",
+	"library.restoring":       "⏳ Ripristino di '%s' (%d elementi) in corso...\n",
+	"library.added":           "\r📥 Aggiunti %d/%d",
+	"log.libraryRestoreError": "Errore nel ripristino della libreria",
+	"library.restored":        "✅ '%s' ripristinati: %d aggiunti, %d già presenti\n",
+	"library.notLibrary":      "❌ '%s' non è un backup della libreria\n",
+	"common.error":            "❌ Errore:",
+
+	// pkg/terminal/linked.go
+	"log.trackDetailsError":       "Errore nel recupero dettagli tracce dall'API Spotify",
+	"linked.noInfo":               "Informazioni non disponibili",
+	"linked.unknownArtist":        "Artista sconosciuto",
+	"track.by":                    " di ",
+	"linked.menu.show":            "👁️ Visualizza le playlist collegate",
+	"linked.menu.add":             "➕ Aggiungi una playlist collegata",
+	"linked.menu.remove":          "🗑️ Rimuovi una playlist collegata",
+	"linked.menu.sync":            "🔄 Aggiorna le canzoni nelle playlist collegate",
+	"linked.menu.title":           "🔗 Menù Playlist Collegate",
+	"linked.backToMain":           "🔙 Torna al menu principale",
+	"linked.returning":            "🔙 Ritorno al menu principale...",
+	"linked.listTitle":            "🔗 -> Lista delle Playlist Collegate <- 🔗",
+	"linked.noneAddOne":           "🕵️ Nessuna playlist collegata, aggiungine una!",
+	"linked.file":                 "   📄 File: %s\n",
+	"linked.origin":               "   📥 Origine:",
+	"linked.destination":          "   🎯 Destinazione:",
+	"linked.addTitle":             "➕ -> Aggiunta Nuova Playlist Collegata <- ➕",
+	"linked.name":                 "🎵 Che nome vuoi dare al collegamento tra le playlist? ",
+	"linked.selectOrigin":         "🎯 Seleziona le playlist da aggiungere come origine",
+	"linked.anotherOrigin":        "Vuoi aggiungere un'altra playlist come origine? (s/n) ",
+	"linked.selectDestination":    "🎯 Seleziona le playlist da aggiungere come destinazione",
+	"linked.anotherDestination":   "❓ Vuoi aggiungere un'altra playlist come destinazione? (s/n) ",
+	"linked.removeTitle":          "🗑️ -> Rimozione Playlist Collegata <- 🗑️",
+	"linked.none":                 "❌ Nessuna playlist collegata, aggiungine una!",
+	"linked.selectRemove":         "🎯 -> Seleziona le playlist collegate da rimuovere <- 🎯",
+	"linked.cancelBack":           "🚪 0. Annulla e torna indietro",
+	"linked.removeSelection":      "⏎ Inserisci le playlist collegate da rimuovere: ",
+	"linked.removeConfirm":        "❓ Rimuovere %d playlist collegate? (s/n) ",
+	"log.syncStart":               "Inizio aggiornamento playlist collegate",
+	"log.linkedDirError":          "Errore lettura directory playlist",
+	"log.linkedFound":             "Playlist collegate trovate",
+	"linked.syncTitle":            "🔄 -> Aggiornamento Playlist Collegate <- 🔄",
+	"log.linkedNone":              "Nessuna playlist collegata trovata",
+	"linked.syncType":             "🎯 Che tipo di aggiornamento vuoi eseguire?",
+	"linked.cancelBackShort":      "🚪 Annulla e torna indietro",
+	"linked.syncAdd":              "➕ Solo aggiunta canzoni (da origine a destinazione)",
+	"linked.syncRemove":           "🗑️ Solo rimozione canzoni (dalle destinazioni)",
+	"linked.syncBoth":             "🔄 Sia aggiungere che rimuovere canzoni",
+	"log.syncType":                "Tipo di operazione selezionata",
+	"log.linkedLoading":           "Caricamento playlist collegata",
+	"log.linkedReadError":         "Errore lettura file playlist",
+	"log.linkedParseError":        "Errore parsing JSON playlist",
+	"log.linkedLoaded":            "Playlist collegata caricata",
+	"linked.progress":             "Aggiornamento",
+	"linked.playlist":             "│ 🎧 Playlist: %s (%s)\n",
+	"linked.processing":           "│ ⏳ Elaborazione in corso...\n",
+	"log.originStart":             "Inizio recupero tracce da playlist origine",
+	"log.originFetch":             "Recupero tracce da playlist origine",
+	"log.originError":             "Errore nel recupero tracce da playlist origine",
+	"log.originFetched":           "Tracce recuperate da playlist origine",
+	"log.originTotal":             "Totale tracce origine recuperate",
+	"log.destinationStart":        "Inizio processamento playlist destinazione",
+	"log.destinationError":        "Errore nel recupero tracce da playlist destinazione",
+	"log.destinationFetched":      "Tracce recuperate da playlist destinazione",
+	"log.toAdd":                   "Tracce da aggiungere identificate",
+	"log.availabilityTracksError": "Errore nella verifica della disponibilità delle tracce",
+	"log.availabilityChecked":     "Disponibilità delle tracce verificata",
+	"linked.nothingToAdd":         "│ ❌ Nessuna canzone da aggiungere a %s\n",
+	"log.nothingToAdd":            "Nessuna traccia da aggiungere",
+	"log.addStart":                "Inizio aggiunta tracce alla playlist",
+	"log.addError":                "ERRORE nell'aggiunta tracce alla playlist",
+	"log.added":                   "Tracce aggiunte con successo",
+	"linked.addedList":            "│     🎵 Canzoni aggiunte:\n",
+	"log.addedDetailsError":       "Errore nel recupero dettagli tracce aggiunte",
+	"log.addSkipped":              "Aggiunta canzoni saltata per scelta utente",
+	"log.toRemove":                "Tracce da rimuovere identificate",
+	"linked.nothingToRemove":      "│ ❌ Nessuna canzone da rimuovere da %s\n",
+	"log.nothingToRemove":         "Nessuna traccia da rimuovere",
+	"log.removeStart":             "Inizio rimozione tracce dalla playlist",
+	"log.removeError":             "ERRORE nella rimozione tracce dalla playlist",
+	"log.removed":                 "Tracce rimosse con successo",
+	"linked.removedList":          "│    🎵 Canzoni rimosse:\n",
+	"log.removedDetailsError":     "Errore nel recupero dettagli tracce rimosse",
+	"log.removeSkipped":           "Rimozione canzoni saltata per scelta utente",
+	"linked.completed":            "║               ✅ AGGIORNAMENTO COMPLETATO ✅               ║",
+	"linked.allUpdated":           "║     Tutte le playlist collegate sono state aggiornate!     ║",
+	"linked.saved":                "Playlist %s salvata come data/playlists/%s.json\n",
+	"linked.removedFile":          "✅ Playlist data/playlists/%s rimossa con successo\n",
+	"linked.adding.one":           "│ ⏳ Aggiunta di %d canzone a %s...\n",
+	"linked.adding.other":         "│ ⏳ Aggiunta di %d canzoni a %s...\n",
+	"linked.addedSongs.one":       "│ ✅ Aggiunta %d canzone a %s\n",
+	"linked.addedSongs.other":     "│ ✅ Aggiunte %d canzoni a %s\n",
+	"linked.removing.one":         "│ ⏳ Rimozione di %d canzone da %s...\n",
+	"linked.removing.other":       "│ ⏳ Rimozione di %d canzoni da %s...\n",
+	"linked.removedSongs.one":     "│ ✅ Rimossa %d canzone da %s\n",
+	"linked.removedSongs.other":   "│ ✅ Rimosse %d canzoni da %s\n",
+
+	// pkg/terminal/offline.go
+	"log.startOffline":       "Avvio di Playlist Manager in modalità offline",
+	"offline.menu.dates":     "📅 Visualizza le date dei backup",
+	"offline.menu.tracks":    "🎵 Visualizza i brani di una playlist salvata",
+	"offline.menu.search":    "🔍 Cerca un brano nei backup",
+	"offline.menu.export":    "📤 Esporta una playlist salvata",
+	"offline.menu.diff":      "🔀 Confronta due backup",
+	"offline.menu.title":     "🏠 Menù Offline",
+	"offline.mode":           "📴 Modalità offline (solo backup)",
+	"log.offlineDates":       "L'utente ha richiesto la lista delle date dei backup",
+	"log.offlineTracks":      "L'utente ha richiesto la visualizzazione di una playlist salvata",
+	"log.offlineSearch":      "L'utente ha richiesto la ricerca nei backup",
+	"log.offlineExport":      "L'utente ha richiesto l'esportazione di una playlist salvata",
+	"log.offlineDiff":        "L'utente ha richiesto il confronto di due backup",
+	"log.backupDirError":     "Errore nella lettura della cartella dei backup",
+	"log.offlineNoBackups":   "Nessun backup trovato per la modalità offline",
+	"offline.noBackupsIn":    "❌ Nessun backup trovato in ",
+	"offline.whichUser":      "👤 Di quale utente vuoi consultare i backup?",
+	"offline.personal":       "\n👤 Backup delle tue playlist:",
+	"offline.others":         "\n👥 Backup delle playlist di altri utenti:",
+	"offline.noBackups":      "❌ Nessun backup trovato",
+	"offline.dateLine.one":   "📆 %s - %d playlist\n",
+	"offline.dateLine.other": "📆 %s - %d playlist\n",
+	"offline.format":         "📤 In che formato vuoi esportare %s?",
+
+	// pkg/terminal/search.go
+	"search.what":            "🔍 Cosa vuoi cercare (titolo, artista, album, ISRC o ID)? ",
+	"search.where":           "📑 Dove vuoi cercare?",
+	"search.allFields":       "🔎 In tutti i campi",
+	"search.title":           "🎵 Nel titolo",
+	"search.artists":         "🎤 Negli artisti",
+	"search.album":           "💿 Nell'album",
+	"search.isrc":            "🏷️ Nell'ISRC",
+	"search.fuzzy":           "❓ Trovare anche i risultati simili (es. con errori di battitura)? (s/n) ",
+	"search.progress":        "Ricerca nelle playlist",
+	"search.noResults":       "\n❌ Nessun risultato per '%s'\n",
+	"search.results.one":     "\n🔍 Risultati per '%s' in %d playlist:\n",
+	"search.results.other":   "\n🔍 Risultati per '%s' in %d playlist:\n",
+	"search.current":         "attuale",
+	"search.backupOf":        "backup del ",
+	"search.backups":         "%d backup dal %s al %s",
+	"search.similar":         " (simile al %.0f%%)",
+	"log.searchBackupsError": "Errore nella ricerca nei backup",
+	"log.searchBackupsDone":  "Ricerca nei backup completata",
+	"search.source":          "📍 Dove vuoi cercare?",
+	"search.live":            "🎧 Nelle playlist attuali su Spotify",
+	"search.inBackups":       "📂 Nei backup",
+	"search.both":            "🔄 In entrambi",
+	"search.playlistsError":  "❌ Errore nella ricerca nelle playlist:",
+	"log.searchDone":         "Ricerca completata",
+	"flag.search.field":      "Campo in cui cercare: title, artist, album o isrc (di base tutti)",
+	"flag.search.fuzzy":      "Trova anche i risultati simili, ad esempio con errori di battitura",
+	"flag.search.live":       "Cerca solo nelle playlist attuali su Spotify",
+	"flag.search.backups":    "Cerca solo nei backup, senza accedere a Spotify",
+	"flag.search.user":       "Utente di cui cercare nei backup, di base l'unico presente",
+	"search.backupsError":    "❌ Errore nella ricerca nei backup:",
+
+	// pkg/terminal/smart.go
+	"smart.file":              "   📄 File: %s.json\n",
+	"smart.likedSongs":        "      ↪ Brani che ti piacciono",
+	"smart.destination":       "   🎯 Destinazione: %s\n",
+	"log.likedSongsError":     "Errore nel recupero dei brani che ti piacciono",
+	"log.originPlaylistError": "Errore nel recupero della playlist di origine",
+	"log.smartSynced":         "Playlist intelligente aggiornata",
+	"log.smartReadError":      "Errore nella lettura delle playlist intelligenti",
+	"smart.none":              "❌ Nessuna playlist intelligente, aggiungine una!",
+	"smart.syncTitle":         "🧠 Aggiornamento delle playlist intelligenti",
+	"log.smartSyncError":      "Errore nell'aggiornamento della playlist intelligente",
+	"smart.syncError":         "❌ Errore nell'aggiornamento di '%s': %v",
+	"smart.synced":            "✅ '%s' aggiornata con %d brani",
+	"smart.upToDate":          "✅ '%s' già aggiornata (%d brani)",
+	"smart.fields":            "ℹ️ Campi: ",
+	"smart.operators":         "ℹ️ Operatori: campo:valore (contiene), = != < <= > >=, AND, OR, NOT, parentesi; ORDER BY campo [ASC|DESC], ... e LIMIT n alla fine",
+	"smart.example":           "ℹ️ Esempio: artist:\"Daft Punk\" AND year>=2015 AND NOT explicit ORDER BY added_at DESC LIMIT 50",
+	"smart.addTitle":          "➕ -> Aggiunta Nuova Playlist Intelligente <- ➕",
+	"smart.name":              "🎵 Che nome vuoi dare alla playlist intelligente? ",
+	"smart.query":             "🔎 Query (invio per annullare): ",
+	"smart.library":           "❓ Cercare nei brani che ti piacciono? (s/n) ",
+	"smart.selectOrigin":      "📥 Seleziona una playlist di origine (0 per terminare la selezione)",
+	"smart.noOrigin":          "❌ Scegli almeno un'origine: i brani che ti piacciono o una playlist",
+	"smart.where":             "🎯 Dove vuoi salvare il risultato? Il contenuto della playlist verrà sostituito a ogni aggiornamento",
+	"smart.description":       "Playlist intelligente: ",
+	"smart.selectDestination": "🎯 Seleziona la playlist di destinazione",
+	"smart.notOwner":          "❌ Puoi usare come destinazione solo le playlist di cui sei proprietario",
+	"smart.destIsSource":      "❌ La destinazione non può essere anche una playlist di origine",
+	"smart.replaceConfirm":    "⚠️ '%s' contiene %d brani, che verranno sostituiti dal risultato della query. Continuare? (s/n) ",
+	"smart.savedPrefix":       "✅ Playlist intelligente ",
+	"smart.savedAs":           " salvata come ",
+	"smart.firstSync":         "⏳ Primo aggiornamento in corso...",
+	"smart.syncErrorShort":    "❌ Errore nell'aggiornamento:",
+	"smart.syncedLine":        "✅ '%s' aggiornata con %d brani\n",
+	"smart.removeWhich":       "🗑️ Quale playlist intelligente vuoi rimuovere?",
+	"smart.removed":           "✅ Playlist intelligente %s rimossa, la playlist '%s' su Spotify non viene modificata\n",
+	"smart.menu.title":        "🧠 Playlist intelligenti",
+	"smart.menu.show":         "👁️ Visualizza le playlist intelligenti",
+	"smart.menu.add":          "➕ Aggiungi una playlist intelligente",
+	"smart.menu.remove":       "🗑️ Rimuovi una playlist intelligente",
+	"smart.menu.sync":         "🔄 Aggiorna le playlist intelligenti",
+	"smart.noneAddOne":        "🕵️ Nessuna playlist intelligente, aggiungine una!",
+	"flag.smart.library":      "Con preview: cerca nei brani che ti piacciono",
+	"flag.smart.sources":      "Con preview: link, URI o ID delle playlist in cui cercare, separati da virgole",
+	"smart.readError":         "❌ Errore nella lettura delle playlist intelligenti:",
+	"smart.syncErrorLine":     "❌ Errore nell'aggiornamento di '%s': %v\n",
+	"smart.syncedCommand":     "✅ %s: %d brani (aggiornata: %t)\n",
+	"smart.originRequired":    "❌ Indica almeno un'origine con -library o -sources",
+	"smart.queryError":        "❌ Errore nella valutazione della query:",
+	"smart.destinationSource": "la destinazione '%s' è anche una playlist di origine",
+	"smart.count":             "📊 %d brani\n",
+
+	// pkg/terminal/sort.go
+	"sort.title":          "\n🔃 Nuovo ordine di '%s' (%s):\n",
+	"sort.unavailable":    "Elemento non disponibile su Spotify",
+	"sort.alreadySorted":  "\n✅ La playlist è già in questo ordine",
+	"sort.moves":          "\n📊 Servono %d spostamenti per applicare il nuovo ordine\n",
+	"log.sortApplyError":  "Errore nel riordinamento della playlist",
+	"log.sorted":          "Playlist riordinata",
+	"sort.done":           "✅ Playlist '%s' riordinata con %d spostamenti\n",
+	"sort.selectPlaylist": "🔃 Seleziona la playlist da ordinare",
+	"sort.notOwner":       "❌ Puoi ordinare solo le playlist di cui sei proprietario",
+	"sort.fields":         "\n🔃 Campi di ordinamento disponibili:",
+	"sort.fieldsHint":     "ℹ️ Separa più campi con una virgola, il primo è il più importante; aggiungi :desc per l'ordine decrescente",
+	"sort.by":             "❓ Ordina per (ad esempio artist,release_date:desc): ",
+	"sort.apply":          "\n❓ Applicare il nuovo ordine? (s/n) ",
+	"sort.cancelled":      "🚪 Ordinamento annullato",
+	"sort.error":          "❌ Errore nel riordinamento:",
+	"flag.sort.by":        "Campi di ordinamento separati da virgole, con :desc per l'ordine decrescente: ",
+	"flag.sort.apply":     "Applica il nuovo ordine alla playlist, di base viene solo mostrato",
+
+	// pkg/terminal/split.go
+	"log.playlistCreated":       "Playlist creata",
+	"split.preview":             "\n✂️ '%s' (%d brani) verrà divisa in %d playlist:\n",
+	"split.part":                "🎵 %d. %s (%d brani)\n",
+	"split.description":         "Parte di ",
+	"split.created":             "✅ Creata '%s' con %d brani (%s)\n",
+	"log.splitDone":             "Playlist divisa",
+	"split.selectPlaylist":      "✂️ Seleziona la playlist da dividere",
+	"split.how":                 "✂️ Come vuoi dividere la playlist?",
+	"split.byCount":             "🔢 Per numero di brani",
+	"split.byDecade":            "📅 Per decennio di uscita",
+	"split.byInitial":           "🔤 Per iniziale dell'artista",
+	"split.byMonth":             "🗓️ Per mese di aggiunta",
+	"split.size":                "❓ Quanti brani per playlist? ",
+	"split.name":                "❓ Nome delle nuove playlist ({name} nome della playlist, {part} parte, {n} numero), invio per '%s': ",
+	"split.notTracks":           "ℹ️ %d elementi che non sono brani (podcast, file locali o non disponibili) non verranno copiati\n",
+	"split.confirm":             "\n❓ Creare le playlist? (s/n) ",
+	"split.cancelled":           "🚪 Divisione annullata",
+	"split.createError":         "❌ Errore nella creazione delle playlist:",
+	"merge.selectPlaylists":     "🔀 Seleziona le playlist da unire",
+	"merge.atLeastTwo":          "❌ Seleziona almeno due playlist da unire",
+	"merge.name":                "❓ Nome della nuova playlist: ",
+	"merge.skipDuplicates":      "❓ Saltare i brani presenti in più playlist? (s/n) ",
+	"merge.summary":             "\n🔀 '%s': %d brani da %d playlist",
+	"merge.skipped":             ", %d duplicati saltati",
+	"merge.notTracks":           "ℹ️ %d elementi che non sono brani (podcast, file locali o non disponibili) non sono stati copiati\n",
+	"merge.description":         "Unione di ",
+	"log.merged":                "Playlist unite",
+	"merge.created":             "✅ Creata '%s' (%s)\n",
+	"flag.split.by":             "Criterio: ",
+	"flag.split.size":           "Numero di brani per playlist, con -by count",
+	"flag.split.name":           "Nome delle nuove playlist: {name} nome della playlist, {part} parte, {n} numero",
+	"flag.split.dryRun":         "Mostra le playlist che verrebbero create senza crearle",
+	"flag.merge.name":           "Nome della nuova playlist, di base i nomi delle playlist unite",
+	"flag.merge.keepDuplicates": "Mantiene i brani presenti in più playlist, di base viene tenuta solo la prima copia",
+	"flag.merge.dryRun":         "Mostra il risultato senza creare la playlist",
+	"split.what":                "✂️ Cosa vuoi fare?",
+	"split.menu.split":          "✂️ Dividi una playlist in più playlist",
+	"split.menu.merge":          "🔀 Unisci più playlist in una nuova playlist",
+
+	// pkg/terminal/stats.go
+	"stats.title":             "\n📊 Statistiche di '%s'\n",
+	"stats.items":             "🎵 Elementi: %d (brani %d, episodi %d, file locali %d)\n",
+	"stats.unavailable":       "⚠️ Non disponibili su Spotify: %d\n",
+	"stats.duration":          "⏱️ Durata totale: %s\n",
+	"stats.explicit":          "🔞 Espliciti: %d (%.1f%%)\n",
+	"stats.artists":           "🎤 Artisti più presenti",
+	"stats.albums":            "💿 Album più presenti",
+	"stats.years":             "📅 Anno di uscita:",
+	"stats.you":               " (tu)",
+	"stats.addedBy":           "👥 Aggiunti da",
+	"stats.overlap":           "\n🔀 Playlist con più brani in comune",
+	"stats.noOverlap":         "✅ Nessun brano in comune tra le playlist",
+	"stats.morePairs":         "... e altre %d coppie (tutte nel report esportato)\n",
+	"stats.pair":              "%d. '%s' e '%s': %d in comune (%.0f%% della più piccola)\n",
+	"stats.fetchingLiked":     "⏳ Recupero dei brani che ti piacciono in corso...",
+	"stats.progress":          "Recupero delle playlist",
+	"stats.library":           "Libreria",
+	"stats.playlists":         "\n📋 Playlist:",
+	"stats.playlistLine":      "   🎵 %s: %d elementi, %s\n",
+	"stats.export":            "📤 Vuoi esportare le statistiche?",
+	"log.statsExportError":    "Errore nell'esportazione delle statistiche",
+	"stats.exportError":       "❌ Errore nell'esportazione delle statistiche:",
+	"log.statsExported":       "Statistiche esportate",
+	"stats.exported":          "✅ Statistiche esportate in ",
+	"stats.what":              "📊 Di cosa vuoi vedere le statistiche?",
+	"stats.wholeLibrary":      "📚 L'intera libreria (brani che ti piacciono e tutte le playlist)",
+	"stats.selectPlaylist":    "📊 Seleziona la playlist",
+	"stats.libraryError":      "❌ Errore nel recupero della libreria:",
+	"flag.stats.library":      "Statistiche dell'intera libreria: brani che ti piacciono e tutte le playlist",
+	"flag.stats.top":          "Numero di artisti e album più presenti da mostrare (0 per tutti)",
+	"flag.stats.format":       "Esporta le statistiche invece di mostrarle: json o csv",
+	"flag.stats.out":          "File in cui esportare, di base data/export/statistiche-<data>.<formato>",
+	"stats.unsupportedFormat": "❌ Formato non supportato: %s (validi: %s, %s)\n",
+
+	// pkg/terminal/where.go
+	"where.which":          "🎵 Quale brano intendi?",
+	"where.reading":        "\r⏳ Lettura delle playlist: %d/%d",
+	"log.whereDone":        "Ricerca del brano nelle playlist completata",
+	"where.none":           "❌ '%s' non è in nessuna delle tue playlist\n",
+	"where.found.one":      "\n📍 '%s' è in %d playlist:\n",
+	"where.found.other":    "\n📍 '%s' è in %d playlist:\n",
+	"where.sameISRC":       " (stesso ISRC)",
+	"where.notEditable":    "🔒 '%s' non è modificabile, saltata\n",
+	"log.whereRemoveError": "Errore nella rimozione del brano",
+	"log.whereRemoved":     "Brano rimosso dalla playlist",
+	"where.removed":        "✅ Rimosso da '%s' (%d)\n",
+	"where.playlists":      "🔢 Inserisci le playlist (0 per annullare): ",
+	"where.track":          "🎵 Inserisci l'URI, il link Spotify o il titolo e l'artista del brano: ",
+	"log.whereSearchError": "Errore nella ricerca del brano",
+	"where.searchError":    "❌ Errore nella ricerca del brano:",
+	"where.notFound":       "❌ Nessun brano trovato",
+	"where.isrc":           "❓ Cercare anche le altre versioni con lo stesso ISRC? (s/n) ",
+	"where.readError":      "❌ Errore nella lettura delle playlist:",
+	"where.remove":         "🗑️ Vuoi rimuovere il brano?",
+	"where.fromAll":        "📁 Da tutte le playlist modificabili (%d)",
+	"where.choose":         "🔢 Scegli le playlist",
+	"where.removeError":    "❌ Errore nella rimozione:",
+	"flag.where.isrc":      "Trova anche le altre versioni del brano con lo stesso ISRC",
+	"flag.where.remove":    "Rimuove il brano da tutte le playlist modificabili (tue o collaborative), il brano va indicato come URI, link o ID",
+	"where.noTrack":        "nessun brano trovato",
+	"where.linkOnly":       "❌ Con -remove il brano va indicato come URI, link o ID, non come testo da cercare",
+
+	// internal/backup/archive.go
+	"archive.unsupported": "formato di archivio non supportato: %s",
+	"archive.notFound":    "%s non trovato nell'archivio",
+	"archive.missingFile": "file %s mancante nell'archivio",
+	"archive.badChecksum": "checksum non valido per il file %s, l'archivio potrebbe essere danneggiato",
+
+	// internal/backup/backup.go
+	"log.backupModeInvalid":   "Modalità di backup non valida, verranno usati i file",
+	"log.backupMode":          "Modalità di backup impostata",
+	"log.backupFileReadError": "Impossibile leggere il file di backup",
+
+	// internal/backup/crypto.go
+	"crypto.openIdentity":     "apertura del file delle chiavi private: %w",
+	"crypto.readIdentity":     "lettura del file delle chiavi private: %w",
+	"crypto.invalidRecipient": "chiave pubblica non valida %s: %w",
+	"log.encryption":          "Cifratura dei backup impostata",
+	"crypto.noKey":            "backup cifrato ma nessuna chiave impostata (BACKUP_PASSPHRASE o BACKUP_IDENTITY_FILE)",
+	"crypto.decryptError":     "impossibile decifrare il backup, la chiave è errata o il file è stato alterato: %w",
+	"crypto.altered":          "il backup cifrato è stato alterato: %w",
+	"crypto.readKey":          "lettura della chiave dei backup %s: %w",
+	"crypto.wrongPassphrase":  "impossibile decifrare la chiave dei backup %s, la password (BACKUP_PASSPHRASE) è errata o il file è stato alterato: %w",
+	"log.backupKeyCreated":    "Chiave dei backup creata e cifrata con la password",
+
+	// internal/backup/library.go
+	"library.likedSongs":      "Brani che ti piacciono",
+	"library.savedAlbums":     "Album salvati",
+	"library.followedArtists": "Artisti seguiti",
+
+	// internal/backup/retention.go
+	"log.retentionDirSkipped":      "Cartella di backup ignorata, il nome non è una data",
+	"log.retentionManifestSkipped": "Manifest ignorato, il nome non è una data",
+	"log.retentionVersionRemoved":  "Versione rimossa dallo store dalla politica di conservazione",
+	"log.retentionBackupRemoved":   "Backup rimosso dalla politica di conservazione",
+	"log.retentionDirRemoved":      "Cartella di backup vuota rimossa",
+
+	// internal/backup/search.go
+	"search.invalidField": "campo di ricerca non valido: %s (validi: %s)",
+
+	// internal/backup/sort.go
+	"sort.invalidField": "campo di ordinamento non valido: %s (validi: %s)",
+	"sort.invalidOrder": "ordine non valido per %s: %s (validi: asc, desc)",
+	"sort.noField":      "nessun campo di ordinamento indicato",
+
+	// internal/backup/split.go
+	"split.invalidSize":      "numero di brani per parte non valido: %d",
+	"split.invalidCriterion": "criterio di divisione non valido: %s (validi: %s)",
+	"backup.unknown":         "sconosciuto",
+
+	// internal/backup/store.go
+	"store.readState":         "lettura dello stato %s di %s: %w",
+	"log.storeStateReadError": "Impossibile leggere lo stato della playlist dallo store",
+	"store.readManifest":      "lettura del manifest %s: %w",
+	"log.storeDecryptError":   "Impossibile decifrare lo stato dello store",
+	"store.invalidHash":       "hash non valido: %q",
+	"log.storeStateRemoved":   "Stato non più usato rimosso dallo store",
+
+	// internal/backup/duplicates.go
+	"duplicates.sameTrack": "stesso brano",
+	"duplicates.sameISRC":  "stesso ISRC",
+	"duplicates.sameName":  "stesso titolo e artisti",
+
+	// internal/spotify/match.go
+	"match.uri":       "uri",
+	"match.isrc":      "isrc",
+	"match.search":    "ricerca",
+	"log.matchNoISRC": "Nessun brano trovato per ISRC",
+
+	// internal/spotify/relink.go
+	"relink.playable":    "riproducibile",
+	"relink.relinked":    "ricollegato",
+	"relink.isrc":        "isrc",
+	"relink.unavailable": "non disponibile",
+	"log.relinked":       "Brano non disponibile sostituito",
+	"log.relinkNone":     "Brano non disponibile e nessun sostituto trovato",
+
+	// internal/link/link.go
+	"link.track":       "brano",
+	"link.episode":     "episodio",
+	"link.playlist":    "playlist",
+	"link.album":       "album",
+	"link.artist":      "artista",
+	"link.show":        "podcast",
+	"link.user":        "utente",
+	"link.notLink":     "'%s' non è un link, un URI o un ID Spotify",
+	"link.wrongKind":   "'%s' è di tipo %s, non %s",
+	"link.invalidURI":  "URI Spotify non valido: %s",
+	"link.invalidLink": "link Spotify non valido: %s",
+	"link.invalid":     "'%s' non è un link o un URI Spotify valido",
+
+	// internal/query/parser.go
+	"query.unclosedQuote":     "query non valida: virgolette non chiuse alla posizione %d",
+	"query.bang":              "query non valida: operatore ! alla posizione %d, usa != o NOT",
+	"query.invalidAt":         "query non valida alla posizione %d: %s",
+	"query.missingParen":      "manca la parentesi chiusa",
+	"query.missingOperator":   "query non valida alla posizione %d: manca l'operatore dopo '%s' (ad esempio %s:valore)",
+	"query.invalidAtWrap":     "query non valida alla posizione %d: %w",
+	"query.missingValue":      "manca il valore di %s",
+	"query.missingCondition":  "manca una condizione alla fine della query",
+	"query.expectedCondition": "condizione attesa invece di '%s'",
+
+	// internal/query/query.go
+	"query.missingBy":        "manca BY dopo ORDER",
+	"query.missingSortField": "manca il campo di ordinamento",
+	"query.limit":            "LIMIT deve essere seguito da un numero positivo",
+	"query.unexpected":       "testo inatteso: %s",
+	"query.textOperators":    "il campo %s si può confrontare solo con :, = e !=",
+	"query.numberRequired":   "il campo %s richiede un numero, non '%s'",
+	"query.boolOperators":    "il campo %s si può confrontare solo con true o false",
+	"query.invalidField":     "campo non valido: %s (validi: %s)",
+
+	// internal/selection/selection.go
+	"selection.none":       "nessun elemento selezionato con '%s'",
+	"selection.noneMine":   "nessun elemento in elenco è tuo",
+	"selection.outOfRange": "'%s' è fuori dall'elenco (da 1 a %d)",
+	"selection.noMatch":    "nessun elemento corrisponde a '%s'",
+
+	// internal/export/export.go
+	"export.unsupported": "formato di esportazione non supportato: %s",
+
+	// internal/export/import.go
+	"import.unsupported": "formato di importazione non supportato: %s",
+	"import.noColumn":    "il file CSV non ha una colonna con il titolo, l'URI o l'ISRC dei brani",
+
+	// internal/export/stats.go
+	"stats.unsupportedReport": "formato del report non supportato: %s (validi: %s, %s)",
+
+	// internal/config/env.go
+	"config.loadError":      "Errore durante il caricamento del file .env. Controlla che sia presente nella stessa cartella dell'eseguibile e che contenga le informazioni necessarie come da documentazione.",
+	"config.pressEnterExit": "Premi invio per uscire...",
+	"config.invalidValue":   "Valore non valido per %s: %s, verrà usato %d\n",
+
+	// internal/spotify/library.go
+	"library.invalidKind":      "tipo di libreria non valido: %s",
+	"library.notLibraryBackup": "il backup '%s' non è un backup della libreria",
+	"log.libraryRestored":      "Libreria ripristinata",
+
+	// internal/spotify/spotify.go
+	"log.dirBackupCreated":        "Cartella data/backup creata",
+	"log.dirAuthCreated":          "Cartella data/auth creata",
+	"log.dirPlaylistsCreated":     "Cartella data/playlists creata",
+	"log.authVarsError":           "Inizializzazione Spotify: errore nel recupero delle variabili per l'autenticazione",
+	"log.authVarsMissing":         "File data/auth/auth.yaml non esistente. Verranno generate nuove variabili per l'autenticazione.",
+	"log.authVarsRead":            "Variabili per l'autenticazione lette da data/auth/auth.yaml",
+	"log.authVarsSaved":           "Variabili per l'autenticazione generate e salvate in data/auth/auth.yaml",
+	"log.authServerClosed":        "Server per l'autenticazione chiuso con successo",
+	"log.authServerCloseError":    "[Spotify] Chiusura server",
+	"log.tokenExpired":            "Token per l'autenticazione scaduto. Verrà rieffettuata l'autenticazione.",
+	"log.tokenRefreshError":       "Errore nel refresh del token per l'autenticazione: ",
+	"log.authURL":                 "URL per l'autenticazione generata",
+	"auth.openURL":                "Apri questo URL per autenticarti: ",
+	"auth.noURL":                  "Errore: URL per l'autenticazione non disponibile",
+	"auth.noURLError":             "URL di autenticazione non disponibile",
+	"log.authServerStart":         "Avvio il server per l'autenticazione...",
+	"log.authCompleted":           "Autenticazione completata",
+	"log.authServerShutdownError": "Errore nella chiusura del server: ",
+	"log.tokenError":              "Ottenimento del token da Spotify: ",
+	"log.tokenSaveError":          "Errore nel salvataggio del token per l'autenticazione: ",
+	"auth.page":                   "Autenticazione completata con successo. Ora puoi chiudere questa pagina.",
+	"auth.failedPage":             "Autenticazione non riuscita. Chiudi questa pagina e riprova dal programma.",
+	"log.tokenMissing":            "File data/auth/token.json non esistente. Verrà rieffettuata l'autenticazione.",
+	"log.tokenRead":               "Token per l'autenticazione letto da data/auth/token.json",
+	"log.tokenSaved":              "Token per l'autenticazione salvato in data/auth/token.json",
+	"log.itemUnavailable":         "Elemento non disponibile su Spotify ignorato",
+	"log.rateLimited":             "Limite di richieste a Spotify superato, attesa prima di riprovare",
+	"log.itemNotTrack":            "Elemento che non è un brano ignorato",
+	"log.itemNotSaved":            "Elemento non disponibile su Spotify, non può essere salvato",
+	"log.coverDownloadError":      "Impossibile scaricare la copertina della playlist",
+	"log.playlistUnchanged":       "Playlist invariata dall'ultimo backup",
+
+	// pkg/utils/utils.go
+
+	// main.go
+	"flag.offline":        "Consulta ed esporta i backup senza autenticarti a Spotify",
+	"log.loggerInit":      "Logger: inizializzato",
+	"log.languageInvalid": "Lingua non valida, viene usata quella del sistema",
+	"main.usage":          "Uso: playlist-manager [opzioni] [comando]",
+
+	// pkg/i18n/i18n.go
+	"i18n.unsupported": "lingua non supportata: %s (supportate: %s)",
+	"i18n.missing":     "%s: manca la chiave %s",
+	"i18n.verbs":       "%s: la chiave %s ha i segnaposto [%s] invece di [%s]",
+	"i18n.unknown":     "%s: la chiave %s non esiste in italiano",
+}
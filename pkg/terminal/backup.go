@@ -0,0 +1,452 @@
+package terminal
+
+import (
+	"fmt"
+	"path/filepath"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/config"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/tui"
+	"playlist-manager/pkg/utils"
+	"strconv"
+	"time"
+
+	spotifyapi "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+selectBackupFile asks the user to choose a backup file of userID (personal or of other users, then the date and the playlist).
+Returns the selected file, nil if the user cancelled the operation or there is nothing to choose, and an error, if present
+*/
+func selectBackupFile(userID string) (*backup.File, error) {
+	// Prima scelta: playlist proprie o di altri
+	ownerChoice, err := chooseOption(i18n.T("backup.whichPlaylist"), i18n.T("common.backToMenu"),
+		i18n.T("backup.personal"),
+		i18n.T("backup.others"),
+		i18n.T("backup.fromArchive"))
+	if err != nil {
+		log.Error(i18n.T("log.ownerChoiceError"), "error", err, "userID", userID)
+		return nil, err
+	}
+
+	if ownerChoice == 0 {
+		log.Info(i18n.T("log.backupSelectionCancelled"), "userID", userID)
+		return nil, nil
+	}
+	if ownerChoice == 3 {
+		utils.ClearTerminal()
+		return selectArchiveFile(userID)
+	}
+	if ownerChoice != 1 && ownerChoice != 2 {
+		log.Warn(i18n.T("log.ownerChoiceInvalid"), "choice", ownerChoice, "userID", userID)
+		fmt.Println(i18n.T("common.invalidChoice"))
+		pressEnter()
+		return nil, nil
+	}
+
+	isPersonal := ownerChoice == 1
+	utils.ClearTerminal()
+	log.Info(i18n.T("log.playlistKindChosen"), "userID", userID, "isPersonal", isPersonal)
+
+	//Get backup dates
+	dates, err := backup.UserDates(userID, isPersonal)
+	if err != nil {
+		log.Error(i18n.T("log.backupDirsError"), "error", err, "userID", userID, "isPersonal", isPersonal)
+		return nil, err
+	}
+
+	if len(dates) == 0 {
+		log.Warn(i18n.T("log.noBackups"), "userID", userID, "isPersonal", isPersonal)
+		if isPersonal {
+			fmt.Println(i18n.T("backup.noPersonal"))
+		} else {
+			fmt.Println(i18n.T("backup.noOthers"))
+		}
+		pressEnter()
+		return nil, nil
+	}
+
+	log.Info(i18n.T("log.backupDirsFound"), "count", len(dates), "userID", userID)
+	dateOptions := make([]string, len(dates))
+	for i, d := range dates {
+		dateOptions[i] = "📆 " + d
+	}
+
+	//Select date
+	dateSelect, err := chooseOption(i18n.T("backup.selectDate"), i18n.T("common.backToMenu"), dateOptions...)
+	if err != nil {
+		log.Error(i18n.T("log.dateSelectionError"), "error", err, "userID", userID)
+		return nil, err
+	}
+	if dateSelect == 0 {
+		log.Info(i18n.T("log.dateCancelled"), "userID", userID)
+		return nil, nil
+	}
+	if dateSelect < 1 || dateSelect > len(dates) {
+		log.Warn(i18n.T("log.dateInvalid"), "selection", dateSelect, "max", len(dates), "userID", userID)
+		fmt.Println(i18n.T("selection.invalid"))
+		pressEnter()
+		return nil, nil
+	}
+
+	selectedDate := dates[dateSelect-1]
+	utils.ClearTerminal()
+	log.Info(i18n.T("log.dateSelected"), "date", selectedDate, "userID", userID, "isPersonal", isPersonal)
+
+	//Get playlist files from selected date
+	files, err := backup.DateFiles(userID, isPersonal, selectedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		fmt.Print(i18n.T("backup.noFiles", selectedDate))
+		pressEnter()
+		return nil, nil
+	}
+
+	fileOptions := make([]string, len(files))
+	for i, f := range files {
+		fileOptions[i] = fmt.Sprintf("🎵 %s (%s)", f.Playlist.DisplayName(), filepath.Base(f.Path))
+	}
+
+	//Select playlist file
+	playlistSelect, err := chooseOption(i18n.T("backup.savedOn", selectedDate), i18n.T("common.backToMenu"), fileOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if playlistSelect == 0 {
+		return nil, nil
+	}
+	if playlistSelect < 1 || playlistSelect > len(files) {
+		fmt.Println(i18n.T("selection.invalid"))
+		pressEnter()
+		return nil, nil
+	}
+
+	return &files[playlistSelect-1], nil
+}
+
+/*
+selectArchiveFile asks the user to choose an archive (one of the user or any path) and a playlist inside it.
+The checksums of the archive are verified before showing its content
+Returns the selected file, nil if the user cancelled the operation, and an error, if present
+*/
+func selectArchiveFile(userID string) (*backup.File, error) {
+	archives, err := backup.Archives(userID)
+	if err != nil {
+		log.Error(i18n.T("log.archivesError"), "error", err, "userID", userID)
+		return nil, err
+	}
+
+	archivePath, err := chooseArchive(archives)
+	if err != nil || archivePath == "" {
+		return nil, err
+	}
+	if n, err := strconv.Atoi(archivePath); err == nil {
+		if n == 0 {
+			return nil, nil
+		}
+		if n < 1 || n > len(archives) {
+			fmt.Println(i18n.T("selection.invalid"))
+			pressEnter()
+			return nil, nil
+		}
+		archivePath = archives[n-1]
+	}
+
+	manifest, files, err := backup.ReadArchive(archivePath)
+	if err != nil {
+		log.Error(i18n.T("log.archiveReadError"), "error", err, "archive", archivePath)
+		fmt.Println(i18n.T("archive.readError"), err)
+		pressEnter()
+		return nil, nil
+	}
+	log.Info(i18n.T("log.archiveRead"), "archive", archivePath, "user", manifest.User, "date", manifest.Date, "playlists", manifest.Playlists)
+	utils.ClearTerminal()
+
+	fileOptions := make([]string, len(files))
+	for i, f := range files {
+		fileOptions[i] = fmt.Sprintf("🎵 %s (%s)", f.Playlist.DisplayName(), manifest.Files[i].Name)
+	}
+	title := i18n.T("archive.title", manifest.User, manifest.Date, manifest.Version, manifest.Playlists, manifest.Tracks)
+	playlistSelect, err := chooseOption(title, i18n.T("common.backToMenu"), fileOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if playlistSelect == 0 {
+		return nil, nil
+	}
+	if playlistSelect < 1 || playlistSelect > len(files) {
+		fmt.Println(i18n.T("selection.invalid"))
+		pressEnter()
+		return nil, nil
+	}
+	return &files[playlistSelect-1], nil
+}
+
+/*
+chooseArchive asks to choose one of the archives or to enter the path of another one
+Returns the path or the number of the archive in the list (empty or "0" if the user cancelled) and an error, if present
+*/
+func chooseArchive(archives []string) (string, error) {
+	if tui.Enabled() {
+		items := make([]tui.Item, 0, len(archives)+1)
+		for _, a := range archives {
+			items = append(items, tui.Item{Title: "🗜️ " + filepath.Base(a)})
+		}
+		items = append(items, tui.Item{Title: i18n.T("common.backToMenu")})
+		res, ok, err := tui.List{Title: i18n.T("archive.select"), Items: items, Hint: i18n.T("archive.pathHint")}.Run()
+		if err != nil || !ok {
+			return "", err
+		}
+		if res.Query != "" {
+			return res.Query, nil
+		}
+		if res.Indexes[0] == len(archives) {
+			return "", nil
+		}
+		return archives[res.Indexes[0]], nil
+	}
+
+	fmt.Println(i18n.T("archive.selectList"))
+	fmt.Println("=====================================")
+	for i, a := range archives {
+		fmt.Printf("🗜️ %d. %s\n", i+1, filepath.Base(a))
+	}
+	fmt.Println(i18n.T("common.backToMenu0"))
+	fmt.Print(i18n.T("archive.number"))
+	text, _ := readAnswer()
+	return text, nil
+}
+
+/*
+archiveBackup writes the newest backups of the given playlists to a single archive, in the format set in the configuration
+Returns an error, if present
+*/
+func archiveBackup(userID string, date string, ids []spotifyapi.ID) error {
+	format, err := backup.ParseArchiveFormat(config.Envs.BackupArchive)
+	if err != nil {
+		return err
+	}
+	files, err := backup.LatestFiles(userID, ids)
+	if err != nil {
+		return err
+	}
+	archivePath := backup.ArchivePath(userID, date, format)
+	archivePath, manifest, err := backup.WriteArchive(archivePath, userID, date, VERSION, files)
+	if err != nil {
+		return err
+	}
+	log.Info(i18n.T("log.archiveCreated"), "archive", archivePath, "playlists", manifest.Playlists, "tracks", manifest.Tracks)
+	fmt.Print(i18n.T("archive.created", filepath.ToSlash(archivePath), manifest.Playlists, manifest.Tracks))
+	return nil
+}
+
+// printBackupTracks prints the tracks of a backed up playlist
+func printBackupTracks(p backup.Playlist) {
+	fmt.Print(i18n.T("tracks.titleLine", p.DisplayName()))
+	if p.Description != "" {
+		fmt.Println("📝", p.Description)
+	}
+	fmt.Println("=======================================")
+	if len(p.Items) == 0 && len(p.TrackIDs) > 0 {
+		fmt.Println(i18n.T("backup.onlyIDs"))
+	}
+	for i, t := range p.Tracks() {
+		fmt.Printf("%s %d. %s\n", itemIcon(t), i+1, t)
+	}
+	if p.Unavailable > 0 {
+		fmt.Print(i18n.T("backup.unavailableItems", p.Unavailable))
+	}
+}
+
+// itemIcon returns the icon shown before an item of a playlist, based on its type
+func itemIcon(t backup.Track) string {
+	switch t.Type {
+	case backup.ItemEpisode:
+		return "🎙️"
+	case backup.ItemLocal:
+		return "💽"
+	default:
+		return "🎶"
+	}
+}
+
+/*
+restoreItems adds the tracks and the podcast episodes of a backup to a playlist, in their order. The tracks not playable anymore
+are replaced by their relinked version or by a track with the same ISRC; the substitutions and the items that can't be restored
+(local files, tracks without a substitute and the items not available when the backup was made) are reported
+Returns an error, if present
+*/
+func restoreItems(p backup.Playlist, playlistID spotifyapi.ID) error {
+	items, skipped := p.RestorableItems()
+	uris, changed, err := spotify.PlayableURIs(items)
+	if err != nil {
+		log.Error(i18n.T("log.availabilityError"), "error", err, "playlistID", playlistID)
+		return err
+	}
+	_, err = spotify.AddItemsToPlaylist(uris, playlistID)
+	if err != nil {
+		log.Error(i18n.T("log.addTracksError"), "error", err, "playlistID", playlistID)
+		return err
+	}
+	log.Info(i18n.T("log.backupItemsAdded"), "playlistName", p.Name, "playlistID", playlistID, "restored", len(uris), "changed", len(changed), "skipped", len(skipped), "unavailable", p.Unavailable)
+	printResolutions(changed, "")
+	if len(skipped) > 0 {
+		fmt.Print(i18n.T("restore.localFiles", len(skipped)))
+		for _, t := range skipped {
+			fmt.Printf("   💽 %s\n", t)
+		}
+	}
+	if p.Unavailable > 0 {
+		fmt.Print(i18n.T("restore.unavailableItems", p.Unavailable))
+	}
+	return nil
+}
+
+// printResolutions prints the tracks replaced by a playable equivalent and the ones not available anymore, every line starts with prefix
+func printResolutions(changed []spotify.Resolution, prefix string) {
+	var missing []spotify.Resolution
+	var substituted []spotify.Resolution
+	for _, r := range changed {
+		if r.Found() {
+			substituted = append(substituted, r)
+		} else {
+			missing = append(missing, r)
+		}
+	}
+	if len(substituted) > 0 {
+		fmt.Print(i18n.T("relink.substituted", prefix, len(substituted)))
+		for _, r := range substituted {
+			fmt.Printf("%s   ↪ %s ➜ %s (%s)\n", prefix, r.Source, backupTrackString(r.Track), i18n.T(r.Status))
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Print(i18n.T("relink.notFound", prefix, len(missing)))
+		for _, r := range missing {
+			fmt.Printf("%s   ↪ %s\n", prefix, r.Source)
+		}
+	}
+}
+
+// retentionPolicy returns the retention policy of the backups set in the configuration
+func retentionPolicy() backup.Policy {
+	return backup.Policy{
+		KeepLast:    config.Envs.BackupKeepLast,
+		KeepDaily:   config.Envs.BackupKeepDaily,
+		KeepWeekly:  config.Envs.BackupKeepWeekly,
+		KeepMonthly: config.Envs.BackupKeepMonthly,
+	}
+}
+
+// printPolicy prints the rules of the retention policy
+func printPolicy(p backup.Policy) {
+	fmt.Println(i18n.T("prune.policy"))
+	fmt.Print(i18n.T("prune.keepLast", p.KeepLast))
+	fmt.Print(i18n.T("prune.keepDaily", p.KeepDaily))
+	fmt.Print(i18n.T("prune.keepWeekly", p.KeepWeekly))
+	fmt.Print(i18n.T("prune.keepMonthly", p.KeepMonthly))
+}
+
+/*
+pruneBackups removes the backups of the user not kept by the policy and prints the result, if dryRun is true nothing is removed
+Returns the number of backups removed (or to remove) and an error, if present
+*/
+func pruneBackups(userID string, policy backup.Policy, dryRun bool) (int, error) {
+	res, err := backup.Prune(userID, policy, time.Now(), dryRun)
+	if err != nil {
+		log.Error(i18n.T("log.pruneError"), "error", err, "userID", userID)
+		return 0, err
+	}
+	log.Info(i18n.T("log.pruneDone"), "removed", len(res.Removed), "kept", res.Kept, "dryRun", dryRun, "userID", userID)
+
+	if len(res.Removed) == 0 {
+		fmt.Print(i18n.T("prune.nothing", res.Kept))
+		return 0, nil
+	}
+	if dryRun {
+		fmt.Print(i18n.T("prune.willDelete", len(res.Removed), res.Kept))
+	} else {
+		fmt.Print(i18n.T("prune.deleted", len(res.Removed), res.Kept))
+	}
+	for _, path := range res.Removed {
+		fmt.Printf("   🗑️ %s\n", filepath.ToSlash(path))
+	}
+	return len(res.Removed), nil
+}
+
+/*
+pruneMenu shows the backups that would be removed by the retention policy in the configuration and removes them after the confirmation of the user
+Returns an error, if present
+*/
+func pruneMenu(userID string) error {
+	policy := retentionPolicy()
+	if policy.IsZero() {
+		fmt.Println(i18n.T("prune.noPolicy"))
+		fmt.Println(i18n.T("prune.noPolicyHint"))
+		return nil
+	}
+	printPolicy(policy)
+	fmt.Println()
+
+	toRemove, err := pruneBackups(userID, policy, true)
+	if err != nil || toRemove == 0 {
+		return err
+	}
+
+	fmt.Print(i18n.T("prune.confirm"))
+	var confirm string
+	confirm, err = readAnswer()
+	if err != nil {
+		return err
+	}
+	if confirm != i18n.T("answer.yes") {
+		fmt.Println(i18n.T("common.cancelled"))
+		return nil
+	}
+	_, err = pruneBackups(userID, policy, false)
+	return err
+}
+
+/*
+restoreAsNew creates a new playlist with the name, the description and the cover of the backup and adds its tracks
+Returns an error, if present
+*/
+func restoreAsNew(f backup.File) error {
+	p := f.Playlist
+	fmt.Print(i18n.T("restore.newInProgress", p.Name))
+	created, err := spotify.CreatePlaylist(p.Name, p.Description)
+	if err != nil {
+		log.Error(i18n.T("log.createPlaylistError"), "error", err, "playlistName", p.Name)
+		return err
+	}
+
+	cover, err := backup.ReadCover(f)
+	if err != nil {
+		log.Warn(i18n.T("log.coverReadError"), "file", f.Path, "error", err)
+		fmt.Println(i18n.T("restore.coverReadError"), err)
+	} else if spotify.CoverTooLarge(cover) {
+		log.Warn(i18n.T("log.coverTooLarge"), "playlistID", created.ID, "file", f.Path, "bytes", len(cover))
+		fmt.Print(i18n.T("restore.coverTooLarge", len(cover)/1024))
+	} else if cover != nil {
+		err = spotify.SetPlaylistCover(created.ID, cover)
+		if err != nil {
+			log.Warn(i18n.T("log.coverUploadError"), "playlistID", created.ID, "error", err)
+			fmt.Println(i18n.T("restore.coverUploadError"), err)
+		} else {
+			fmt.Println(i18n.T("restore.coverUploaded"))
+		}
+	}
+
+	err = restoreItems(p, created.ID)
+	if err != nil {
+		return err
+	}
+	log.Info(i18n.T("log.restoredNew"), "file", f.Path, "playlistID", created.ID, "tracks", len(p.Tracks()))
+	fmt.Print(i18n.T("restore.newDone", p.Name, created.ID))
+	return nil
+}
@@ -0,0 +1,558 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"sort"
+	"strings"
+	"time"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// Exit codes of the commands, the same used by diff: 0 no differences, 1 differences found, 2 error
+const (
+	ExitOK      = 0
+	ExitChanges = 1
+	ExitError   = 2
+)
+
+// command is a command that can be run from the command line without the interactive menu.
+// usage and help are keys of the catalogue, translated when printed because the map is created before the language is chosen
+type command struct {
+	usage string
+	help  string
+	run   func(fs *flag.FlagSet, args []string) int
+}
+
+var commands = map[string]command{
+	"archive": {
+		usage: "cmd.archive.usage",
+		help:  "cmd.archive.help",
+		run:   archiveCommand,
+	},
+	"compare": {
+		usage: "cmd.compare.usage",
+		help:  "cmd.compare.help",
+		run:   compareCommand,
+	},
+	"diff": {
+		usage: "cmd.diff.usage",
+		help:  "cmd.diff.help",
+		run:   diffCommand,
+	},
+	"duplicates": {
+		usage: "cmd.duplicates.usage",
+		help:  "cmd.duplicates.help",
+		run:   duplicatesCommand,
+	},
+	"export": {
+		usage: "cmd.export.usage",
+		help:  "cmd.export.help",
+		run:   exportCommand,
+	},
+	"import": {
+		usage: "cmd.import.usage",
+		help:  "cmd.import.help",
+		run:   importCommand,
+	},
+	"keygen": {
+		usage: "cmd.keygen.usage",
+		help:  "cmd.keygen.help",
+		run:   keygenCommand,
+	},
+	"library": {
+		usage: "cmd.library.usage",
+		help:  "cmd.library.help",
+		run:   libraryCommand,
+	},
+	"merge": {
+		usage: "cmd.merge.usage",
+		help:  "cmd.merge.help",
+		run:   mergeCommand,
+	},
+	"prune": {
+		usage: "cmd.prune.usage",
+		help:  "cmd.prune.help",
+		run:   pruneCommand,
+	},
+	"search": {
+		usage: "cmd.search.usage",
+		help:  "cmd.search.help",
+		run:   searchCommand,
+	},
+	"smart": {
+		usage: "cmd.smart.usage",
+		help:  "cmd.smart.help",
+		run:   smartCommand,
+	},
+	"sort": {
+		usage: "cmd.sort.usage",
+		help:  "cmd.sort.help",
+		run:   sortCommand,
+	},
+	"split": {
+		usage: "cmd.split.usage",
+		help:  "cmd.split.help",
+		run:   splitCommand,
+	},
+	"stats": {
+		usage: "cmd.stats.usage",
+		help:  "cmd.stats.help",
+		run:   statsCommand,
+	},
+	"store": {
+		usage: "cmd.store.usage",
+		help:  "cmd.store.help",
+		run:   storeCommand,
+	},
+	"translations": {
+		usage: "cmd.translations.usage",
+		help:  "cmd.translations.help",
+		run:   translationsCommand,
+	},
+	"verify": {
+		usage: "cmd.verify.usage",
+		help:  "cmd.verify.help",
+		run:   verifyCommand,
+	},
+	"where": {
+		usage: "cmd.where.usage",
+		help:  "cmd.where.help",
+		run:   whereCommand,
+	},
+}
+
+/*
+RunCommand runs the command given as first element of args (the arguments left after the global flags)
+Returns the exit code of the program
+*/
+func RunCommand(args []string) int {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(os.Stderr, i18n.T("cmd.unknown", args[0]))
+		PrintCommands()
+		return ExitError
+	}
+	log.Info(i18n.T("log.command"), "command", args[0], "args", args[1:])
+	return cmd.run(newFlagSet(args[0], cmd), args[1:])
+}
+
+// PrintCommands prints the usage of all the available commands
+func PrintCommands() {
+	fmt.Fprintln(os.Stderr, i18n.T("cmd.available"))
+	for _, name := range commandNames() {
+		fmt.Fprintf(os.Stderr, "  %s\n    \t%s\n", i18n.T(commands[name].usage), i18n.T(commands[name].help))
+	}
+	fmt.Fprintln(os.Stderr, "\n"+i18n.T(playlistArgHint))
+}
+
+// commandNames returns the names of the commands in alphabetical order
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newFlagSet returns the flag set of a command, that prints its usage in case of error
+func newFlagSet(name string, cmd command) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		usage := i18n.T(cmd.usage)
+		fmt.Fprint(os.Stderr, i18n.T("cmd.usage", usage, i18n.T(cmd.help)))
+		fs.PrintDefaults()
+		if strings.Contains(usage, "playlist") {
+			fmt.Fprintln(os.Stderr, i18n.T(playlistArgHint))
+		}
+	}
+	return fs
+}
+
+// authCommand authenticates to Spotify for the commands that need the API, printing the error if present
+func authCommand() bool {
+	err := spotify.Auth()
+	if err != nil {
+		log.Error(i18n.T("log.authError"), "error", err)
+		fmt.Fprintln(os.Stderr, i18n.T("cmd.authError"), err)
+		return false
+	}
+	return true
+}
+
+// diffCommand compares two backups or a backup and the live playlist, exits with 1 if there are differences
+func diffCommand(fs *flag.FlagSet, args []string) int {
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return ExitError
+	}
+
+	oldPl, err := backup.Read(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cmd.backupReadError"), err)
+		return ExitError
+	}
+
+	var newPl backup.Playlist
+	newLabel := i18n.T("diff.livePlaylist")
+	if fs.NArg() == 2 {
+		newPl, err = backup.Read(fs.Arg(1))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cmd.backupReadError"), err)
+			return ExitError
+		}
+		if newPl.ID != oldPl.ID {
+			fmt.Fprint(os.Stderr, i18n.T("diff.differentPlaylists", oldPl.ID, newPl.ID))
+			return ExitError
+		}
+		newLabel = fs.Arg(1)
+	} else {
+		if !authCommand() {
+			return ExitError
+		}
+		newPl, err = spotify.GetPlaylistSnapshot(oldPl.ID, oldPl.Name)
+		if err != nil {
+			log.Error(i18n.T("log.livePlaylistError"), "error", err, "playlistID", oldPl.ID)
+			fmt.Fprintln(os.Stderr, i18n.T("diff.livePlaylistError"), err)
+			return ExitError
+		}
+	}
+
+	res := backup.Diff(oldPl, newPl)
+	printDiff(res, oldPl.Name, fs.Arg(0), newLabel)
+	if res.HasChanges() {
+		return ExitChanges
+	}
+	return ExitOK
+}
+
+// pruneCommand applies the retention policy to the backups of one or all the users
+func pruneCommand(fs *flag.FlagSet, args []string) int {
+	policy := retentionPolicy()
+	user := fs.String("user", "", i18n.T("flag.prune.user"))
+	dryRun := fs.Bool("dry-run", false, i18n.T("flag.prune.dryRun"))
+	fs.IntVar(&policy.KeepLast, "keep-last", policy.KeepLast, i18n.T("flag.keepLast"))
+	fs.IntVar(&policy.KeepDaily, "keep-daily", policy.KeepDaily, i18n.T("flag.keepDaily"))
+	fs.IntVar(&policy.KeepWeekly, "keep-weekly", policy.KeepWeekly, i18n.T("flag.keepWeekly"))
+	fs.IntVar(&policy.KeepMonthly, "keep-monthly", policy.KeepMonthly, i18n.T("flag.keepMonthly"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if policy.IsZero() {
+		fmt.Fprintln(os.Stderr, i18n.T("prune.noPolicyCommand"))
+		return ExitError
+	}
+
+	users := []string{*user}
+	if *user == "" {
+		users, err = backup.Users()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cmd.backupDirError"), err)
+			return ExitError
+		}
+	}
+	for _, u := range users {
+		fmt.Printf("👤 %s\n", u)
+		_, err = pruneBackups(u, policy, *dryRun)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cmd.pruneError"), err)
+			return ExitError
+		}
+	}
+	return ExitOK
+}
+
+// commandUser returns the user given with the -user flag or, if there is only one user with backups, that one
+func commandUser(user string) (string, bool) {
+	if user != "" {
+		return user, true
+	}
+	users, err := backup.Users()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cmd.backupDirError"), err)
+		return "", false
+	}
+	if len(users) != 1 {
+		fmt.Fprintln(os.Stderr, i18n.T("cmd.userRequired"), users)
+		return "", false
+	}
+	return users[0], true
+}
+
+// storeCommand runs the tools of the content-addressed store of the backups
+func storeCommand(fs *flag.FlagSet, args []string) int {
+	user := fs.String("user", "", i18n.T("flag.store.user"))
+	date := fs.String("date", time.Now().Format(backup.DateLayout), i18n.T("flag.store.date"))
+	out := fs.String("out", "", i18n.T("flag.store.out"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return ExitError
+	}
+	userID, ok := commandUser(*user)
+	if !ok {
+		return ExitError
+	}
+
+	switch fs.Arg(0) {
+	case "verify":
+		res, err := backup.Verify(userID)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("store.verifyError"), err)
+			return ExitError
+		}
+		log.Info(i18n.T("log.storeVerified"), "userID", userID, "objects", res.Objects, "manifests", res.Manifests, "corrupted", len(res.Corrupted), "missing", len(res.Missing))
+		fmt.Print(i18n.T("store.summary", userID, res.Manifests, res.Objects))
+		for _, path := range res.Corrupted {
+			fmt.Println(i18n.T("verify.altered"), filepath.ToSlash(path))
+		}
+		for _, ref := range res.Missing {
+			fmt.Println(i18n.T("verify.missing"), ref)
+		}
+		for _, path := range res.Unused {
+			fmt.Println(i18n.T("store.unused"), filepath.ToSlash(path))
+		}
+		if !res.OK() {
+			return ExitChanges
+		}
+		fmt.Println(i18n.T("verify.ok"))
+
+	case "history":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			return ExitError
+		}
+		id, ok := playlistArg(fs.Arg(1))
+		if !ok {
+			return ExitError
+		}
+		history, err := backup.History(userID, id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("store.historyError"), err)
+			return ExitError
+		}
+		if len(history) == 0 {
+			fmt.Println(i18n.T("store.noHistory"))
+			return ExitOK
+		}
+		fmt.Print(i18n.T("store.history", fs.Arg(1)))
+		for i, h := range history {
+			path, err := backup.ObjectPath(userID, h.Hash)
+			if err != nil {
+				fmt.Printf("📆 %s  ❌ %v\n", h.Date, err)
+				continue
+			}
+			p, err := backup.Read(path)
+			if err != nil {
+				fmt.Printf("📆 %s  %s  ❌ %v\n", h.Date, h.Hash[:12], err)
+				continue
+			}
+			status := i18n.T("store.unchanged")
+			if i == 0 {
+				status = i18n.T("store.first")
+			} else if h.Changed {
+				status = i18n.T("store.changed")
+			}
+			fmt.Print(i18n.T("store.historyLine", h.Date, h.Hash[:12], p.Name, len(p.TrackIDs), status))
+		}
+
+	case "checkout":
+		files, err := backup.PointInTime(userID, *date)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("store.checkoutError"), err)
+			return ExitError
+		}
+		dir := *out
+		if dir == "" {
+			dir = filepath.Join("data", "checkout", userID, *date)
+		}
+		for _, f := range files {
+			f.Playlist.CoverImage, err = backup.ReadCover(f)
+			if err != nil {
+				log.Warn(i18n.T("log.coverReadPlaylistError"), "playlistID", f.Playlist.ID, "error", err)
+			}
+			_, err = backup.Write(dir, f.Playlist)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, i18n.T("store.writeError"), err)
+				return ExitError
+			}
+		}
+		log.Info(i18n.T("log.storeCheckout"), "userID", userID, "date", *date, "dir", dir, "count", len(files))
+		fmt.Print(i18n.T("store.checkoutDone", len(files), *date, filepath.ToSlash(dir)))
+
+	case "import":
+		imported, err := backup.ImportToStore(userID)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("store.importError"), err)
+			return ExitError
+		}
+		log.Info(i18n.T("log.storeImported"), "userID", userID, "count", imported)
+		fmt.Print(i18n.T("store.importDone", imported))
+
+	default:
+		fs.Usage()
+		return ExitError
+	}
+	return ExitOK
+}
+
+// archiveCommand writes the backups of a date to a single compressed archive
+func archiveCommand(fs *flag.FlagSet, args []string) int {
+	user := fs.String("user", "", i18n.T("flag.archive.user"))
+	date := fs.String("date", time.Now().Format(backup.DateLayout), i18n.T("flag.archive.date"))
+	format := fs.String("format", string(backup.ArchiveTarGz), i18n.T("flag.archive.format"))
+	out := fs.String("out", "", i18n.T("flag.archive.out"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	f, err := backup.ParseArchiveFormat(*format)
+	if err != nil || f == "" {
+		fmt.Fprintln(os.Stderr, i18n.T("archive.invalidFormat"), *format)
+		return ExitError
+	}
+	userID, ok := commandUser(*user)
+	if !ok {
+		return ExitError
+	}
+
+	files, err := backup.DateArchiveFiles(userID, *date)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("archive.backupsError"), err)
+		return ExitError
+	}
+	if len(files) == 0 {
+		fmt.Fprint(os.Stderr, i18n.T("archive.noBackups", *date))
+		return ExitError
+	}
+
+	archivePath := *out
+	if archivePath == "" {
+		archivePath = backup.ArchivePath(userID, *date, f)
+	}
+	archivePath, manifest, err := backup.WriteArchive(archivePath, userID, *date, VERSION, files)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("archive.createError"), err)
+		return ExitError
+	}
+	log.Info(i18n.T("log.archiveCreated"), "archive", archivePath, "playlists", manifest.Playlists, "tracks", manifest.Tracks)
+	fmt.Print(i18n.T("archive.created", filepath.ToSlash(archivePath), manifest.Playlists, manifest.Tracks))
+	return ExitOK
+}
+
+// keygenCommand generates an age key pair for the encryption of the backups
+func keygenCommand(fs *flag.FlagSet, args []string) int {
+	out := fs.String("out", filepath.Join("data", "auth", "backup-key.txt"), i18n.T("flag.keygen.out"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if _, err := os.Stat(*out); err == nil {
+		fmt.Fprint(os.Stderr, i18n.T("keygen.exists", filepath.ToSlash(*out)))
+		return ExitError
+	}
+
+	privateKey, publicKey, err := backup.GenerateKey()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("keygen.error"), err)
+		return ExitError
+	}
+	err = os.MkdirAll(filepath.Dir(*out), 0755)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("keygen.saveError"), err)
+		return ExitError
+	}
+	content := fmt.Sprintf("# created: %s\n# public key: %s\n%s\n", time.Now().Format(time.RFC3339), publicKey, privateKey)
+	err = os.WriteFile(*out, []byte(content), 0600)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("keygen.saveError"), err)
+		return ExitError
+	}
+	log.Info(i18n.T("log.keygen"), "identityFile", *out, "publicKey", publicKey)
+	fmt.Println(i18n.T("keygen.savedIn"), filepath.ToSlash(*out), i18n.T("keygen.keepIt"))
+	fmt.Println(i18n.T("keygen.public"), publicKey)
+	fmt.Println(i18n.T("keygen.setEnv"))
+	fmt.Println("   BACKUP_RECIPIENTS=" + publicKey)
+	fmt.Println("   BACKUP_IDENTITY_FILE=" + filepath.ToSlash(*out))
+	return ExitOK
+}
+
+// verifyCommand checks the integrity of all the backups of one or all the users, exits with 1 if a problem is found
+func verifyCommand(fs *flag.FlagSet, args []string) int {
+	user := fs.String("user", "", i18n.T("flag.verify.user"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+
+	users := []string{*user}
+	if *user == "" {
+		users, err = backup.Users()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cmd.backupDirError"), err)
+			return ExitError
+		}
+	}
+	code := ExitOK
+	for _, u := range users {
+		res, err := backup.VerifyAll(u)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("verify.error"), err)
+			return ExitError
+		}
+		log.Info(i18n.T("log.verified"), "userID", u, "files", res.Files, "archives", res.Archives, "objects", res.Store.Objects, "failed", len(res.Failed))
+		fmt.Print(i18n.T("verify.summary", u, res.Files, res.Archives, res.Store.Objects))
+		if res.Plain > 0 {
+			fmt.Print(i18n.T("verify.plain", res.Plain))
+		}
+		for _, f := range res.Failed {
+			fmt.Println("   ❌", filepath.ToSlash(f))
+		}
+		for _, path := range res.Store.Corrupted {
+			fmt.Println(i18n.T("verify.altered"), filepath.ToSlash(path))
+		}
+		for _, ref := range res.Store.Missing {
+			fmt.Println(i18n.T("verify.missing"), ref)
+		}
+		if !res.OK() {
+			code = ExitChanges
+			continue
+		}
+		fmt.Println(i18n.T("verify.okIndented"))
+	}
+	return code
+}
+
+// translationsCommand checks that the catalogues of the messages are complete in all the languages, exits with 1 if a problem is found
+func translationsCommand(fs *flag.FlagSet, args []string) int {
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+
+	problems := i18n.Check()
+	for _, p := range problems {
+		fmt.Println("   ❌", p)
+	}
+	if len(problems) > 0 {
+		fmt.Print(i18n.N("translations.problems", len(problems), len(problems)))
+		return ExitChanges
+	}
+	fmt.Print(i18n.T("translations.ok", strings.Join(i18n.Languages(), ", ")))
+	return ExitOK
+}
@@ -0,0 +1,261 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/link"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"strconv"
+	"strings"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// compareTop is the number of tracks and artists shown for each set, the new playlists contain all of them
+const compareTop = 10
+
+// printComparison prints the tracks in common, the unique tracks of each playlist, the similarity and the shared artists
+func printComparison(c backup.Comparison) {
+	fmt.Println(i18n.T("compare.title") + strings.Join(quoted(c.Names), ", "))
+	fmt.Println("=======================================")
+	printTrackSet(i18n.T("compare.common", len(c.Common)), c.Common)
+	for i, name := range c.Names {
+		printTrackSet(i18n.T("compare.only", name, len(c.Unique[i])), c.Unique[i])
+	}
+	fmt.Println(i18n.T("compare.similarity"))
+	for i := range c.Names {
+		for j := i + 1; j < len(c.Names); j++ {
+			fmt.Print(i18n.T("compare.similarityLine", c.Names[i], c.Names[j], c.Similarity[i][j]*100))
+		}
+	}
+	fmt.Print(i18n.T("compare.artists", len(c.SharedArtists)))
+	if len(c.SharedArtists) > 0 {
+		shown := c.SharedArtists[:min(compareTop, len(c.SharedArtists))]
+		fmt.Print(": " + strings.Join(shown, ", "))
+		if len(c.SharedArtists) > len(shown) {
+			fmt.Print(i18n.T("compare.others", len(c.SharedArtists)-len(shown)))
+		}
+	}
+	fmt.Println()
+}
+
+// printTrackSet prints the title and the first tracks of a set
+func printTrackSet(title string, tracks []backup.Track) {
+	fmt.Println(title + ":")
+	for i, t := range tracks {
+		if i == compareTop {
+			fmt.Print(i18n.T("compare.othersLine", len(tracks)-compareTop))
+			break
+		}
+		fmt.Printf("   %s %s\n", itemIcon(t), t)
+	}
+}
+
+// quoted returns the names between single quotes
+func quoted(names []string) []string {
+	res := make([]string, 0, len(names))
+	for _, n := range names {
+		res = append(res, "'"+n+"'")
+	}
+	return res
+}
+
+/*
+comparisonSet returns a set of the comparison: "common" for the tracks in common, the number of a playlist (from 1) for its unique tracks
+Returns the tracks, the default name of the new playlist and an error if the set is not valid
+*/
+func comparisonSet(c backup.Comparison, set string) ([]backup.Track, string, error) {
+	if set == "common" {
+		return c.Common, i18n.T("compare.commonName") + strings.Join(c.Names, " + "), nil
+	}
+	n, err := strconv.Atoi(set)
+	if err != nil || n < 1 || n > len(c.Names) {
+		return nil, "", fmt.Errorf(i18n.T("compare.invalidSet"), set, len(c.Names))
+	}
+	return c.Unique[n-1], i18n.T("compare.onlyName") + c.Names[n-1], nil
+}
+
+/*
+saveComparisonSet creates a new playlist with the tracks of a set, the other items (podcast episodes and local files) are skipped
+Returns an error, if present
+*/
+func saveComparisonSet(tracks []backup.Track, name string) error {
+	var onlyTracks []backup.Track
+	for _, t := range tracks {
+		if t.Type == backup.ItemTrack {
+			onlyTracks = append(onlyTracks, t)
+		}
+	}
+	if len(onlyTracks) == 0 {
+		fmt.Println(i18n.T("compare.nothingToSave"))
+		return nil
+	}
+	created, err := createWithTracks(name, i18n.T("compare.description"), onlyTracks)
+	if err != nil {
+		return err
+	}
+	fmt.Print(i18n.T("compare.created", created.Name, len(onlyTracks), created.ID))
+	if skipped := len(tracks) - len(onlyTracks); skipped > 0 {
+		fmt.Print(i18n.T("compare.notTracks", skipped))
+	}
+	return nil
+}
+
+/*
+compareMenu asks the user two or more playlists (live or backups), shows their comparison and saves a set in a new playlist if requested
+Returns an error, if present
+*/
+func compareMenu() error {
+	var playlists []backup.Playlist
+	for {
+		fmt.Print(i18n.T("compare.selected", len(playlists)))
+		for _, p := range playlists {
+			fmt.Printf("   🎵 %s\n", p.Name)
+		}
+		options := []string{i18n.T("compare.addLive"), i18n.T("compare.addBackup")}
+		if len(playlists) >= 2 {
+			options = append(options, i18n.T("compare.compare"))
+		}
+		title := i18n.T("compare.menuTitle", len(playlists))
+		choice, err := chooseOption(title, i18n.T("common.cancel"), options...)
+		if err != nil {
+			return err
+		}
+		switch choice {
+		case 1:
+			selected, err := selectPlaylist(i18n.T("compare.selectPlaylist"))
+			if err != nil {
+				return err
+			}
+			if selected == nil {
+				continue
+			}
+			p, err := livePlaylist(selected.ID, selected.Name)
+			if err != nil {
+				fmt.Println(i18n.T("common.playlistError"), err)
+				continue
+			}
+			playlists = append(playlists, p)
+		case 2:
+			f, err := selectBackupFile(userID)
+			if err != nil {
+				return err
+			}
+			if f != nil {
+				playlists = append(playlists, f.Playlist)
+			}
+		case 3:
+			if len(playlists) >= 2 {
+				return compare(playlists)
+			}
+		case 0:
+			return nil
+		}
+	}
+}
+
+/*
+compare shows the comparison of the playlists and asks the user which set to save in a new playlist
+Returns an error, if present
+*/
+func compare(playlists []backup.Playlist) error {
+	c := backup.Compare(playlists)
+	printComparison(c)
+	keepOutput()
+
+	options := []string{i18n.T("compare.saveCommon", len(c.Common))}
+	for i, name := range c.Names {
+		options = append(options, i18n.T("compare.saveOnly", name, len(c.Unique[i])))
+	}
+	choice, err := chooseOption(i18n.T("compare.save"), i18n.T("common.no"), options...)
+	if err != nil {
+		return err
+	}
+	if choice < 1 || choice > len(c.Names)+1 {
+		return nil
+	}
+	set := "common"
+	if choice > 1 {
+		set = strconv.Itoa(choice - 1)
+	}
+	tracks, name, _ := comparisonSet(c, set)
+	fmt.Print(i18n.T("compare.name", name))
+	if custom := readLine(); custom != "" {
+		name = custom
+	}
+	err = saveComparisonSet(tracks, name)
+	if err != nil {
+		fmt.Println(i18n.T("common.createPlaylistError"), err)
+	}
+	return nil
+}
+
+/*
+comparedPlaylist reads a playlist to compare from a backup file, if arg is the path of an existing file, or from Spotify given its link, URI or ID
+Returns the playlist and an error, if present
+*/
+func comparedPlaylist(arg string) (backup.Playlist, error) {
+	if _, err := os.Stat(arg); err == nil {
+		return backup.Read(arg)
+	}
+	id, err := link.ParseKind(arg, link.Playlist)
+	if err != nil {
+		return backup.Playlist{}, err
+	}
+	full, err := spotify.GetPlaylist(id)
+	if err != nil {
+		log.Error(i18n.T("log.playlistError"), "error", err, "playlistID", arg)
+		return backup.Playlist{}, err
+	}
+	return livePlaylist(full.ID, full.Name)
+}
+
+// compareCommand compares two or more playlists (live or backup files) and saves a set in a new playlist if requested
+func compareCommand(fs *flag.FlagSet, args []string) int {
+	save := fs.String("save", "", i18n.T("flag.compare.save"))
+	name := fs.String("name", "", i18n.T("flag.compare.name"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return ExitError
+	}
+	if !authCommand() {
+		return ExitError
+	}
+
+	var playlists []backup.Playlist
+	for _, arg := range fs.Args() {
+		p, err := comparedPlaylist(arg)
+		if err != nil {
+			fmt.Fprint(os.Stderr, i18n.T("common.readFileError", arg, err))
+			return ExitError
+		}
+		playlists = append(playlists, p)
+	}
+	c := backup.Compare(playlists)
+	printComparison(c)
+	if *save == "" {
+		return ExitOK
+	}
+
+	tracks, defaultName, err := comparisonSet(c, *save)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return ExitError
+	}
+	if *name == "" {
+		*name = defaultName
+	}
+	err = saveComparisonSet(tracks, *name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("common.createPlaylistError"), err)
+		return ExitError
+	}
+	return ExitOK
+}
@@ -0,0 +1,89 @@
+package terminal
+
+import (
+	"fmt"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// printDiff prints the differences between two versions of a playlist, oldLabel and newLabel describe the two versions
+func printDiff(res backup.DiffResult, name string, oldLabel string, newLabel string) {
+	fmt.Print(i18n.T("diff.title", name, oldLabel, newLabel))
+	fmt.Println("=======================================")
+	if !res.HasChanges() {
+		fmt.Println(i18n.T("diff.none"))
+		return
+	}
+
+	if len(res.Added) > 0 {
+		fmt.Print(i18n.T("diff.added", len(res.Added)))
+		for _, c := range res.Added {
+			fmt.Printf("   #%d %s\n", c.Position, c.Track)
+		}
+	}
+	if len(res.Removed) > 0 {
+		fmt.Print(i18n.T("diff.removed", len(res.Removed)))
+		for _, c := range res.Removed {
+			fmt.Printf("   #%d %s\n", c.Position, c.Track)
+		}
+	}
+	if len(res.Moved) > 0 {
+		fmt.Print(i18n.T("diff.moved", len(res.Moved)))
+		for _, m := range res.Moved {
+			fmt.Printf("   #%d ➜ #%d %s\n", m.From, m.To, m.Track)
+		}
+	}
+}
+
+/*
+diffMenu asks the user to choose a backup and compares it with another backup or, if live is true, with the current playlist on Spotify
+Returns an error, if present
+*/
+func diffMenu(live bool) error {
+	fmt.Println(i18n.T("diff.selectBackup"))
+	oldFile, err := selectBackupFile(userID)
+	if err != nil {
+		return err
+	}
+	if oldFile == nil {
+		return nil
+	}
+	utils.ClearTerminal()
+
+	var newPl backup.Playlist
+	newLabel := i18n.T("diff.livePlaylist")
+	if live {
+		log.Info(i18n.T("log.diffLive"), "file", oldFile.Path, "playlistID", oldFile.Playlist.ID, "userID", userID)
+		newPl, err = spotify.GetPlaylistSnapshot(oldFile.Playlist.ID, oldFile.Playlist.Name)
+		if err != nil {
+			log.Error(i18n.T("log.livePlaylistError"), "error", err, "playlistID", oldFile.Playlist.ID)
+			fmt.Println(i18n.T("diff.livePlaylistError"), err)
+			return nil
+		}
+	} else {
+		fmt.Println(i18n.T("diff.selectNewer"))
+		newFile, err := selectBackupFile(userID)
+		if err != nil {
+			return err
+		}
+		if newFile == nil {
+			return nil
+		}
+		if newFile.Playlist.ID != oldFile.Playlist.ID {
+			log.Warn(i18n.T("log.diffDifferent"), "old", oldFile.Path, "new", newFile.Path)
+			fmt.Println(i18n.T("diff.different"))
+		}
+		newPl = newFile.Playlist
+		newLabel = newFile.Path
+	}
+
+	res := backup.Diff(oldFile.Playlist, newPl)
+	log.Info(i18n.T("log.diffDone"), "added", len(res.Added), "removed", len(res.Removed), "moved", len(res.Moved))
+	utils.ClearTerminal()
+	printDiff(res, oldFile.Playlist.DisplayName(), oldFile.Path, newLabel)
+	return nil
+}
@@ -0,0 +1,251 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+	"strconv"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+findDuplicates reads a playlist from Spotify and finds its duplicated tracks (see backup.FindDuplicates)
+Returns the groups of duplicates, the snapshot ID of the version read and an error, if present
+*/
+func findDuplicates(id api.ID, exact bool) ([]backup.DuplicateGroup, string, error) {
+	items, snapshotID, err := spotify.GetPositionedItems(id)
+	if err != nil {
+		log.Error(i18n.T("log.tracksError"), "error", err, "playlistID", id)
+		return nil, "", err
+	}
+	return backup.FindDuplicates(items, exact), snapshotID, nil
+}
+
+// printDuplicates prints the groups of duplicates of a playlist, with the position, the duration and the date each copy was added
+func printDuplicates(name string, groups []backup.DuplicateGroup) {
+	if len(groups) == 0 {
+		fmt.Print(i18n.T("duplicates.none", name))
+		return
+	}
+	fmt.Print(i18n.T("duplicates.title", name, len(groups)))
+	fmt.Println("=======================================")
+	for i, g := range groups {
+		fmt.Printf("%d. %s\n", i+1, i18n.T(g.Reason))
+		for _, c := range g.Items {
+			text := fmt.Sprintf("   #%d %s (%s)", c.Position, c.Track, utils.FormatDuration(c.Track.Duration))
+			if c.Track.AddedAt != "" {
+				text += i18n.T("duplicates.addedOn") + c.Track.AddedAt
+			}
+			fmt.Println(text)
+		}
+	}
+}
+
+// duplicateExtras returns the copies removed by default: all the occurrences of every group except the first
+func duplicateExtras(groups []backup.DuplicateGroup) (extra []backup.Change) {
+	for _, g := range groups {
+		extra = append(extra, g.Extra()...)
+	}
+	return extra
+}
+
+/*
+removeDuplicates removes the given copies from a playlist, only at their positions
+Returns an error, if present
+*/
+func removeDuplicates(id api.ID, name string, snapshotID string, items []backup.Change) error {
+	_, err := spotify.RemovePositions(id, snapshotID, items)
+	if err != nil {
+		log.Error(i18n.T("log.duplicatesRemoveError"), "error", err, "playlistID", id)
+		return err
+	}
+	log.Info(i18n.T("log.duplicatesRemoved"), "playlistName", name, "playlistID", id, "removed", len(items))
+	fmt.Print(i18n.N("duplicates.removed", len(items), len(items), name))
+	return nil
+}
+
+/*
+choosePositions asks the positions of the copies to remove, among the ones in the groups of duplicates
+Returns the chosen copies, empty if the user entered 0 or nothing valid
+*/
+func choosePositions(groups []backup.DuplicateGroup) (chosen []backup.Change) {
+	byPosition := map[int]backup.Change{}
+	for _, g := range groups {
+		for _, c := range g.Items {
+			byPosition[c.Position] = c
+		}
+	}
+	fmt.Print(i18n.T("duplicates.positions"))
+	text, _ := readAnswer()
+	seen := map[int]bool{}
+	for _, f := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' }) {
+		pos, err := strconv.Atoi(strings.TrimPrefix(f, "#"))
+		if err != nil || pos == 0 {
+			continue
+		}
+		c, ok := byPosition[pos]
+		if !ok {
+			fmt.Print(i18n.T("duplicates.notDuplicate", pos))
+			continue
+		}
+		if !seen[pos] {
+			seen[pos] = true
+			chosen = append(chosen, c)
+		}
+	}
+	return chosen
+}
+
+/*
+duplicatesMenu finds the duplicated tracks of one or all the playlists owned by the user, shows them grouped
+and removes the copies chosen by the user
+Returns an error, if present
+*/
+func duplicatesMenu() error {
+	choice, err := chooseOption(i18n.T("duplicates.where"), i18n.T("common.backToMenu"), i18n.T("duplicates.inPlaylist"), i18n.T("duplicates.inAll"))
+	if err != nil {
+		return err
+	}
+
+	var playlists []api.SimplePlaylist
+	switch choice {
+	case 1:
+		selected, err := selectPlaylist(i18n.T("duplicates.selectPlaylist"))
+		if err != nil || selected == nil {
+			return err
+		}
+		playlists = append(playlists, *selected)
+	case 2:
+		pl, err := spotify.GetPlaylists()
+		if err != nil {
+			log.Error(i18n.T("log.playlistsError"), "error", err, "userID", userID)
+			return err
+		}
+		for _, p := range pl {
+			if p.Owner.ID == userID {
+				playlists = append(playlists, p)
+			}
+		}
+	default:
+		return nil
+	}
+
+	fmt.Print(i18n.T("duplicates.near"))
+	near, _ := readAnswer()
+	utils.ClearTerminal()
+
+	for _, p := range playlists {
+		fmt.Print(i18n.T("duplicates.searching", p.Name))
+		groups, snapshotID, err := findDuplicates(p.ID, near != i18n.T("answer.yes"))
+		if err != nil {
+			fmt.Println(i18n.T("common.tracksError"), err)
+			continue
+		}
+		printDuplicates(p.Name, groups)
+		if len(groups) == 0 {
+			continue
+		}
+
+		keepOutput()
+		action, err := chooseOption(i18n.T("duplicates.action", p.Name), i18n.T("duplicates.keep"),
+			i18n.T("duplicates.removeCopies", len(duplicateExtras(groups))),
+			i18n.T("duplicates.choose"))
+		if err != nil {
+			return err
+		}
+		var remove []backup.Change
+		switch action {
+		case 1:
+			remove = duplicateExtras(groups)
+		case 2:
+			remove = choosePositions(groups)
+		}
+		if len(remove) == 0 {
+			continue
+		}
+		err = removeDuplicates(p.ID, p.Name, snapshotID, remove)
+		if err != nil {
+			fmt.Println(i18n.T("duplicates.removeError"), err)
+		}
+	}
+	return nil
+}
+
+// duplicatesCommand finds the duplicates of a playlist (or of all the owned playlists), exits with 1 if duplicates are found and not removed
+func duplicatesCommand(fs *flag.FlagSet, args []string) int {
+	all := fs.Bool("all", false, i18n.T("flag.duplicates.all"))
+	exact := fs.Bool("exact", false, i18n.T("flag.duplicates.exact"))
+	remove := fs.Bool("remove", false, i18n.T("flag.duplicates.remove"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if (*all && fs.NArg() != 0) || (!*all && fs.NArg() != 1) {
+		fs.Usage()
+		return ExitError
+	}
+	if !authCommand() {
+		return ExitError
+	}
+
+	var playlists []api.SimplePlaylist
+	if *all {
+		owner, err := spotify.GetUserID()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("common.userError"), err)
+			return ExitError
+		}
+		pl, err := spotify.GetPlaylists()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("common.playlistsError"), err)
+			return ExitError
+		}
+		for _, p := range pl {
+			if p.Owner.ID == owner {
+				playlists = append(playlists, p)
+			}
+		}
+	} else {
+		id, ok := playlistArg(fs.Arg(0))
+		if !ok {
+			return ExitError
+		}
+		full, err := spotify.GetPlaylist(id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("selection.playlistNotFound"), err)
+			return ExitError
+		}
+		playlists = append(playlists, full.SimplePlaylist)
+	}
+
+	code := ExitOK
+	for _, p := range playlists {
+		groups, snapshotID, err := findDuplicates(p.ID, *exact)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("common.tracksError"), err)
+			return ExitError
+		}
+		printDuplicates(p.Name, groups)
+		if len(groups) == 0 {
+			continue
+		}
+		if !*remove {
+			code = ExitChanges
+			continue
+		}
+		err = removeDuplicates(p.ID, p.Name, snapshotID, duplicateExtras(groups))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("duplicates.removeError"), err)
+			return ExitError
+		}
+	}
+	return code
+}
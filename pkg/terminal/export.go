@@ -0,0 +1,171 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/export"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+exportMenu asks the user to choose a live playlist or a backup and exports it to one of the supported formats
+Returns an error, if present
+*/
+func exportMenu() error {
+	choice, err := chooseOption(i18n.T("export.what"), i18n.T("common.backToMenu"), i18n.T("export.live"), i18n.T("export.backup"))
+	if err != nil {
+		return err
+	}
+
+	var p backup.Playlist
+	switch choice {
+	case 1:
+		selected, err := selectPlaylists(i18n.T("export.selectPlaylists"))
+		if err != nil || selected == nil {
+			return err
+		}
+		what := i18n.T("export.count", len(selected))
+		if len(selected) == 1 {
+			what = "'" + selected[0].Name + "'"
+		}
+		format, err := chooseFormat(what)
+		if err != nil || format == "" {
+			return err
+		}
+		utils.ClearTerminal()
+		for _, s := range selected {
+			p, err := livePlaylist(s.ID, s.Name)
+			if err != nil {
+				fmt.Println(i18n.T("export.errorPlaylist", s.Name), err)
+				continue
+			}
+			err = writeExport(p, format)
+			if err != nil {
+				fmt.Println(i18n.T("export.errorPlaylist", s.Name), err)
+			}
+		}
+		return nil
+	case 2:
+		f, err := selectBackupFile(userID)
+		if err != nil || f == nil {
+			return err
+		}
+		p = f.Playlist
+	default:
+		return nil
+	}
+	utils.ClearTerminal()
+	return exportPlaylist(p)
+}
+
+/*
+selectPlaylist shows the playlists of the user and asks to choose one
+Returns the selected playlist (nil if the user cancelled) and an error, if present
+*/
+func selectPlaylist(title string) (*api.SimplePlaylist, error) {
+	pl, err := spotify.GetPlaylists()
+	if err != nil {
+		log.Error(i18n.T("log.playlistsError"), "error", err, "userID", userID)
+		return nil, err
+	}
+	utils.ClearTerminal()
+	selected, _, err := choosePlaylist(title, pl)
+	return selected, err
+}
+
+/*
+selectPlaylists shows the playlists of the user and asks to choose one or more of them (see choosePlaylists)
+Returns the selected playlists (nil if the user cancelled) and an error, if present
+*/
+func selectPlaylists(title string) ([]api.SimplePlaylist, error) {
+	pl, err := spotify.GetPlaylists()
+	if err != nil {
+		log.Error(i18n.T("log.playlistsError"), "error", err, "userID", userID)
+		return nil, err
+	}
+	utils.ClearTerminal()
+	selected, _, err := choosePlaylists(title, pl)
+	return selected, err
+}
+
+/*
+livePlaylist returns the current state of a playlist on Spotify, with the details of the tracks
+Returns the playlist and an error, if present
+*/
+func livePlaylist(id api.ID, name string) (p backup.Playlist, err error) {
+	fmt.Print(i18n.T("export.fetching", name))
+	p, err = spotify.GetPlaylistSnapshot(id, name)
+	if err != nil {
+		log.Error(i18n.T("log.playlistError"), "error", err, "playlistID", id)
+	}
+	return p, err
+}
+
+// exportCommand exports a backup file or a live playlist to M3U8, XSPF, JSPF or CSV
+func exportCommand(fs *flag.FlagSet, args []string) int {
+	format := fs.String("format", string(export.FormatM3U8), i18n.T("flag.export.format"))
+	out := fs.String("out", "", i18n.T("flag.export.out"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return ExitError
+	}
+	f, err := export.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return ExitError
+	}
+
+	// The argument is a backup file if it exists, otherwise the ID of a playlist on Spotify
+	var p backup.Playlist
+	if _, statErr := os.Stat(fs.Arg(0)); statErr == nil {
+		p, err = backup.Read(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cmd.backupReadError"), err)
+			return ExitError
+		}
+	} else {
+		if !authCommand() {
+			return ExitError
+		}
+		id, ok := playlistArg(fs.Arg(0))
+		if !ok {
+			return ExitError
+		}
+		full, err := spotify.GetPlaylist(id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("selection.playlistNotFound"), err)
+			return ExitError
+		}
+		p, err = spotify.GetPlaylistSnapshot(id, full.Name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("common.playlistError"), err)
+			return ExitError
+		}
+	}
+
+	var path string
+	if *out == "" {
+		path, err = export.ToFile(p, f)
+	} else {
+		path, err = export.ToPath(p, f, *out)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("export.error"), err)
+		return ExitError
+	}
+	log.Info(i18n.T("log.exported"), "playlistName", p.Name, "format", f, "path", path)
+	fmt.Println(i18n.T("export.done"), path)
+	return ExitOK
+}
@@ -0,0 +1,253 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/export"
+	"playlist-manager/internal/link"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// importMinScore is the default confidence under which a track found by search must be confirmed before being imported
+const importMinScore = 0.8
+
+/*
+matchFile reads a playlist from a file (CSV, M3U, XSPF or JSPF) and searches its tracks on Spotify, showing the progress
+Returns the playlist read, the matches and an error, if present
+*/
+func matchFile(path string) (p backup.Playlist, matches []spotify.Match, err error) {
+	p, err = export.ReadFile(path)
+	if err != nil {
+		return p, nil, err
+	}
+	log.Info(i18n.T("log.importRead"), "file", path, "playlistName", p.Name, "tracks", len(p.Items))
+	matches, err = spotify.MatchTracks(p.Items, func(done int, total int) {
+		fmt.Print(i18n.T("import.searching", done, total))
+	})
+	fmt.Println()
+	return p, matches, err
+}
+
+// printMatch prints a track of the file and the track found on Spotify, with the confidence
+func printMatch(i int, m spotify.Match) {
+	fmt.Printf("%d. 📄 %s\n", i+1, m.Source)
+	if m.Found() {
+		fmt.Printf("   🎧 %s (%s, %.0f%%)\n", backupTrackString(m.Track), i18n.T(m.Method), m.Score*100)
+	}
+}
+
+// backupTrackString returns the title and the artists of a track returned by the API, in the same form used for the backups
+func backupTrackString(t *api.FullTrack) string {
+	artists := make([]string, 0, len(t.Artists))
+	for _, a := range t.Artists {
+		artists = append(artists, a.Name)
+	}
+	return backup.Track{Name: t.Name, Artists: artists}.String()
+}
+
+/*
+importReport divides the matches in the tracks to import (found with a confidence of at least minScore),
+the ones with low confidence and the ones not found
+Returns the IDs to import, in the order of the file, and the indexes of the low confidence and not found tracks
+*/
+func importReport(matches []spotify.Match, minScore float64) (ids []api.ID, low []int, missing []int) {
+	for i, m := range matches {
+		switch {
+		case !m.Found():
+			missing = append(missing, i)
+		case m.Score < minScore:
+			low = append(low, i)
+		default:
+			ids = append(ids, m.Track.ID)
+		}
+	}
+	return ids, low, missing
+}
+
+/*
+reviewMatches asks the user to confirm the tracks found with low confidence and to enter the Spotify link of the tracks not found
+Returns the IDs to import, in the order of the file
+*/
+func reviewMatches(matches []spotify.Match, minScore float64) []api.ID {
+	_, low, missing := importReport(matches, minScore)
+	accepted := map[int]api.ID{}
+	for i, m := range matches {
+		if m.Found() && m.Score >= minScore {
+			accepted[i] = m.Track.ID
+		}
+	}
+
+	if len(low) > 0 {
+		fmt.Print(i18n.N("import.lowScore", len(low), len(low), minScore*100))
+		fmt.Println("=======================================")
+	}
+	for _, i := range low {
+		printMatch(i, matches[i])
+		fmt.Print(i18n.T("import.right"))
+		confirm, _ := readAnswer()
+		if confirm == i18n.T("answer.yes") {
+			accepted[i] = matches[i].Track.ID
+		}
+	}
+
+	if len(missing) > 0 {
+		fmt.Print(i18n.N("import.notFound", len(missing), len(missing)))
+		fmt.Println("=======================================")
+	}
+	for _, i := range missing {
+		printMatch(i, matches[i])
+		fmt.Print(i18n.T("import.link"))
+		text, _ := readAnswer()
+		if id, err := link.ParseKind(text, link.Track); err == nil {
+			accepted[i] = id
+		} else if text != "0" {
+			fmt.Println("⚠️", err, i18n.T("import.skipped"))
+		}
+	}
+
+	ids := []api.ID{}
+	for i := range matches {
+		if id, ok := accepted[i]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+/*
+importMenu asks the path of a file, searches its tracks on Spotify, lets the user review the uncertain ones
+and adds them to a new playlist or to an existing one
+Returns an error, if present
+*/
+func importMenu() error {
+	fmt.Print(i18n.T("import.path"))
+	// The paths dragged into the terminal are quoted
+	path, _ := readAnswer()
+	path = strings.Trim(path, `"'`)
+
+	p, matches, err := matchFile(path)
+	if err != nil {
+		log.Error(i18n.T("log.importFileError"), "error", err, "file", path)
+		fmt.Println(i18n.T("import.fileError"), err)
+		return nil
+	}
+	ids := reviewMatches(matches, importMinScore)
+	utils.ClearTerminal()
+	fmt.Print(i18n.T("import.summary", p.Name, len(matches), len(ids)))
+	if len(ids) == 0 {
+		return nil
+	}
+
+	title := i18n.T("import.where", len(ids))
+	choice, err := chooseOption(title, i18n.T("common.cancel"), i18n.T("import.new", p.Name), i18n.T("restore.existing"))
+	if err != nil {
+		return err
+	}
+
+	var dest api.ID
+	destName := p.Name
+	switch choice {
+	case 1:
+		created, err := spotify.CreatePlaylist(p.Name, i18n.T("import.description")+path)
+		if err != nil {
+			log.Error(i18n.T("log.createPlaylistError"), "error", err, "playlistName", p.Name)
+			fmt.Println(i18n.T("common.createPlaylistError"), err)
+			return nil
+		}
+		dest = created.ID
+	case 2:
+		selected, err := selectPlaylist(i18n.T("import.selectPlaylist"))
+		if err != nil || selected == nil {
+			return err
+		}
+		dest, destName = selected.ID, selected.Name
+	default:
+		return nil
+	}
+
+	_, err = spotify.AddTracksToPlaylist(ids, dest)
+	if err != nil {
+		log.Error(i18n.T("log.importAddError"), "error", err, "playlistID", dest)
+		fmt.Println(i18n.T("common.addTracksError"), err)
+		return nil
+	}
+	log.Info(i18n.T("log.imported"), "file", path, "playlistID", dest, "imported", len(ids), "total", len(matches))
+	fmt.Print(i18n.T("import.done", len(ids), len(matches), destName))
+	return nil
+}
+
+// importCommand imports a CSV, M3U, XSPF or JSPF file into a new or an existing playlist, exits with 1 if some tracks are not imported
+func importCommand(fs *flag.FlagSet, args []string) int {
+	playlistID := fs.String("playlist", "", i18n.T("flag.import.playlist"))
+	name := fs.String("name", "", i18n.T("flag.import.name"))
+	minScore := fs.Float64("min-score", importMinScore, i18n.T("flag.import.minScore"))
+	dryRun := fs.Bool("dry-run", false, i18n.T("flag.import.dryRun"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return ExitError
+	}
+	if !authCommand() {
+		return ExitError
+	}
+
+	p, matches, err := matchFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("import.fileError"), err)
+		return ExitError
+	}
+	ids, low, missing := importReport(matches, *minScore)
+	for _, i := range low {
+		fmt.Print(i18n.T("import.lowScoreSkipped"))
+		printMatch(i, matches[i])
+	}
+	for _, i := range missing {
+		fmt.Print(i18n.T("import.notFoundItem"))
+		printMatch(i, matches[i])
+	}
+	fmt.Print(i18n.T("import.summaryCommand", p.Name, len(matches), len(ids), len(low), len(missing)))
+
+	if !*dryRun && len(ids) > 0 {
+		var dest api.ID
+		if *playlistID != "" {
+			id, ok := playlistArg(*playlistID)
+			if !ok {
+				return ExitError
+			}
+			dest = id
+		} else {
+			if *name != "" {
+				p.Name = *name
+			}
+			created, err := spotify.CreatePlaylist(p.Name, i18n.T("import.description")+fs.Arg(0))
+			if err != nil {
+				fmt.Fprintln(os.Stderr, i18n.T("common.createPlaylistError"), err)
+				return ExitError
+			}
+			dest = created.ID
+		}
+		_, err = spotify.AddTracksToPlaylist(ids, dest)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("common.addTracksError"), err)
+			return ExitError
+		}
+		log.Info(i18n.T("log.imported"), "file", fs.Arg(0), "playlistID", dest, "imported", len(ids), "total", len(matches))
+		fmt.Print(i18n.T("import.doneCommand", len(ids), dest))
+	}
+	if len(low) > 0 || len(missing) > 0 {
+		return ExitChanges
+	}
+	return ExitOK
+}
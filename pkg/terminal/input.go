@@ -0,0 +1,48 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+	"strings"
+)
+
+/*
+readInput reads a line from the standard input through utils.Stdin, without the end of line and the spaces around it:
+the answers are read a line at a time, reading a single word would leave the rest of the line (\n, or \r\n on Windows) to the next prompt
+Returns the line and io.EOF if there are no more lines
+*/
+func readInput() (string, error) {
+	line, err := utils.Stdin.ReadString('\n')
+	if err == io.EOF && line != "" {
+		// Last line without end of line
+		err = nil
+	}
+	return strings.TrimSpace(line), err
+}
+
+// readLine reads a line from the standard input, empty if the user just pressed enter or there are no more lines
+func readLine() string {
+	line, _ := readInput()
+	return line
+}
+
+/*
+readAnswer reads the first line that is not empty, so pressing enter without writing anything asks again
+Returns the answer and io.EOF if there are no more lines
+*/
+func readAnswer() (string, error) {
+	for {
+		line, err := readInput()
+		if err != nil || line != "" {
+			return line, err
+		}
+	}
+}
+
+// pressEnter waits for the user to press enter before going back to the menu
+func pressEnter() {
+	fmt.Print(i18n.T("menu.pressEnter"))
+	readLine()
+}
@@ -0,0 +1,48 @@
+package terminal
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"playlist-manager/pkg/utils"
+)
+
+func TestReadInput(t *testing.T) {
+	previous := utils.Stdin
+	t.Cleanup(func() { utils.Stdin = previous })
+	tests := []struct {
+		name   string
+		input  string
+		answer bool // True to read with readAnswer, otherwise with readLine
+		want   []string
+	}{
+		{name: "unix lines", input: "1\nname\n", want: []string{"1", "name"}},
+		{name: "windows lines", input: "1\r\nname\r\n", want: []string{"1", "name"}},
+		{name: "empty line", input: "\nname\n", want: []string{"", "name"}},
+		{name: "last line without end of line", input: "s", want: []string{"s", ""}},
+		{name: "answer skips empty lines", input: "\n\r\n  2 \n3\n", answer: true, want: []string{"2", "3"}},
+		{name: "answer at the end", input: "\n", answer: true, want: []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utils.Stdin = bufio.NewReader(strings.NewReader(tt.input))
+			for _, want := range tt.want {
+				var got string
+				if tt.answer {
+					var err error
+					got, err = readAnswer()
+					if want == "" && err != io.EOF {
+						t.Errorf("readAnswer error = %v, want io.EOF", err)
+					}
+				} else {
+					got = readLine()
+				}
+				if got != want {
+					t.Errorf("read %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
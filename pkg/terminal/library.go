@@ -0,0 +1,107 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"time"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+backupLibrary saves the liked songs, the saved albums and the followed artists of the user, with the same layout of the playlists
+Returns an error, if present
+*/
+func backupLibrary(userID string) error {
+	for _, kind := range backup.LibraryKinds() {
+		fmt.Print(i18n.T("library.saving", kind.Name()))
+		p, err := spotify.GetLibrary(kind)
+		if err != nil {
+			log.Error(i18n.T("log.libraryError"), "error", err, "kind", kind, "userID", userID)
+			fmt.Println(i18n.T("library.permissions"))
+			return err
+		}
+		location, unchanged, err := backup.Save(userID, true, p, time.Now())
+		if err != nil {
+			log.Error(i18n.T("log.librarySaveError"), "error", err, "kind", kind, "userID", userID)
+			return err
+		}
+		log.Info(i18n.T("log.librarySaved"), "kind", kind, "items", len(p.TrackIDs), "unavailable", p.Unavailable, "location", location, "unchanged", unchanged, "userID", userID)
+		if unchanged {
+			fmt.Print(i18n.T("library.unchanged", kind.Name(), location))
+		} else {
+			fmt.Print(i18n.T("library.saved", kind.Name(), len(p.TrackIDs), location))
+		}
+		if p.Unavailable > 0 {
+			fmt.Print(i18n.T("backup.unavailableItems", p.Unavailable))
+		}
+	}
+	return nil
+}
+
+/*
+restoreLibrary adds the content of a library backup to the library of the user, showing the progress
+Returns an error, if present
+*/
+func restoreLibrary(p backup.Playlist) error {
+	fmt.Print(i18n.T("library.restoring", p.DisplayName(), len(p.TrackIDs)))
+	res, err := spotify.RestoreLibrary(p, func(done int, total int) {
+		fmt.Print(i18n.T("library.added", done, total))
+	})
+	fmt.Println()
+	if err != nil {
+		log.Error(i18n.T("log.libraryRestoreError"), "error", err, "kind", p.Kind)
+		return err
+	}
+	fmt.Print(i18n.T("library.restored", p.DisplayName(), res.Restored, res.Present))
+	return nil
+}
+
+// libraryCommand saves the library of the user or restores a library backup
+func libraryCommand(fs *flag.FlagSet, args []string) int {
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	backupMode := fs.NArg() == 1 && fs.Arg(0) == "backup"
+	if !backupMode && (fs.NArg() != 2 || fs.Arg(0) != "restore") {
+		fs.Usage()
+		return ExitError
+	}
+
+	var p backup.Playlist
+	if !backupMode {
+		p, err = backup.Read(fs.Arg(1))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cmd.backupReadError"), err)
+			return ExitError
+		}
+		if !p.IsLibrary() {
+			fmt.Fprint(os.Stderr, i18n.T("library.notLibrary", p.Name))
+			return ExitError
+		}
+	}
+	if !authCommand() {
+		return ExitError
+	}
+
+	if backupMode {
+		userID, err := spotify.GetUserID()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("common.userError"), err)
+			return ExitError
+		}
+		err = backupLibrary(userID)
+	} else {
+		err = restoreLibrary(p)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("common.error"), err)
+		return ExitError
+	}
+	return ExitOK
+}
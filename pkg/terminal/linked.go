@@ -1,12 +1,16 @@
 package terminal
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/selection"
 	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/tui"
 	"playlist-manager/pkg/utils"
+	"slices"
 
 	"github.com/savioxavier/termlink"
 	spotifyapi "github.com/zmb3/spotify/v2"
@@ -30,14 +34,14 @@ func getTrackDetails(trackIDs []spotifyapi.ID) ([]TrackDetails, error) {
 	// Usa la nuova funzione dell'API Spotify
 	spotifyTracks, err := spotify.GetTrackDetails(trackIDs)
 	if err != nil {
-		log.Warn("Errore nel recupero dettagli tracce dall'API Spotify", "error", err)
+		log.Warn(i18n.T("log.trackDetailsError"), "error", err)
 		// In caso di errore, restituisce i dettagli base con gli ID
 		var details []TrackDetails
 		for _, id := range trackIDs {
 			details = append(details, TrackDetails{
 				ID:     string(id),
 				Name:   string(id),
-				Artist: "Informazioni non disponibili",
+				Artist: i18n.T("linked.noInfo"),
 			})
 		}
 		return details, nil
@@ -54,7 +58,7 @@ func getTrackDetails(trackIDs []spotifyapi.ID) ([]TrackDetails, error) {
 		for _, artist := range track.Artists {
 			artists = append(artists, artist.Name)
 		}
-		artistsStr := "Artista sconosciuto"
+		artistsStr := i18n.T("linked.unknownArtist")
 		if len(artists) > 0 {
 			if len(artists) == 1 {
 				artistsStr = artists[0]
@@ -69,7 +73,7 @@ func getTrackDetails(trackIDs []spotifyapi.ID) ([]TrackDetails, error) {
 
 		details = append(details, TrackDetails{
 			ID:     string(track.ID),
-			Name:   track.Name + " di " + artistsStr,
+			Name:   track.Name + i18n.T("track.by") + artistsStr,
 			Artist: artistsStr,
 		})
 	}
@@ -98,29 +102,23 @@ type linkedPlaylist struct {
 }
 
 func linkedMenu() (err error) {
-	options := []string{"Visualizza le playlist collegate",
-		"Aggiungi una playlist collegata",
-		"Rimuovi una playlist collegata",
-		"Aggiorna le canzoni nelle playlist collegate",
+	options := []string{
+		i18n.T("linked.menu.show"),
+		i18n.T("linked.menu.add"),
+		i18n.T("linked.menu.remove"),
+		i18n.T("linked.menu.sync"),
 	}
 
 	for {
-		fmt.Println("========================================================")
-		fmt.Println("🎧 Playlist Manager " + VERSION + " 🎧\n✨ Sviluppato da " + termlink.ColorLink("Matteo Lombardi", "https://github.com/matteolomba", "italic yellow") + " ✨")
-		fmt.Println("========================================================")
-		displayAuthStatus()
-		fmt.Println("========================================================")
-		fmt.Println("🔗 -> Menù Playlist Collegate <- 🔗")
-		fmt.Println("========================================================")
-		fmt.Printf("🔙 0. Torna al menu principale\n")
-		optionEmojis := []string{"👁️", "➕", "🗑️", "🔄"}
-		for i, o := range options {
-			fmt.Printf("%s %d. %s\n", optionEmojis[i], i+1, o)
+		// The header is shown in the title of the full-screen menu
+		if !tui.Enabled() {
+			fmt.Println("========================================================")
+			fmt.Println("🎧 Playlist Manager " + VERSION + i18n.T("menu.developedBy") + termlink.ColorLink("Matteo Lombardi", "https://github.com/matteolomba", "italic yellow") + " ✨")
+			fmt.Println("========================================================")
+			displayAuthStatus()
+			fmt.Println("========================================================")
 		}
-		fmt.Println("========================================================")
-		fmt.Print("❓ Cosa vuoi fare? ")
-		var sel int
-		_, err := fmt.Scan(&sel)
+		sel, err := chooseOption(menuTitle(i18n.T("linked.menu.title")), i18n.T("linked.backToMain"), options...)
 		if err != nil {
 			return err
 		}
@@ -129,7 +127,7 @@ func linkedMenu() (err error) {
 
 		switch sel {
 		case 0:
-			fmt.Println("🔙 Ritorno al menu principale...")
+			fmt.Println(i18n.T("linked.returning"))
 			return nil
 		case 1: // Show linked playlists
 			utils.ClearTerminal()
@@ -158,12 +156,23 @@ func linkedMenu() (err error) {
 			if err != nil {
 				return err
 			}
+			// The smart playlists are evaluated again on every sync, as their sources may have changed
+			smart, err := loadSmartPlaylists()
+			if err != nil {
+				return err
+			}
+			if len(smart) > 0 {
+				fmt.Println()
+				err = syncSmartPlaylists()
+				if err != nil {
+					return err
+				}
+			}
 
 		default:
-			fmt.Println("❌ Scelta non valida o non ancora implementata")
+			fmt.Println(i18n.T("menu.invalidChoice"))
 		}
-		fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-		fmt.Scanf("\n\n")
+		pressEnter()
 		utils.ClearTerminal()
 	}
 }
@@ -178,13 +187,13 @@ func showLinkedPlaylists() (err error) {
 
 	if len(files) == 0 {
 		fmt.Println("===========================================")
-		fmt.Println("🔗 -> Lista delle Playlist Collegate <- 🔗")
+		fmt.Println(i18n.T("linked.listTitle"))
 		fmt.Println("===========================================")
 		fmt.Println()
-		fmt.Println("🕵️ Nessuna playlist collegata, aggiungine una!")
+		fmt.Println(i18n.T("linked.noneAddOne"))
 	} else {
 		fmt.Println("===========================================")
-		fmt.Println("🔗 -> Lista delle Playlist Collegate <- 🔗")
+		fmt.Println(i18n.T("linked.listTitle"))
 		fmt.Println("===========================================")
 		for i, f := range files {
 			//Read file
@@ -202,16 +211,16 @@ func showLinkedPlaylists() (err error) {
 
 			//Print playlist info in a formatted way
 			fmt.Printf("\n🔗 %d. %s\n", i+1, tempPl.Name)
-			fmt.Printf("   📄 File: %s\n", f.Name())
+			fmt.Print(i18n.T("linked.file", f.Name()))
 
 			// Print origin playlists
-			fmt.Println("   📥 Origine:")
+			fmt.Println(i18n.T("linked.origin"))
 			for _, origin := range tempPl.Origin {
 				fmt.Printf("      ↪ %s\n", origin.Name)
 			}
 
 			// Print destination playlists
-			fmt.Println("   🎯 Destinazione:")
+			fmt.Println(i18n.T("linked.destination"))
 			for _, dest := range tempPl.Destination {
 				fmt.Printf("      ↪ %s\n", dest.Name)
 			}
@@ -226,21 +235,28 @@ func showLinkedPlaylists() (err error) {
 	return nil
 }
 
+// appendPlaylists adds the selected playlists to the list, skipping the ones already in it
+func appendPlaylists(list []Playlist, selected []spotifyapi.SimplePlaylist) []Playlist {
+	for _, p := range selected {
+		if !slices.ContainsFunc(list, func(l Playlist) bool { return l.ID == string(p.ID) }) {
+			list = append(list, Playlist{ID: string(p.ID), Name: p.Name})
+		}
+	}
+	return list
+}
+
 func addLinkedPlaylist() (err error) {
 	utils.ClearTerminal()
 	fmt.Println("==============================================")
-	fmt.Println("➕ -> Aggiunta Nuova Playlist Collegata <- ➕")
+	fmt.Println(i18n.T("linked.addTitle"))
 	fmt.Println("==============================================")
 	fmt.Println()
 
 	lp := linkedPlaylist{}
-	fmt.Print("🎵 Che nome vuoi dare al collegamento tra le playlist? ")
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		if scanner.Text() != "" {
-			lp.Name = scanner.Text()
-			break
-		}
+	fmt.Print(i18n.T("linked.name"))
+	lp.Name, err = readAnswer()
+	if err != nil {
+		return err
 	}
 
 	pl, err := spotify.GetPlaylists()
@@ -251,37 +267,24 @@ func addLinkedPlaylist() (err error) {
 	//Origin playlist selection
 	for {
 		utils.ClearTerminal()
-		fmt.Println("=============================================================")
-		fmt.Println("🎯 -> Seleziona la playlist da aggiungere come origine <- 🎯")
-		fmt.Println("=============================================================")
-		fmt.Println()
-		fmt.Println("🚪 0. Annulla e torna indietro")
-		for i, p := range pl {
-			fmt.Printf("📋 %d. %s - %s\n", i+1, p.Name, p.ID)
-		}
-		fmt.Println("=============================================================")
-		fmt.Print("⏎ Inserisci il numero della playlist da aggiungere come origine: ")
-		var sel int
-		_, err = fmt.Scan(&sel)
+		selected, cancelled, err := choosePlaylists(i18n.T("linked.selectOrigin"), pl)
 		if err != nil {
 			return err
 		}
 
-		if sel == 0 {
+		if cancelled {
 			break
-		} else if sel < 1 || sel > len(pl) {
-			fmt.Println("Selezione non valida")
-		} else {
-			lp.Origin = append(lp.Origin, Playlist{ID: string(pl[sel-1].ID), Name: pl[sel-1].Name})
+		} else if selected != nil {
+			lp.Origin = appendPlaylists(lp.Origin, selected)
 
 			if len(lp.Origin) >= 2 {
-				fmt.Print("Vuoi aggiungere un'altra playlist come origine? (s/n) ")
+				fmt.Print(i18n.T("linked.anotherOrigin"))
 				var sel string
-				_, err = fmt.Scan(&sel)
+				sel, err = readAnswer()
 				if err != nil {
 					return err
 				}
-				if sel != "s" {
+				if sel != i18n.T("answer.yes") {
 					break
 				}
 			}
@@ -290,43 +293,30 @@ func addLinkedPlaylist() (err error) {
 
 	// Verifica se l'utente ha annullato senza configurare playlist origin
 	if len(lp.Origin) == 0 {
-		fmt.Println("🚪 Operazione annullata dall'utente")
+		fmt.Println(i18n.T("common.cancelled"))
 		return nil
 	}
 
 	//Destination playlist selection
 	for {
 		utils.ClearTerminal()
-		fmt.Println("==================================================================")
-		fmt.Println("🎯 -> Seleziona la playlist da aggiungere come destinazione <- 🎯")
-		fmt.Println("==================================================================")
-		fmt.Println()
-		fmt.Println("🚪 0. Annulla e torna indietro")
-		for i, p := range pl {
-			fmt.Printf("📋 %d. %s - %s\n", i+1, p.Name, p.ID)
-		}
-		fmt.Println("==================================================================")
-		fmt.Print("⏎ Inserisci il numero della playlist da aggiungere come destinazione: ")
-		var sel int
-		_, err = fmt.Scan(&sel)
+		selected, cancelled, err := choosePlaylists(i18n.T("linked.selectDestination"), pl)
 		if err != nil {
 			return err
 		}
 
-		if sel == 0 {
+		if cancelled {
 			break
-		} else if sel < 1 || sel > len(pl) {
-			fmt.Println("❌ Selezione non valida")
-		} else {
-			lp.Destination = append(lp.Destination, Playlist{ID: string(pl[sel-1].ID), Name: pl[sel-1].Name})
+		} else if selected != nil {
+			lp.Destination = appendPlaylists(lp.Destination, selected)
 
-			fmt.Print("❓ Vuoi aggiungere un'altra playlist come destinazione? (s/n) ")
+			fmt.Print(i18n.T("linked.anotherDestination"))
 			var sel string
-			_, err = fmt.Scan(&sel)
+			sel, err = readAnswer()
 			if err != nil {
 				return err
 			}
-			if sel != "s" {
+			if sel != i18n.T("answer.yes") {
 				break
 			}
 		}
@@ -334,7 +324,7 @@ func addLinkedPlaylist() (err error) {
 
 	// Verifica se l'utente ha annullato senza configurare playlist destination
 	if len(lp.Destination) == 0 {
-		fmt.Println("🚪 Operazione annullata dall'utente")
+		fmt.Println(i18n.T("common.cancelled"))
 		return nil
 	}
 
@@ -352,14 +342,14 @@ func addLinkedPlaylist() (err error) {
 		return err
 	}
 
-	fmt.Println("Playlist " + lp.Name + " salvata come data/playlists/" + lp.ID + ".json")
+	fmt.Print(i18n.T("linked.saved", lp.Name, lp.ID))
 	return nil
 }
 
 func removeLinkedPlaylist() (err error) {
 	utils.ClearTerminal()
 	fmt.Println("=========================================")
-	fmt.Println("🗑️ -> Rimozione Playlist Collegata <- 🗑️")
+	fmt.Println(i18n.T("linked.removeTitle"))
 	fmt.Println("=========================================")
 	fmt.Println()
 
@@ -369,13 +359,14 @@ func removeLinkedPlaylist() (err error) {
 	}
 
 	if len(files) == 0 {
-		fmt.Println("❌ Nessuna playlist collegata, aggiungine una!")
+		fmt.Println(i18n.T("linked.none"))
 	} else {
 		fmt.Println("=========================================================")
-		fmt.Println("🎯 -> Seleziona la playlist collegata da rimuovere <- 🎯")
+		fmt.Println(i18n.T("linked.selectRemove"))
 		fmt.Println("=========================================================")
 		fmt.Println()
-		fmt.Println("🚪 0. Annulla e torna indietro")
+		fmt.Println(i18n.T("linked.cancelBack"))
+		items := make([]selection.Item, len(files))
 		for i, f := range files {
 			//Read file
 			tempData, err := os.ReadFile("data/playlists/" + f.Name())
@@ -390,18 +381,20 @@ func removeLinkedPlaylist() (err error) {
 				return err
 			}
 
+			items[i] = selection.Item{Name: tempPl.Name}
+
 			//Print playlist info in a formatted way
 			fmt.Printf("\n🔗 %d. %s\n", i+1, tempPl.Name)
-			fmt.Printf("   📄 File: %s\n", f.Name())
+			fmt.Print(i18n.T("linked.file", f.Name()))
 
 			// Print origin playlists
-			fmt.Println("   📥 Origine:")
+			fmt.Println(i18n.T("linked.origin"))
 			for _, origin := range tempPl.Origin {
 				fmt.Printf("      ↪ %s\n", origin.Name)
 			}
 
 			// Print destination playlists
-			fmt.Println("   🎯 Destinazione:")
+			fmt.Println(i18n.T("linked.destination"))
 			for _, dest := range tempPl.Destination {
 				fmt.Printf("      ↪ %s\n", dest.Name)
 			}
@@ -409,26 +402,35 @@ func removeLinkedPlaylist() (err error) {
 		}
 
 		fmt.Println("======================================================")
-		fmt.Print("⏎ Inserisci il numero della playlist collegata da rimuovere: ")
-		var sel int
-		_, err = fmt.Scan(&sel)
+		fmt.Println(i18n.T(listSelectionHint))
+		fmt.Print(i18n.T("linked.removeSelection"))
+		sel, cancelled, err := scanSelection(items)
 		if err != nil {
 			return err
 		}
 
-		if sel == 0 {
-			fmt.Println("🚪 Operazione annullata dall'utente")
+		if cancelled {
+			fmt.Println(i18n.T("common.cancelled"))
 			return nil
-		} else if sel < 1 || sel > len(files) {
-			fmt.Println("❌ Selezione non valida")
-			fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-			fmt.Scanf("\n\n")
+		} else if sel == nil {
+			pressEnter()
 		} else {
-			err = os.Remove("data/playlists/" + files[sel-1].Name())
-			if err != nil {
-				return err
+			// More links are removed only after a confirmation
+			if len(sel) > 1 {
+				fmt.Print(i18n.T("linked.removeConfirm", len(sel)))
+				confirm, _ := readAnswer()
+				if confirm != i18n.T("answer.yes") {
+					fmt.Println(i18n.T("common.cancelled"))
+					return nil
+				}
+			}
+			for _, i := range sel {
+				err = os.Remove("data/playlists/" + files[i].Name())
+				if err != nil {
+					return err
+				}
+				fmt.Print(i18n.T("linked.removedFile", files[i].Name()))
 			}
-			fmt.Println("✅ Playlist data/playlists/" + files[sel-1].Name() + " rimossa con successo")
 		}
 
 	}
@@ -436,73 +438,67 @@ func removeLinkedPlaylist() (err error) {
 }
 
 func updateLinkedPlaylists() (err error) {
-	log.Info("Inizio aggiornamento playlist collegate")
+	log.Info(i18n.T("log.syncStart"))
 
 	playlists := []linkedPlaylist{}
 
 	//Get files
 	files, err := os.ReadDir("data/playlists")
 	if err != nil {
-		log.Error("Errore lettura directory playlist", "error", err)
+		log.Error(i18n.T("log.linkedDirError"), "error", err)
 		return err
 	}
-	log.Info("Playlist collegate trovate", "count", len(files))
+	log.Info(i18n.T("log.linkedFound"), "count", len(files))
 
 	utils.ClearTerminal()
 	fmt.Println("=============================================")
-	fmt.Println("🔄 -> Aggiornamento Playlist Collegate <- 🔄")
+	fmt.Println(i18n.T("linked.syncTitle"))
 	fmt.Println("=============================================")
 	fmt.Println()
 
 	if len(files) == 0 {
-		fmt.Println("❌ Nessuna playlist collegata, aggiungine una!")
-		log.Warn("Nessuna playlist collegata trovata")
+		fmt.Println(i18n.T("linked.none"))
+		log.Warn(i18n.T("log.linkedNone"))
 		return nil
 	}
 
 	// Menu per scegliere il tipo di operazione
-	fmt.Println("🎯 Che tipo di aggiornamento vuoi eseguire?")
-	fmt.Println("==========================================")
-	fmt.Println("➕ 1. Solo aggiunta canzoni (da origine a destinazione)")
-	fmt.Println("🗑️ 2. Solo rimozione canzoni (dalle destinazioni)")
-	fmt.Println("🔄 3. Sia aggiungere che rimuovere canzoni")
-	fmt.Println("🚪 0. Annulla e torna indietro")
-	fmt.Println("==========================================")
-	fmt.Print("❓ Cosa vuoi fare? ")
-
-	var operationType int
-	_, err = fmt.Scan(&operationType)
+	operationType, err := chooseOption(i18n.T("linked.syncType"), i18n.T("linked.cancelBackShort"),
+		i18n.T("linked.syncAdd"),
+		i18n.T("linked.syncRemove"),
+		i18n.T("linked.syncBoth"),
+	)
 	if err != nil {
 		return err
 	}
 
 	if operationType == 0 {
-		fmt.Println("🚪 Operazione annullata dall'utente")
+		fmt.Println(i18n.T("common.cancelled"))
 		return nil
 	}
 
 	if operationType < 1 || operationType > 3 {
-		fmt.Println("❌ Scelta non valida")
+		fmt.Println(i18n.T("common.invalidChoice"))
 		return nil
 	}
 
 	addSongs := operationType == 1 || operationType == 3
 	removeSongs := operationType == 2 || operationType == 3
 
-	log.Info("Tipo di operazione selezionata", "operationType", operationType, "addSongs", addSongs, "removeSongs", removeSongs)
+	log.Info(i18n.T("log.syncType"), "operationType", operationType, "addSongs", addSongs, "removeSongs", removeSongs)
 
 	utils.ClearTerminal()
 	fmt.Println("=============================================")
-	fmt.Println("🔄 -> Aggiornamento Playlist Collegate <- 🔄")
+	fmt.Println(i18n.T("linked.syncTitle"))
 	fmt.Println("=============================================")
 	fmt.Println()
 
 	for _, f := range files {
-		log.Info("Caricamento playlist collegata", "file", f.Name())
+		log.Info(i18n.T("log.linkedLoading"), "file", f.Name())
 		//Read file
 		tempData, err := os.ReadFile("data/playlists/" + f.Name())
 		if err != nil {
-			log.Error("Errore lettura file playlist", "file", f.Name(), "error", err)
+			log.Error(i18n.T("log.linkedReadError"), "file", f.Name(), "error", err)
 			return err
 		}
 
@@ -510,16 +506,18 @@ func updateLinkedPlaylists() (err error) {
 		var tempPl linkedPlaylist
 		err = json.Unmarshal(tempData, &tempPl)
 		if err != nil {
-			log.Error("Errore parsing JSON playlist", "file", f.Name(), "error", err)
+			log.Error(i18n.T("log.linkedParseError"), "file", f.Name(), "error", err)
 			return err
 		}
-		log.Info("Playlist collegata caricata", "name", tempPl.Name, "id", tempPl.ID, "origins", len(tempPl.Origin), "destinations", len(tempPl.Destination))
+		log.Info(i18n.T("log.linkedLoaded"), "name", tempPl.Name, "id", tempPl.ID, "origins", len(tempPl.Origin), "destinations", len(tempPl.Destination))
 		playlists = append(playlists, tempPl)
 	}
 
+	progress := tui.NewProgress(i18n.T("linked.progress"), len(playlists))
 	for _, pl := range playlists {
+		progress.NextLine(pl.Name)
 		fmt.Println("┌──────────────────────────────────────────────────────────────────────────────────────────")
-		fmt.Printf("│ 🎧 Playlist: %s (%s)\n", pl.Name, pl.ID)
+		fmt.Print(i18n.T("linked.playlist", pl.Name, pl.ID))
 
 		//Print linked playlist info (origin + destination)
 		plString := "\"" + pl.Origin[0].Name + "\""
@@ -532,145 +530,135 @@ func updateLinkedPlaylists() (err error) {
 		}
 		fmt.Printf("│ 🔗 %s\n", plString)
 		fmt.Println("│")
-		fmt.Printf("│ ⏳ Elaborazione in corso...\n")
+		fmt.Print(i18n.T("linked.processing"))
 		fmt.Println("├──────────────────────────────────────────────────────────────────────────────────────────")
 
 		//-> Get tracks from origin playlists
 		var originTracks []spotifyapi.ID
-		log.Info("Inizio recupero tracce da playlist origine", "linkedPlaylistName", pl.Name, "originCount", len(pl.Origin))
+		log.Info(i18n.T("log.originStart"), "linkedPlaylistName", pl.Name, "originCount", len(pl.Origin))
 
 		for _, p := range pl.Origin {
-			log.Info("Recupero tracce da playlist origine", "playlistName", p.Name, "playlistID", p.ID)
+			log.Info(i18n.T("log.originFetch"), "playlistName", p.Name, "playlistID", p.ID)
 			//Get tracks from origin playlist
 			tracks, err := spotify.GetTrackIDs(spotifyapi.ID(p.ID))
 			if err != nil {
-				log.Error("Errore nel recupero tracce da playlist origine", "playlistName", p.Name, "playlistID", p.ID, "error", err)
+				log.Error(i18n.T("log.originError"), "playlistName", p.Name, "playlistID", p.ID, "error", err)
 				return err
 			}
-			log.Info("Tracce recuperate da playlist origine", "playlistName", p.Name, "trackCount", len(tracks))
+			log.Info(i18n.T("log.originFetched"), "playlistName", p.Name, "trackCount", len(tracks))
 			originTracks = append(originTracks, tracks...)
 		}
-		log.Info("Totale tracce origine recuperate", "totalTracks", len(originTracks))
+		log.Info(i18n.T("log.originTotal"), "totalTracks", len(originTracks))
 
 		//-> Compare tracks with destination playlists
 		for _, p := range pl.Destination {
-			log.Info("Inizio processamento playlist destinazione", "playlistName", p.Name, "playlistID", p.ID)
+			log.Info(i18n.T("log.destinationStart"), "playlistName", p.Name, "playlistID", p.ID)
 			//Get tracks from destination playlist
 			destTracks, err := spotify.GetTrackIDs(spotifyapi.ID(p.ID))
 			if err != nil {
-				log.Error("Errore nel recupero tracce da playlist destinazione", "playlistName", p.Name, "playlistID", p.ID, "error", err)
+				log.Error(i18n.T("log.destinationError"), "playlistName", p.Name, "playlistID", p.ID, "error", err)
 				return err
 			}
-			log.Info("Tracce recuperate da playlist destinazione", "playlistName", p.Name, "trackCount", len(destTracks))
+			log.Info(i18n.T("log.destinationFetched"), "playlistName", p.Name, "trackCount", len(destTracks))
 
 			//Get tracks that are only in the origin playlists (is the track in the destination playlist?)
-			var tracksToAdd []spotifyapi.ID
-			for _, t := range originTracks {
-				found := false
-				for _, dt := range destTracks {
-					if t == dt {
-						found = true
-						break
-					}
+			tracksToAdd := backup.Difference(originTracks, destTracks)
+			log.Info(i18n.T("log.toAdd"), "playlistName", p.Name, "tracksToAddCount", len(tracksToAdd))
+
+			// The tracks not playable in the market of the user are replaced by their playable version (or a track with the same ISRC).
+			// A substitute already in the destination is kept and not added again
+			resolutions, err := spotify.ResolveTrackIDs(tracksToAdd)
+			if err != nil {
+				log.Error(i18n.T("log.availabilityTracksError"), "playlistName", p.Name, "error", err)
+				return err
+			}
+			keepTracks := slices.Clone(originTracks)
+			var changed []spotify.Resolution
+			tracksToAdd = nil
+			for _, r := range resolutions {
+				if !r.Found() {
+					changed = append(changed, r)
+					continue
 				}
-				if !found {
-					tracksToAdd = append(tracksToAdd, t)
+				keepTracks = append(keepTracks, r.Track.ID)
+				if r.Substituted() {
+					if slices.Contains(destTracks, r.Track.ID) {
+						continue
+					}
+					changed = append(changed, r)
 				}
+				tracksToAdd = append(tracksToAdd, r.Track.ID)
 			}
-			log.Info("Tracce da aggiungere identificate", "playlistName", p.Name, "tracksToAddCount", len(tracksToAdd))
+			log.Info(i18n.T("log.availabilityChecked"), "playlistName", p.Name, "tracksToAddCount", len(tracksToAdd), "changed", len(changed))
+
+			// Snapshot ID of the last version of the destination playlist, so the removal is made on the version returned by the addition
+			snapshotID := ""
 
 			//Add songs to destination playlists
 			if addSongs {
 				if len(tracksToAdd) == 0 {
-					fmt.Printf("│ ❌ Nessuna canzone da aggiungere a %s\n", p.Name)
-					log.Info("Nessuna traccia da aggiungere", "playlistName", p.Name)
+					fmt.Print(i18n.T("linked.nothingToAdd", p.Name))
+					log.Info(i18n.T("log.nothingToAdd"), "playlistName", p.Name)
 				} else {
 					// Mostra l'operazione in corso
-					if len(tracksToAdd) == 1 {
-						fmt.Printf("│ ⏳ Aggiunta di 1 canzone a %s...\n", p.Name)
-					} else {
-						fmt.Printf("│ ⏳ Aggiunta di %d canzoni a %s...\n", len(tracksToAdd), p.Name)
-					}
+					fmt.Print(i18n.N("linked.adding", len(tracksToAdd), len(tracksToAdd), p.Name))
 
-					log.Info("Inizio aggiunta tracce alla playlist", "playlistName", p.Name, "playlistID", p.ID, "tracksCount", len(tracksToAdd))
-					err = spotify.AddTracksToPlaylist(tracksToAdd, spotifyapi.ID(p.ID))
+					log.Info(i18n.T("log.addStart"), "playlistName", p.Name, "playlistID", p.ID, "tracksCount", len(tracksToAdd))
+					snapshotID, err = spotify.AddTracksToPlaylist(tracksToAdd, spotifyapi.ID(p.ID))
 					if err != nil {
-						log.Error("ERRORE nell'aggiunta tracce alla playlist", "playlistName", p.Name, "playlistID", p.ID, "error", err, "tracksCount", len(tracksToAdd))
+						log.Error(i18n.T("log.addError"), "playlistName", p.Name, "playlistID", p.ID, "error", err, "tracksCount", len(tracksToAdd))
 						return err
 					}
-					log.Info("Tracce aggiunte con successo", "playlistName", p.Name, "tracksCount", len(tracksToAdd))
+					log.Info(i18n.T("log.added"), "playlistName", p.Name, "tracksCount", len(tracksToAdd))
 
 					// Mostra il risultato completato
-					if len(tracksToAdd) == 1 {
-						fmt.Printf("│ ✅ Aggiunta 1 canzone a %s\n", p.Name)
-					} else {
-						fmt.Printf("│ ✅ Aggiunte %d canzoni a %s\n", len(tracksToAdd), p.Name)
-					}
+					fmt.Print(i18n.N("linked.addedSongs", len(tracksToAdd), len(tracksToAdd), p.Name))
 
 					// Mostra l'elenco delle canzoni aggiunte
-					fmt.Printf("│     🎵 Canzoni aggiunte:\n")
+					fmt.Print(i18n.T("linked.addedList"))
 					trackDetails, err := getTrackDetails(tracksToAdd)
 					if err != nil {
-						log.Warn("Errore nel recupero dettagli tracce aggiunte", "error", err)
+						log.Warn(i18n.T("log.addedDetailsError"), "error", err)
 					} else {
 						for _, track := range trackDetails {
 							fmt.Printf("│       ↪ %s\n", track.Name)
 						}
 					}
 				}
+				printResolutions(changed, "│ ")
 			} else {
-				log.Info("Aggiunta canzoni saltata per scelta utente", "playlistName", p.Name)
+				log.Info(i18n.T("log.addSkipped"), "playlistName", p.Name)
 			}
 
-			//Get tracks that are only in the destination playlists (is the track in the origin playlist?)
-			var tracksToRemove []spotifyapi.ID
-			for _, dt := range destTracks {
-				found := false
-				for _, t := range originTracks {
-					if dt == t {
-						found = true
-						break
-					}
-				}
-				if !found {
-					tracksToRemove = append(tracksToRemove, dt)
-				}
-			}
-			log.Info("Tracce da rimuovere identificate", "playlistName", p.Name, "tracksToRemoveCount", len(tracksToRemove))
+			//Get tracks that are only in the destination playlists (is the track, or its substitute, in the origin playlist?)
+			tracksToRemove := backup.Difference(destTracks, keepTracks)
+			log.Info(i18n.T("log.toRemove"), "playlistName", p.Name, "tracksToRemoveCount", len(tracksToRemove))
 
 			//Remove songs from destination playlists
 			if removeSongs {
 				if len(tracksToRemove) == 0 {
-					fmt.Printf("│ ❌ Nessuna canzone da rimuovere da %s\n", p.Name)
-					log.Info("Nessuna traccia da rimuovere", "playlistName", p.Name)
+					fmt.Print(i18n.T("linked.nothingToRemove", p.Name))
+					log.Info(i18n.T("log.nothingToRemove"), "playlistName", p.Name)
 				} else {
 					// Mostra l'operazione in corso
-					if len(tracksToRemove) == 1 {
-						fmt.Printf("│ ⏳ Rimozione di 1 canzone da %s...\n", p.Name)
-					} else {
-						fmt.Printf("│ ⏳ Rimozione di %d canzoni da %s...\n", len(tracksToRemove), p.Name)
-					}
+					fmt.Print(i18n.N("linked.removing", len(tracksToRemove), len(tracksToRemove), p.Name))
 
-					log.Info("Inizio rimozione tracce dalla playlist", "playlistName", p.Name, "playlistID", p.ID, "tracksCount", len(tracksToRemove))
-					err = spotify.RemoveTracksFromPlaylist(tracksToRemove, spotifyapi.ID(p.ID))
+					log.Info(i18n.T("log.removeStart"), "playlistName", p.Name, "playlistID", p.ID, "tracksCount", len(tracksToRemove))
+					snapshotID, err = spotify.RemoveTracksFromPlaylist(tracksToRemove, spotifyapi.ID(p.ID), snapshotID)
 					if err != nil {
-						log.Error("ERRORE nella rimozione tracce dalla playlist", "playlistName", p.Name, "playlistID", p.ID, "error", err, "tracksCount", len(tracksToRemove))
+						log.Error(i18n.T("log.removeError"), "playlistName", p.Name, "playlistID", p.ID, "error", err, "tracksCount", len(tracksToRemove))
 						return err
 					}
-					log.Info("Tracce rimosse con successo", "playlistName", p.Name, "tracksCount", len(tracksToRemove))
+					log.Info(i18n.T("log.removed"), "playlistName", p.Name, "tracksCount", len(tracksToRemove), "snapshotID", snapshotID)
 
 					// Mostra il risultato completato
-					if len(tracksToRemove) == 1 {
-						fmt.Printf("│ ✅ Rimossa 1 canzone da %s\n", p.Name)
-					} else {
-						fmt.Printf("│ ✅ Rimosse %d canzoni da %s\n", len(tracksToRemove), p.Name)
-					}
+					fmt.Print(i18n.N("linked.removedSongs", len(tracksToRemove), len(tracksToRemove), p.Name))
 
 					// Mostra l'elenco delle canzoni rimosse
-					fmt.Printf("│    🎵 Canzoni rimosse:\n")
+					fmt.Print(i18n.T("linked.removedList"))
 					trackDetails, err := getTrackDetails(tracksToRemove)
 					if err != nil {
-						log.Warn("Errore nel recupero dettagli tracce rimosse", "error", err)
+						log.Warn(i18n.T("log.removedDetailsError"), "error", err)
 					} else {
 						for _, track := range trackDetails {
 							fmt.Printf("│       ↪ %s\n", track.Name)
@@ -678,7 +666,7 @@ func updateLinkedPlaylists() (err error) {
 					}
 				}
 			} else {
-				log.Info("Rimozione canzoni saltata per scelta utente", "playlistName", p.Name)
+				log.Info(i18n.T("log.removeSkipped"), "playlistName", p.Name)
 			}
 		}
 
@@ -686,10 +674,11 @@ func updateLinkedPlaylists() (err error) {
 		fmt.Println("└──────────────────────────────────────────────────────────────────────────────────────────")
 		fmt.Println()
 	}
+	progress.Done()
 
 	fmt.Println("╔════════════════════════════════════════════════════════════╗")
-	fmt.Println("║               ✅ AGGIORNAMENTO COMPLETATO ✅               ║")
-	fmt.Println("║     Tutte le playlist collegate sono state aggiornate!     ║")
+	fmt.Println(i18n.T("linked.completed"))
+	fmt.Println(i18n.T("linked.allUpdated"))
 	fmt.Println("╚════════════════════════════════════════════════════════════╝")
 	return nil
 }
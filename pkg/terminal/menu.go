@@ -0,0 +1,185 @@
+package terminal
+
+import (
+	"fmt"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/tui"
+	"strconv"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+/*
+chooseOption shows a menu: with the full-screen interface a list to navigate with the arrows, otherwise the numbered options.
+Each option starts with its icon, as back, the option to go back (number 0)
+Returns the number of the chosen option (from 1, 0 to go back, -1 if the answer is not a number) and an error, if present
+*/
+func chooseOption(title string, back string, options ...string) (int, error) {
+	if tui.Enabled() {
+		items := make([]tui.Item, 0, len(options)+1)
+		for _, o := range options {
+			items = append(items, tui.Item{Title: o})
+		}
+		items = append(items, tui.Item{Title: back})
+		res, ok, err := tui.List{Title: title, Items: items}.Run()
+		if err != nil || !ok || len(res.Indexes) == 0 || res.Indexes[0] == len(options) {
+			return 0, err
+		}
+		return res.Indexes[0] + 1, nil
+	}
+
+	fmt.Println("\n" + title)
+	fmt.Println("=======================================")
+	for i, o := range options {
+		icon, text, _ := strings.Cut(o, " ")
+		fmt.Printf("%s %d. %s\n", icon, i+1, text)
+	}
+	icon, text, _ := strings.Cut(back, " ")
+	fmt.Printf("%s 0. %s\n", icon, text)
+	fmt.Print(i18n.T("menu.choice"))
+	answer, err := readAnswer()
+	if err != nil {
+		return 0, err
+	}
+	// A stray answer is not valid, but doesn't end the program
+	choice, err := strconv.Atoi(answer)
+	if err != nil {
+		return -1, nil
+	}
+	return choice, nil
+}
+
+// playlistItem returns a playlist as an item of the full-screen list, with its details
+func playlistItem(p api.SimplePlaylist) tui.Item {
+	owner := p.Owner.DisplayName
+	if owner == "" {
+		owner = p.Owner.ID
+	}
+	visibility := i18n.T("playlist.private")
+	switch {
+	case p.Collaborative:
+		visibility = i18n.T("playlist.collaborative")
+	case p.IsPublic:
+		visibility = i18n.T("playlist.public")
+	}
+	icon := "🎵"
+	if p.Owner.ID != userID {
+		icon = "👥"
+	}
+	detail := []string{
+		"🎵 " + p.Name,
+		"",
+		i18n.T("playlist.owner") + owner,
+		i18n.T("playlist.tracks", p.Tracks.Total),
+		i18n.T("playlist.visibility") + visibility,
+		"🆔 " + string(p.ID),
+	}
+	if url := p.ExternalURLs["spotify"]; url != "" {
+		detail = append(detail, "🔗 "+url)
+	}
+	if p.Description != "" {
+		detail = append(detail, "", "📝 "+p.Description)
+	}
+	return tui.Item{Title: icon + " " + p.Name, Detail: detail}
+}
+
+// keepOutput waits for enter with the full-screen interface, so the user can read the output before the next list covers it
+func keepOutput() {
+	if tui.Enabled() {
+		fmt.Print(i18n.T("menu.pressEnterContinue"))
+		readLine()
+	}
+}
+
+/*
+choosePlaylist asks to choose a playlist of pl: with the full-screen interface in a list with the details, otherwise by its number.
+In both cases also the link, the URI or the ID of a playlist not in pl can be entered
+Returns the chosen playlist (nil if the user cancelled or the choice is not valid), true if the user cancelled and an error, if present
+*/
+func choosePlaylist(title string, pl []api.SimplePlaylist) (*api.SimplePlaylist, bool, error) {
+	if tui.Enabled() {
+		items := make([]tui.Item, len(pl))
+		for i, p := range pl {
+			items[i] = playlistItem(p)
+		}
+		res, ok, err := tui.List{Title: title, Items: items, Hint: i18n.T(linkHint)}.Run()
+		if err != nil || !ok {
+			return nil, !ok, err
+		}
+		if res.Query != "" {
+			return playlistFromLink(res.Query), false, nil
+		}
+		return &pl[res.Indexes[0]], false, nil
+	}
+
+	fmt.Println("\n" + title + ":")
+	fmt.Println("=========================")
+	for i, p := range pl {
+		fmt.Printf("🎵 %d. %s - %s\n", i+1, p.Name, p.ID)
+	}
+	fmt.Println(i18n.T("menu.cancel"))
+	fmt.Println(i18n.T(linkHint))
+	fmt.Print(i18n.T("menu.playlistNumber"))
+	return scanPlaylistChoice(pl)
+}
+
+/*
+choosePlaylists asks to choose one or more playlists of pl: with the full-screen interface in a list with the details, selecting them with Tab,
+otherwise with a selection (see parsePlaylistSelection). In both cases also the links, the URIs or the IDs of playlists not in pl can be entered
+Returns the chosen playlists (nil if the user cancelled or the selection is not valid), true if the user cancelled and an error, if present
+*/
+func choosePlaylists(title string, pl []api.SimplePlaylist) ([]api.SimplePlaylist, bool, error) {
+	if tui.Enabled() {
+		items := make([]tui.Item, len(pl))
+		for i, p := range pl {
+			items[i] = playlistItem(p)
+		}
+		hint := i18n.T("menu.tuiSelectionHint")
+		res, ok, err := tui.List{Title: title, Items: items, Multi: true, Hint: hint}.Run()
+		if err != nil || !ok {
+			return nil, !ok, err
+		}
+		if res.Query != "" {
+			return parsePlaylistSelection(res.Query, pl), false, nil
+		}
+		selected := make([]api.SimplePlaylist, len(res.Indexes))
+		for i, index := range res.Indexes {
+			selected[i] = pl[index]
+		}
+		return selected, false, nil
+	}
+
+	fmt.Println("\n" + title + ":")
+	fmt.Println("=========================")
+	for i, p := range pl {
+		fmt.Printf("🎵 %d. %s - %s\n", i+1, p.Name, p.ID)
+	}
+	fmt.Println(i18n.T("menu.cancel"))
+	fmt.Println(i18n.T(selectionHint))
+	fmt.Println(i18n.T(linkHint))
+	fmt.Print(i18n.T("menu.playlistsSelection"))
+	return scanPlaylistSelection(pl)
+}
+
+/*
+browse shows a long list of lines (for example the tracks of a playlist): with the full-screen interface in a list to scroll and filter,
+otherwise printed all together
+Returns an error, if present
+*/
+func browse(title string, lines []string) error {
+	if !tui.Enabled() {
+		fmt.Println("\n" + title)
+		fmt.Println("=======================================")
+		for _, l := range lines {
+			fmt.Println(l)
+		}
+		return nil
+	}
+	items := make([]tui.Item, len(lines))
+	for i, l := range lines {
+		items[i] = tui.Item{Title: l}
+	}
+	_, _, err := tui.List{Title: title, Items: items, Hint: i18n.T("menu.browseHint")}.Run()
+	return err
+}
@@ -0,0 +1,255 @@
+package terminal
+
+import (
+	"fmt"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/export"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/tui"
+	"playlist-manager/pkg/utils"
+
+	"github.com/savioxavier/termlink"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+DisplayOffline shows the menu of the offline mode, that works only on the backups in data/backup
+without authenticating to Spotify and without network
+*/
+func DisplayOffline() (err error) {
+	log.Info(i18n.T("log.startOffline"), "version", VERSION)
+	options := []string{
+		i18n.T("offline.menu.dates"),
+		i18n.T("offline.menu.tracks"),
+		i18n.T("offline.menu.search"),
+		i18n.T("offline.menu.export"),
+		i18n.T("offline.menu.diff"),
+		i18n.T("menu.main.prune"),
+	}
+
+	userID, err = selectBackupUser()
+	if err != nil {
+		return err
+	}
+	if userID == "" {
+		return nil
+	}
+	utils.ClearTerminal()
+
+	for {
+		title := i18n.T("offline.menu.title")
+		if tui.Enabled() {
+			// The header is shown in the title of the full-screen menu
+			title = fmt.Sprintf("%s · 🎧 Playlist Manager %s · 📴 offline · 👤 %s", title, VERSION, userID)
+		} else {
+			fmt.Println("========================================================")
+			fmt.Println("🎧 Playlist Manager " + VERSION + i18n.T("menu.developedBy") + termlink.ColorLink("Matteo Lombardi", "https://github.com/matteolomba", "italic yellow") + " ✨")
+			fmt.Println("========================================================")
+			fmt.Println(i18n.T("offline.mode"))
+			fmt.Println(i18n.T("auth.user"), userID)
+			fmt.Println("========================================================")
+		}
+		sel, err := chooseOption(title, i18n.T("menu.exit"), options...)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+
+		switch sel {
+		case 0:
+			log.Info(i18n.T("log.exit"), "userID", userID)
+			fmt.Println(i18n.T("menu.bye"))
+			return nil
+
+		case 1: // List backup dates
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.offlineDates"), "userID", userID)
+			err = showBackupDates(userID)
+			if err != nil {
+				return err
+			}
+			pressEnter()
+
+		case 2: // Show the tracks of a backed up playlist
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.offlineTracks"), "userID", userID)
+			file, err := selectBackupFile(userID)
+			if err != nil {
+				return err
+			}
+			if file == nil {
+				break
+			}
+			utils.ClearTerminal()
+			printBackupTracks(file.Playlist)
+			pressEnter()
+
+		case 3: // Search in the backups
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.offlineSearch"), "userID", userID)
+			err = searchBackups(userID)
+			if err != nil {
+				return err
+			}
+			pressEnter()
+
+		case 4: // Export a backed up playlist
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.offlineExport"), "userID", userID)
+			file, err := selectBackupFile(userID)
+			if err != nil {
+				return err
+			}
+			if file == nil {
+				break
+			}
+			utils.ClearTerminal()
+			err = exportPlaylist(file.Playlist)
+			if err != nil {
+				return err
+			}
+			pressEnter()
+
+		case 5: // Compare two backups
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.offlineDiff"), "userID", userID)
+			err = diffMenu(false)
+			if err != nil {
+				return err
+			}
+			pressEnter()
+
+		case 6: // Prune old backups
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.prune"), "userID", userID)
+			err = pruneMenu(userID)
+			if err != nil {
+				return err
+			}
+			pressEnter()
+
+		default:
+			log.Warn(i18n.T("log.invalidChoice"), "selection", sel, "userID", userID)
+			fmt.Println(i18n.T("menu.invalidChoice"))
+		}
+		utils.ClearTerminal()
+	}
+}
+
+/*
+selectBackupUser asks the user which of the users found in data/backup to use, if there is only one it is selected automatically
+Returns the user ID, empty if there are no backups or the user cancelled, and an error, if present
+*/
+func selectBackupUser() (string, error) {
+	users, err := backup.Users()
+	if err != nil {
+		log.Error(i18n.T("log.backupDirError"), "error", err)
+		return "", err
+	}
+
+	switch len(users) {
+	case 0:
+		log.Warn(i18n.T("log.offlineNoBackups"))
+		fmt.Println(i18n.T("offline.noBackupsIn") + backup.Dir)
+		return "", nil
+	case 1:
+		return users[0], nil
+	}
+
+	options := make([]string, len(users))
+	for i, u := range users {
+		options[i] = "👤 " + u
+	}
+	sel, err := chooseOption(i18n.T("offline.whichUser"), i18n.T("menu.exit"), options...)
+	if err != nil {
+		return "", err
+	}
+	if sel < 1 || sel > len(users) {
+		return "", nil
+	}
+	return users[sel-1], nil
+}
+
+// showBackupDates prints the dates of the backups of the user, with the number of playlists saved in each one
+func showBackupDates(userID string) error {
+	for _, personal := range []bool{true, false} {
+		dates, err := backup.UserDates(userID, personal)
+		if err != nil {
+			log.Error(i18n.T("log.backupDirsError"), "error", err, "userID", userID, "isPersonal", personal)
+			return err
+		}
+
+		if personal {
+			fmt.Println(i18n.T("offline.personal"))
+		} else {
+			fmt.Println(i18n.T("offline.others"))
+		}
+		fmt.Println("=======================================")
+		if len(dates) == 0 {
+			fmt.Println(i18n.T("offline.noBackups"))
+			continue
+		}
+		for _, d := range dates {
+			files, err := backup.DateFiles(userID, personal, d)
+			if err != nil {
+				return err
+			}
+			fmt.Print(i18n.N("offline.dateLine", len(files), d, len(files)))
+		}
+	}
+	return nil
+}
+
+// exportPlaylist asks the format and exports the playlist to the data/export folder
+func exportPlaylist(p backup.Playlist) error {
+	format, err := chooseFormat("'" + p.DisplayName() + "'")
+	if err != nil || format == "" {
+		return err
+	}
+	err = writeExport(p, format)
+	if err != nil {
+		fmt.Println(i18n.T("export.error"), err)
+	}
+	return nil
+}
+
+/*
+chooseFormat asks the format in which to export what is described
+Returns the format (empty if the user cancelled or the choice is not valid) and an error, if present
+*/
+func chooseFormat(what string) (export.Format, error) {
+	formats := export.Formats()
+	options := make([]string, len(formats))
+	for i, f := range formats {
+		options[i] = "📄 " + string(f)
+	}
+	sel, err := chooseOption(i18n.T("offline.format", what), i18n.T("common.cancel"), options...)
+	if err != nil {
+		return "", err
+	}
+	if sel == 0 {
+		return "", nil
+	}
+	if sel < 1 || sel > len(formats) {
+		fmt.Println(i18n.T("selection.invalid"))
+		return "", nil
+	}
+	return formats[sel-1], nil
+}
+
+/*
+writeExport exports the playlist to the data/export folder, printing the file
+Returns an error, if present
+*/
+func writeExport(p backup.Playlist, format export.Format) error {
+	path, err := export.ToFile(p, format)
+	if err != nil {
+		log.Error(i18n.T("log.exportError"), "error", err, "playlistName", p.Name, "format", format)
+		return err
+	}
+	log.Info(i18n.T("log.exported"), "playlistName", p.Name, "format", format, "path", path)
+	fmt.Println(i18n.T("export.done"), path)
+	return nil
+}
@@ -0,0 +1,203 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/tui"
+	"playlist-manager/pkg/utils"
+	"strings"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+askSearch asks the user the text to search, the field and if the similar texts should be found too
+Returns the text (empty if the user cancelled) and the options
+*/
+func askSearch() (string, backup.SearchOptions) {
+	fmt.Print(i18n.T("search.what"))
+	query := readLine()
+	if query == "" {
+		return "", backup.SearchOptions{}
+	}
+	var opts backup.SearchOptions
+	// All the fields are the option 0, chosen also going back
+	field, _ := chooseOption(i18n.T("search.where"), i18n.T("search.allFields"), i18n.T("search.title"), i18n.T("search.artists"), i18n.T("search.album"), i18n.T("search.isrc"))
+	if field >= 1 && field <= len(backup.SearchFields()) {
+		opts.Field = backup.SearchFields()[field-1]
+	}
+	fmt.Print(i18n.T("search.fuzzy"))
+	fuzzy, _ := readAnswer()
+	opts.Fuzzy = fuzzy == i18n.T("answer.yes")
+	return query, opts
+}
+
+/*
+searchLive looks for the query in all the playlists of the user on Spotify
+Returns the matches and an error, if present
+*/
+func searchLive(query string, opts backup.SearchOptions) (matches []backup.Match, err error) {
+	pl, err := spotify.GetPlaylists()
+	if err != nil {
+		log.Error(i18n.T("log.playlistsError"), "error", err, "userID", userID)
+		return nil, err
+	}
+	progress := tui.NewProgress(i18n.T("search.progress"), len(pl))
+	for _, p := range pl {
+		progress.Next(p.Name)
+		snapshot, err := spotify.GetPlaylistSnapshot(p.ID, p.Name)
+		if err != nil {
+			log.Error(i18n.T("log.playlistError"), "error", err, "playlistID", p.ID)
+			return nil, err
+		}
+		matches = append(matches, backup.SearchPlaylist(snapshot, query, opts)...)
+	}
+	progress.Done()
+	return matches, nil
+}
+
+// printMatches prints the matches grouped by playlist, with the dates of the backups that contain them
+func printMatches(query string, matches []backup.Match) {
+	if len(matches) == 0 {
+		fmt.Print(i18n.T("search.noResults", query))
+		return
+	}
+	groups := backup.GroupMatches(matches)
+	fmt.Print(i18n.N("search.results", len(groups), query, len(groups)))
+	fmt.Println("=======================================")
+	for _, g := range groups {
+		var where []string
+		if g.Live {
+			where = append(where, i18n.T("search.current"))
+		}
+		if len(g.Dates) == 1 {
+			where = append(where, i18n.T("search.backupOf")+g.Dates[0])
+		} else if len(g.Dates) > 1 {
+			where = append(where, i18n.T("search.backups", len(g.Dates), g.Dates[0], g.Dates[len(g.Dates)-1]))
+		}
+		owner := "👤"
+		if !g.Matches[0].Personal && g.Matches[0].Date != "" {
+			owner = "👥"
+		}
+		fmt.Printf("\n%s %s (%s)\n", owner, g.Name, strings.Join(where, ", "))
+		for _, m := range g.Matches {
+			text := fmt.Sprintf("   #%d %s %s", m.Position, itemIcon(m.Track), m.Track)
+			if m.Score < 1 {
+				text += i18n.T("search.similar", m.Score*100)
+			}
+			fmt.Println(text)
+		}
+	}
+}
+
+// searchBackups asks what to search and prints the tracks of the backups that match it, with the playlist and the dates
+func searchBackups(userID string) error {
+	query, opts := askSearch()
+	if query == "" {
+		return nil
+	}
+	matches, err := backup.Search(userID, query, opts)
+	if err != nil {
+		log.Error(i18n.T("log.searchBackupsError"), "error", err, "query", query, "userID", userID)
+		return err
+	}
+	log.Info(i18n.T("log.searchBackupsDone"), "query", query, "matches", len(matches), "userID", userID)
+	printMatches(query, matches)
+	return nil
+}
+
+/*
+searchMenu asks what to search and where (live playlists, backups or both) and prints the results
+Returns an error, if present
+*/
+func searchMenu() error {
+	query, opts := askSearch()
+	if query == "" {
+		fmt.Println(i18n.T("common.cancelled"))
+		return nil
+	}
+	choice, err := chooseOption(i18n.T("search.source"), i18n.T("common.cancel"), i18n.T("search.live"), i18n.T("search.inBackups"), i18n.T("search.both"))
+	if err != nil {
+		return err
+	}
+	if choice < 1 || choice > 3 {
+		return nil
+	}
+	var matches []backup.Match
+	// The backups are read first, so the position of a track is the one in the live playlist when it is in both
+	if choice != 1 {
+		found, err := backup.Search(userID, query, opts)
+		if err != nil {
+			log.Error(i18n.T("log.searchBackupsError"), "error", err, "query", query, "userID", userID)
+			return err
+		}
+		matches = append(matches, found...)
+	}
+	if choice != 2 {
+		utils.ClearTerminal()
+		found, err := searchLive(query, opts)
+		if err != nil {
+			fmt.Println(i18n.T("search.playlistsError"), err)
+			return nil
+		}
+		matches = append(matches, found...)
+	}
+	log.Info(i18n.T("log.searchDone"), "query", query, "field", opts.Field, "fuzzy", opts.Fuzzy, "matches", len(matches), "userID", userID)
+	utils.ClearTerminal()
+	printMatches(query, matches)
+	return nil
+}
+
+// searchCommand searches a text in the live playlists and in the backups, by default in both
+func searchCommand(fs *flag.FlagSet, args []string) int {
+	field := fs.String("field", "", i18n.T("flag.search.field"))
+	fuzzy := fs.Bool("fuzzy", false, i18n.T("flag.search.fuzzy"))
+	live := fs.Bool("live", false, i18n.T("flag.search.live"))
+	backups := fs.Bool("backups", false, i18n.T("flag.search.backups"))
+	user := fs.String("user", "", i18n.T("flag.search.user"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() != 1 || (*live && *backups) {
+		fs.Usage()
+		return ExitError
+	}
+	query := fs.Arg(0)
+	opts := backup.SearchOptions{Field: *field, Fuzzy: *fuzzy}
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return ExitError
+	}
+
+	var matches []backup.Match
+	if !*live {
+		owner, ok := commandUser(*user)
+		if !ok {
+			return ExitError
+		}
+		found, err := backup.Search(owner, query, opts)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("search.backupsError"), err)
+			return ExitError
+		}
+		matches = append(matches, found...)
+	}
+	if !*backups {
+		if !authCommand() {
+			return ExitError
+		}
+		found, err := searchLive(query, opts)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("search.playlistsError"), err)
+			return ExitError
+		}
+		matches = append(matches, found...)
+	}
+	printMatches(query, matches)
+	return ExitOK
+}
@@ -0,0 +1,157 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"playlist-manager/internal/link"
+	"playlist-manager/internal/selection"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"strconv"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// linkHint is the key of the message shown under the lists of playlists, that accept also a playlist not in the list
+const linkHint = "selection.linkHint"
+
+// selectionHint is the key of the message shown under the lists from which more elements can be selected at once
+const selectionHint = "selection.hint"
+
+// listSelectionHint is the key of the message shown under the lists of elements that are not owned by someone, where mine can't be used
+const listSelectionHint = "selection.listHint"
+
+// playlistArgHint is the key of the message shown in the usage of the commands, whose playlists can be given in any of the formats
+const playlistArgHint = "selection.playlistArgHint"
+
+/*
+scanPlaylistChoice reads the choice of a playlist: its number in pl, 0 to cancel, or the link, URI or ID of any playlist.
+If the choice is not valid the reason is shown
+Returns the chosen playlist (nil if the user cancelled or the choice is not valid), true if the user entered 0 and an error, if present
+*/
+func scanPlaylistChoice(pl []api.SimplePlaylist) (selected *api.SimplePlaylist, cancelled bool, err error) {
+	text, err := readAnswer()
+	if err != nil {
+		return nil, false, err
+	}
+	if n, err := strconv.Atoi(text); err == nil {
+		if n == 0 {
+			return nil, true, nil
+		}
+		if n < 1 || n > len(pl) {
+			fmt.Println(i18n.T("selection.invalid"))
+			return nil, false, nil
+		}
+		return &pl[n-1], false, nil
+	}
+	return playlistFromLink(text), false, nil
+}
+
+// playlistFromLink returns the playlist given its link, URI or ID, nil (showing the reason) if it is not valid or not found
+func playlistFromLink(text string) *api.SimplePlaylist {
+	id, err := link.ParseKind(text, link.Playlist)
+	if err != nil {
+		fmt.Println("❌", err)
+		return nil
+	}
+	full, err := spotify.GetPlaylist(id)
+	if err != nil {
+		log.Warn(i18n.T("log.playlistNotFound"), "error", err, "playlistID", id)
+		fmt.Println(i18n.T("selection.playlistNotFound"), err)
+		return nil
+	}
+	return &full.SimplePlaylist
+}
+
+/*
+playlistArg reads the ID of a playlist from an argument of a command: a link, an URI or an ID.
+If it is not valid the error is shown on the standard error
+Returns the ID and false if the argument is not valid
+*/
+func playlistArg(arg string) (api.ID, bool) {
+	id, err := link.ParseKind(arg, link.Playlist)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return "", false
+	}
+	return id, true
+}
+
+/*
+scanPlaylistSelection reads a selection of playlists of pl (see parsePlaylistSelection), 0 to cancel
+Returns the selected playlists (nil if the user cancelled or the selection is not valid), true if the user entered 0 and an error, if present
+*/
+func scanPlaylistSelection(pl []api.SimplePlaylist) (selected []api.SimplePlaylist, cancelled bool, err error) {
+	text, err := readAnswer()
+	if err != nil || text == "0" {
+		return nil, text == "0", err
+	}
+	return parsePlaylistSelection(text, pl), false, nil
+}
+
+/*
+parsePlaylistSelection reads a selection of playlists of pl (see selection.Parse).
+The parts of the selection that are a link, an URI or an ID select also a playlist not in pl. If the selection is not valid the reason is shown
+Returns the selected playlists, nil if the selection is not valid
+*/
+func parsePlaylistSelection(text string, pl []api.SimplePlaylist) []api.SimplePlaylist {
+	// The links are resolved one by one, the rest is a selection of the list
+	var parts []string
+	var selected, linked []api.SimplePlaylist
+	for _, part := range strings.Split(text, ",") {
+		if _, err := link.Parse(part); err != nil {
+			parts = append(parts, part)
+			continue
+		}
+		p := playlistFromLink(part)
+		if p == nil {
+			return nil
+		}
+		linked = append(linked, *p)
+	}
+	if len(parts) > 0 {
+		items := make([]selection.Item, len(pl))
+		for i, p := range pl {
+			items[i] = selection.Item{Name: p.Name, Mine: p.Owner.ID == userID}
+		}
+		indexes, err := selection.Parse(strings.Join(parts, ","), items)
+		if err != nil {
+			fmt.Println("❌", err)
+			return nil
+		}
+		for _, i := range indexes {
+			selected = append(selected, pl[i])
+		}
+	}
+
+	// A playlist selected more times is kept once
+	var res []api.SimplePlaylist
+	seen := map[api.ID]bool{}
+	for _, p := range append(selected, linked...) {
+		if !seen[p.ID] {
+			seen[p.ID] = true
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+/*
+scanSelection reads a selection of items (see selection.Parse), 0 to cancel. If the selection is not valid the reason is shown
+Returns the indexes of the selected items (nil if the user cancelled or the selection is not valid), true if the user entered 0 and an error, if present
+*/
+func scanSelection(items []selection.Item) (indexes []int, cancelled bool, err error) {
+	text, err := readAnswer()
+	if err != nil || text == "0" {
+		return nil, text == "0", err
+	}
+	indexes, err = selection.Parse(text, items)
+	if err != nil {
+		fmt.Println("❌", err)
+		return nil, false, nil
+	}
+	return indexes, false, nil
+}
@@ -0,0 +1,44 @@
+package terminal
+
+import (
+	"slices"
+	"testing"
+
+	api "github.com/zmb3/spotify/v2"
+)
+
+func TestParsePlaylistSelection(t *testing.T) {
+	userID = "me"
+	pl := []api.SimplePlaylist{
+		{ID: "p1", Name: "Rock classics", Owner: api.User{ID: "me"}},
+		{ID: "p2", Name: "Jazz", Owner: api.User{ID: "other"}},
+		{ID: "p3", Name: "Rock 2024", Owner: api.User{ID: "me"}},
+	}
+	tests := []struct {
+		text string
+		want []api.ID // nil if the selection is not valid
+	}{
+		{text: "1", want: []api.ID{"p1"}},
+		{text: "3, 1", want: []api.ID{"p1", "p3"}},
+		{text: "1-3,!2", want: []api.ID{"p1", "p3"}},
+		{text: "mine", want: []api.ID{"p1", "p3"}},
+		{text: "jazz", want: []api.ID{"p2"}},
+		{text: "all,1", want: []api.ID{"p1", "p2", "p3"}},
+		{text: "4", want: nil},
+		{text: "metal", want: nil},
+		// A link to a resource that is not a playlist is not valid, without calling Spotify
+		{text: "1,https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC", want: nil},
+		{text: "spotify:album:4uLU6hMCjMI75M1A2tKUQC", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var got []api.ID
+			for _, p := range parsePlaylistSelection(tt.text, pl) {
+				got = append(got, p.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parsePlaylistSelection(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,512 @@
+package terminal
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/query"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/tui"
+	"playlist-manager/pkg/utils"
+	"slices"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// smartDir is the folder with the definitions of the smart playlists, one JSON file for each
+const smartDir = "data/smart"
+
+/*
+Model that represents a smart playlist
+A smart playlist contains:
+- ID: the ID of the smart playlist (only for that program), also the name of its file
+- Name: the name of the smart playlist (only for that program)
+- Query: the query that chooses and sorts the tracks (see the query package)
+- Library: if the liked songs of the user are part of the sources
+- Sources: the playlists where the tracks are taken from
+- Destination: the playlist that is replaced with the result of the query on every sync
+*/
+type smartPlaylist struct {
+	ID          string
+	Name        string
+	Query       string
+	Library     bool
+	Sources     []Playlist
+	Destination Playlist
+}
+
+/*
+loadSmartPlaylists reads the definitions of the smart playlists from data/smart
+Returns the smart playlists, ordered by file name, and an error, if present
+*/
+func loadSmartPlaylists() ([]smartPlaylist, error) {
+	files, err := os.ReadDir(smartDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var res []smartPlaylist
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(smartDir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var sp smartPlaylist
+		err = json.Unmarshal(data, &sp)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+		res = append(res, sp)
+	}
+	return res, nil
+}
+
+// saveSmartPlaylist writes the definition of a smart playlist in data/smart/<ID>.json
+func saveSmartPlaylist(sp smartPlaylist) (string, error) {
+	err := os.MkdirAll(smartDir, 0755)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(sp, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(smartDir, sp.ID+".json")
+	return path, os.WriteFile(path, data, 0644)
+}
+
+// printSmartPlaylist prints the definition of a smart playlist
+func printSmartPlaylist(i int, sp smartPlaylist) {
+	fmt.Printf("\n🧠 %d. %s\n", i+1, sp.Name)
+	fmt.Print(i18n.T("smart.file", sp.ID))
+	fmt.Printf("   🔎 Query: %s\n", sp.Query)
+	fmt.Println(i18n.T("linked.origin"))
+	if sp.Library {
+		fmt.Println(i18n.T("smart.likedSongs"))
+	}
+	for _, s := range sp.Sources {
+		fmt.Printf("      ↪ %s\n", s.Name)
+	}
+	fmt.Print(i18n.T("smart.destination", sp.Destination.Name))
+}
+
+/*
+smartSources reads the tracks of the sources: the liked songs, if library is true, and the playlists.
+A track in more sources is kept only once, in the first position
+Returns the tracks and an error, if present
+*/
+func smartSources(library bool, sources []Playlist) ([]backup.Track, error) {
+	var tracks []backup.Track
+	if library {
+		p, err := spotify.GetLibrary(backup.KindLikedSongs)
+		if err != nil {
+			log.Error(i18n.T("log.likedSongsError"), "error", err)
+			return nil, err
+		}
+		tracks = append(tracks, p.Items...)
+	}
+	for _, s := range sources {
+		p, err := spotify.GetPlaylistSnapshot(api.ID(s.ID), s.Name)
+		if err != nil {
+			log.Error(i18n.T("log.originPlaylistError"), "error", err, "playlistName", s.Name, "playlistID", s.ID)
+			return nil, err
+		}
+		tracks = append(tracks, p.Items...)
+	}
+	seen := map[string]bool{}
+	unique := tracks[:0]
+	for _, t := range tracks {
+		if !seen[t.Key()] {
+			seen[t.Key()] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique, nil
+}
+
+/*
+evaluateSmart runs the query of a smart playlist on its sources. The local files can't be added to a playlist, so they are skipped
+Returns the resulting tracks and an error, if present
+*/
+func evaluateSmart(sp smartPlaylist) ([]backup.Track, error) {
+	q, err := query.Parse(sp.Query)
+	if err != nil {
+		return nil, err
+	}
+	tracks, err := smartSources(sp.Library, sp.Sources)
+	if err != nil {
+		return nil, err
+	}
+	var res []backup.Track
+	for _, t := range q.Apply(tracks) {
+		if t.Restorable() {
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
+
+// isSource returns true if the playlist with the given ID is one of the sources of the smart playlist
+func (sp smartPlaylist) isSource(id string) bool {
+	return slices.ContainsFunc(sp.Sources, func(p Playlist) bool { return p.ID == id })
+}
+
+/*
+syncSmartPlaylist evaluates a smart playlist and replaces the content of its destination with the result, if it is different
+Returns the number of tracks of the result, if the destination has been changed and an error, if present
+*/
+func syncSmartPlaylist(sp smartPlaylist) (count int, changed bool, err error) {
+	// Every update would read the result of the previous one, a filter or a LIMIT would remove tracks from the source
+	if sp.isSource(sp.Destination.ID) {
+		return 0, false, fmt.Errorf(i18n.T("smart.destinationSource"), sp.Destination.Name)
+	}
+	res, err := evaluateSmart(sp)
+	if err != nil {
+		return 0, false, err
+	}
+	current, err := spotify.GetPlaylistSnapshot(api.ID(sp.Destination.ID), sp.Destination.Name)
+	if err != nil {
+		return 0, false, err
+	}
+	uris := make([]api.URI, 0, len(res))
+	for _, t := range res {
+		uris = append(uris, t.URI)
+	}
+	currentURIs := make([]api.URI, 0, len(current.Items))
+	for _, t := range current.Items {
+		currentURIs = append(currentURIs, t.URI)
+	}
+	if slices.Equal(uris, currentURIs) && current.Unavailable == 0 {
+		return len(uris), false, nil
+	}
+	_, err = spotify.ReplacePlaylistItems(uris, api.ID(sp.Destination.ID))
+	if err != nil {
+		return 0, false, err
+	}
+	log.Info(i18n.T("log.smartSynced"), "name", sp.Name, "playlistID", sp.Destination.ID, "tracks", len(uris), "before", len(currentURIs))
+	return len(uris), true, nil
+}
+
+/*
+syncSmartPlaylists evaluates all the smart playlists and updates their destinations, showing the result of each one
+Returns an error, if present
+*/
+func syncSmartPlaylists() error {
+	playlists, err := loadSmartPlaylists()
+	if err != nil {
+		log.Error(i18n.T("log.smartReadError"), "error", err)
+		return err
+	}
+	if len(playlists) == 0 {
+		fmt.Println(i18n.T("smart.none"))
+		return nil
+	}
+	fmt.Println(i18n.T("smart.syncTitle"))
+	fmt.Println("=============================================")
+	progress := tui.NewProgress(i18n.T("linked.progress"), len(playlists))
+	for _, sp := range playlists {
+		progress.Next(sp.Name + " ➜ " + sp.Destination.Name)
+		count, changed, err := syncSmartPlaylist(sp)
+		switch {
+		case err != nil:
+			log.Error(i18n.T("log.smartSyncError"), "error", err, "name", sp.Name)
+			progress.Println(i18n.T("smart.syncError", sp.Name, err))
+		case changed:
+			progress.Println(i18n.T("smart.synced", sp.Destination.Name, count))
+		default:
+			progress.Println(i18n.T("smart.upToDate", sp.Destination.Name, count))
+		}
+	}
+	progress.Done()
+	return nil
+}
+
+// printQueryHelp prints the syntax of the queries
+func printQueryHelp() {
+	fmt.Println(i18n.T("smart.fields") + strings.Join(query.Fields(), ", "))
+	fmt.Println(i18n.T("smart.operators"))
+	fmt.Println(i18n.T("smart.example"))
+}
+
+/*
+addSmartPlaylist asks the user the name, the query, the sources and the destination of a new smart playlist, saves it and updates it
+Returns an error, if present
+*/
+func addSmartPlaylist() error {
+	fmt.Println("==============================================")
+	fmt.Println(i18n.T("smart.addTitle"))
+	fmt.Println("==============================================")
+	fmt.Println()
+
+	sp := smartPlaylist{ID: utils.RandomString(10)}
+	fmt.Print(i18n.T("smart.name"))
+	sp.Name = readLine()
+	if sp.Name == "" {
+		fmt.Println(i18n.T("common.cancelled"))
+		return nil
+	}
+
+	printQueryHelp()
+	for {
+		fmt.Print(i18n.T("smart.query"))
+		sp.Query = readLine()
+		if sp.Query == "" {
+			fmt.Println(i18n.T("common.cancelled"))
+			return nil
+		}
+		_, err := query.Parse(sp.Query)
+		if err == nil {
+			break
+		}
+		fmt.Println("❌", err)
+	}
+
+	fmt.Print(i18n.T("smart.library"))
+	library, _ := readAnswer()
+	sp.Library = library == i18n.T("answer.yes")
+	for {
+		title := i18n.T("smart.selectOrigin")
+		selected, err := selectPlaylist(title)
+		if err != nil {
+			return err
+		}
+		if selected == nil {
+			break
+		}
+		sp.Sources = append(sp.Sources, Playlist{ID: string(selected.ID), Name: selected.Name})
+	}
+	if !sp.Library && len(sp.Sources) == 0 {
+		fmt.Println(i18n.T("smart.noOrigin"))
+		return nil
+	}
+
+	title := i18n.T("smart.where")
+	choice, err := chooseOption(title, i18n.T("common.cancel"), i18n.T("import.new", sp.Name), i18n.T("restore.existing"))
+	if err != nil {
+		return err
+	}
+	switch choice {
+	case 1:
+		created, err := spotify.CreatePlaylist(sp.Name, i18n.T("smart.description")+sp.Query)
+		if err != nil {
+			log.Error(i18n.T("log.createPlaylistError"), "error", err, "playlistName", sp.Name)
+			fmt.Println(i18n.T("common.createPlaylistError"), err)
+			return nil
+		}
+		sp.Destination = Playlist{ID: string(created.ID), Name: created.Name}
+	case 2:
+		selected, err := selectPlaylist(i18n.T("smart.selectDestination"))
+		if err != nil || selected == nil {
+			return err
+		}
+		if selected.Owner.ID != userID {
+			fmt.Println(i18n.T("smart.notOwner"))
+			return nil
+		}
+		if sp.isSource(string(selected.ID)) {
+			fmt.Println(i18n.T("smart.destIsSource"))
+			return nil
+		}
+		if selected.Tracks.Total > 0 {
+			fmt.Print(i18n.T("smart.replaceConfirm", selected.Name, selected.Tracks.Total))
+			confirm, err := readAnswer()
+			if err != nil {
+				return err
+			}
+			if confirm != i18n.T("answer.yes") {
+				fmt.Println(i18n.T("common.cancelled"))
+				return nil
+			}
+		}
+		sp.Destination = Playlist{ID: string(selected.ID), Name: selected.Name}
+	default:
+		return nil
+	}
+
+	path, err := saveSmartPlaylist(sp)
+	if err != nil {
+		return err
+	}
+	fmt.Println(i18n.T("smart.savedPrefix") + sp.Name + i18n.T("smart.savedAs") + filepath.ToSlash(path))
+	fmt.Println(i18n.T("smart.firstSync"))
+	count, _, err := syncSmartPlaylist(sp)
+	if err != nil {
+		fmt.Println(i18n.T("smart.syncErrorShort"), err)
+		return nil
+	}
+	fmt.Print(i18n.T("smart.syncedLine", sp.Destination.Name, count))
+	return nil
+}
+
+/*
+removeSmartPlaylist asks the user which smart playlist to remove and deletes its definition (the destination playlist is kept)
+Returns an error, if present
+*/
+func removeSmartPlaylist() error {
+	playlists, err := loadSmartPlaylists()
+	if err != nil {
+		return err
+	}
+	if len(playlists) == 0 {
+		fmt.Println(i18n.T("smart.none"))
+		return nil
+	}
+	options := make([]string, len(playlists))
+	for i, sp := range playlists {
+		options[i] = fmt.Sprintf("🧠 %s (%s ➜ %s)", sp.Name, sp.Query, sp.Destination.Name)
+	}
+	sel, err := chooseOption(i18n.T("smart.removeWhich"), i18n.T("linked.cancelBackShort"), options...)
+	if err != nil {
+		return err
+	}
+	if sel == 0 {
+		fmt.Println(i18n.T("common.cancelled"))
+		return nil
+	}
+	if sel < 1 || sel > len(playlists) {
+		fmt.Println(i18n.T("selection.invalid"))
+		return nil
+	}
+	path := filepath.Join(smartDir, playlists[sel-1].ID+".json")
+	err = os.Remove(path)
+	if err != nil {
+		return err
+	}
+	fmt.Print(i18n.T("smart.removed", filepath.ToSlash(path), playlists[sel-1].Destination.Name))
+	return nil
+}
+
+/*
+smartMenu shows the menu of the smart playlists
+Returns an error, if present
+*/
+func smartMenu() error {
+	choice, err := chooseOption(i18n.T("smart.menu.title"), i18n.T("common.backToMenu"),
+		i18n.T("smart.menu.show"),
+		i18n.T("smart.menu.add"),
+		i18n.T("smart.menu.remove"),
+		i18n.T("smart.menu.sync"),
+	)
+	if err != nil {
+		return err
+	}
+	utils.ClearTerminal()
+	switch choice {
+	case 1:
+		playlists, err := loadSmartPlaylists()
+		if err != nil {
+			return err
+		}
+		if len(playlists) == 0 {
+			fmt.Println(i18n.T("smart.noneAddOne"))
+		}
+		for i, sp := range playlists {
+			printSmartPlaylist(i, sp)
+		}
+	case 2:
+		return addSmartPlaylist()
+	case 3:
+		return removeSmartPlaylist()
+	case 4:
+		return syncSmartPlaylists()
+	}
+	return nil
+}
+
+// smartCommand lists or updates the smart playlists, or shows the result of a query without saving it
+func smartCommand(fs *flag.FlagSet, args []string) int {
+	library := fs.Bool("library", false, i18n.T("flag.smart.library"))
+	sources := fs.String("sources", "", i18n.T("flag.smart.sources"))
+	if len(args) == 0 {
+		fs.Usage()
+		return ExitError
+	}
+	// The flags follow the subcommand
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return ExitError
+	}
+	switch args[0] {
+	case "list":
+		playlists, err := loadSmartPlaylists()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("smart.readError"), err)
+			return ExitError
+		}
+		for i, sp := range playlists {
+			printSmartPlaylist(i, sp)
+		}
+		return ExitOK
+	case "sync":
+		if !authCommand() {
+			return ExitError
+		}
+		playlists, err := loadSmartPlaylists()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("smart.readError"), err)
+			return ExitError
+		}
+		code := ExitOK
+		for _, sp := range playlists {
+			count, changed, err := syncSmartPlaylist(sp)
+			if err != nil {
+				fmt.Fprint(os.Stderr, i18n.T("smart.syncErrorLine", sp.Name, err))
+				code = ExitError
+				continue
+			}
+			fmt.Print(i18n.T("smart.syncedCommand", sp.Name, count, changed))
+		}
+		return code
+	case "preview":
+		if fs.NArg() != 1 {
+			fs.Usage()
+			return ExitError
+		}
+		if _, err := query.Parse(fs.Arg(0)); err != nil {
+			fmt.Fprintln(os.Stderr, "❌", err)
+			return ExitError
+		}
+		var src []Playlist
+		for _, arg := range strings.Split(*sources, ",") {
+			if arg = strings.TrimSpace(arg); arg != "" {
+				id, ok := playlistArg(arg)
+				if !ok {
+					return ExitError
+				}
+				src = append(src, Playlist{ID: string(id), Name: arg})
+			}
+		}
+		if !*library && len(src) == 0 {
+			fmt.Fprintln(os.Stderr, i18n.T("smart.originRequired"))
+			return ExitError
+		}
+		if !authCommand() {
+			return ExitError
+		}
+		res, err := evaluateSmart(smartPlaylist{Query: fs.Arg(0), Library: *library, Sources: src})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("smart.queryError"), err)
+			return ExitError
+		}
+		for i, t := range res {
+			fmt.Printf("%s %d. %s\n", itemIcon(t), i+1, t)
+		}
+		fmt.Print(i18n.T("smart.count", len(res)))
+		return ExitOK
+	default:
+		fs.Usage()
+		return ExitError
+	}
+}
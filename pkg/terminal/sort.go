@@ -0,0 +1,198 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// sortPlan is the new order of a playlist and the moves needed to apply it
+type sortPlan struct {
+	tracks     []backup.Track // Items of the playlist in the current order, empty for the ones not available on Spotify
+	order      []int          // Current indexes of the items in the new order
+	moves      []backup.Reorder
+	snapshotID string // Version of the playlist read, the moves are made on it
+}
+
+/*
+planSort reads a playlist from Spotify and sorts it by the given keys. The items not available on Spotify have no details,
+so they are moved to the end keeping their relative order
+Returns the plan and an error, if present
+*/
+func planSort(id api.ID, keys []backup.SortKey) (plan sortPlan, err error) {
+	items, snapshotID, err := spotify.GetPositionedItems(id)
+	if err != nil {
+		log.Error(i18n.T("log.tracksError"), "error", err, "playlistID", id)
+		return plan, err
+	}
+	plan.snapshotID = snapshotID
+	if len(items) > 0 {
+		plan.tracks = make([]backup.Track, items[len(items)-1].Position)
+	}
+	available := make([]bool, len(plan.tracks))
+	var sortable []backup.Track
+	var indexes []int
+	for _, c := range items {
+		plan.tracks[c.Position-1] = c.Track
+		available[c.Position-1] = true
+		sortable = append(sortable, c.Track)
+		indexes = append(indexes, c.Position-1)
+	}
+	for _, i := range backup.SortOrder(sortable, keys) {
+		plan.order = append(plan.order, indexes[i])
+	}
+	for i, ok := range available {
+		if !ok {
+			plan.order = append(plan.order, i)
+		}
+	}
+	plan.moves = backup.ReorderMoves(plan.order)
+	return plan, nil
+}
+
+// printSortPreview prints the new order of the playlist, with the current position of every item
+func printSortPreview(name string, keys []backup.SortKey, plan sortPlan) {
+	names := make([]string, 0, len(keys))
+	for _, k := range keys {
+		names = append(names, k.String())
+	}
+	fmt.Print(i18n.T("sort.title", name, strings.Join(names, ", ")))
+	fmt.Println("=======================================")
+	for i, j := range plan.order {
+		t := plan.tracks[j]
+		text := t.String()
+		if text == "" {
+			text = i18n.T("sort.unavailable")
+		}
+		marker := "  "
+		if i != j {
+			marker = "↕️"
+		}
+		fmt.Printf("%s %d. (#%d) %s\n", marker, i+1, j+1, text)
+	}
+	if len(plan.moves) == 0 {
+		fmt.Println(i18n.T("sort.alreadySorted"))
+	} else {
+		fmt.Print(i18n.T("sort.moves", len(plan.moves)))
+	}
+}
+
+/*
+applySort applies the new order to the playlist on Spotify
+Returns an error, if present
+*/
+func applySort(id api.ID, name string, plan sortPlan) error {
+	_, err := spotify.ReorderPlaylist(id, plan.snapshotID, plan.moves)
+	if err != nil {
+		log.Error(i18n.T("log.sortApplyError"), "error", err, "playlistID", id)
+		return err
+	}
+	log.Info(i18n.T("log.sorted"), "playlistName", name, "playlistID", id, "moves", len(plan.moves))
+	fmt.Print(i18n.T("sort.done", name, len(plan.moves)))
+	return nil
+}
+
+/*
+sortMenu asks the user to choose an owned playlist and the sort keys, shows the new order and applies it after confirmation
+Returns an error, if present
+*/
+func sortMenu() error {
+	selected, err := selectPlaylist(i18n.T("sort.selectPlaylist"))
+	if err != nil || selected == nil {
+		return err
+	}
+	if selected.Owner.ID != userID {
+		fmt.Println(i18n.T("sort.notOwner"))
+		return nil
+	}
+
+	fmt.Println(i18n.T("sort.fields"), strings.Join(backup.SortFields(), ", "))
+	fmt.Println(i18n.T("sort.fieldsHint"))
+	fmt.Print(i18n.T("sort.by"))
+	text, _ := readAnswer()
+	keys, err := backup.ParseSortKeys(text)
+	if err != nil {
+		fmt.Println("❌", err)
+		return nil
+	}
+
+	fmt.Print(i18n.T("export.fetching", selected.Name))
+	plan, err := planSort(selected.ID, keys)
+	if err != nil {
+		fmt.Println(i18n.T("common.playlistError"), err)
+		return nil
+	}
+	printSortPreview(selected.Name, keys, plan)
+	if len(plan.moves) == 0 {
+		return nil
+	}
+	fmt.Print(i18n.T("sort.apply"))
+	confirm, _ := readAnswer()
+	if confirm != i18n.T("answer.yes") {
+		fmt.Println(i18n.T("sort.cancelled"))
+		return nil
+	}
+	err = applySort(selected.ID, selected.Name, plan)
+	if err != nil {
+		fmt.Println(i18n.T("sort.error"), err)
+	}
+	return nil
+}
+
+// sortCommand shows the new order of a playlist sorted by the given keys and applies it with -apply, exits with 1 if the order changes and is not applied
+func sortCommand(fs *flag.FlagSet, args []string) int {
+	by := fs.String("by", "", i18n.T("flag.sort.by")+strings.Join(backup.SortFields(), ", "))
+	apply := fs.Bool("apply", false, i18n.T("flag.sort.apply"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() != 1 || *by == "" {
+		fs.Usage()
+		return ExitError
+	}
+	keys, err := backup.ParseSortKeys(*by)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return ExitError
+	}
+	id, ok := playlistArg(fs.Arg(0))
+	if !ok {
+		return ExitError
+	}
+	if !authCommand() {
+		return ExitError
+	}
+
+	full, err := spotify.GetPlaylist(id)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("selection.playlistNotFound"), err)
+		return ExitError
+	}
+	plan, err := planSort(id, keys)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("common.playlistError"), err)
+		return ExitError
+	}
+	printSortPreview(full.Name, keys, plan)
+	if len(plan.moves) == 0 {
+		return ExitOK
+	}
+	if !*apply {
+		return ExitChanges
+	}
+	err = applySort(id, full.Name, plan)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("sort.error"), err)
+		return ExitError
+	}
+	return ExitOK
+}
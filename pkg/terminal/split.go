@@ -0,0 +1,339 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"strconv"
+	"strings"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+/*
+playlistTracks reads the tracks of a playlist from Spotify: the other items (podcast episodes and local files) are not counted
+Returns the playlist, with only the tracks, the number of items skipped and an error, if present
+*/
+func playlistTracks(id api.ID, name string) (p backup.Playlist, skipped int, err error) {
+	p, err = livePlaylist(id, name)
+	if err != nil {
+		return p, 0, err
+	}
+	tracks := p.Items[:0:0]
+	for _, t := range p.Items {
+		if t.Type == backup.ItemTrack {
+			tracks = append(tracks, t)
+		}
+	}
+	skipped = len(p.Items) - len(tracks) + p.Unavailable
+	p.Items = tracks
+	return p, skipped, nil
+}
+
+/*
+createWithTracks creates a new private playlist with the given tracks
+Returns the playlist created and an error, if present
+*/
+func createWithTracks(name string, description string, tracks []backup.Track) (*api.FullPlaylist, error) {
+	created, err := spotify.CreatePlaylist(name, description)
+	if err != nil {
+		log.Error(i18n.T("log.createPlaylistError"), "error", err, "playlistName", name)
+		return nil, err
+	}
+	ids := make([]api.ID, 0, len(tracks))
+	for _, t := range tracks {
+		ids = append(ids, t.ID)
+	}
+	_, err = spotify.AddTracksToPlaylist(ids, created.ID)
+	if err != nil {
+		log.Error(i18n.T("log.addTracksError"), "error", err, "playlistID", created.ID)
+		return created, err
+	}
+	log.Info(i18n.T("log.playlistCreated"), "playlistName", name, "playlistID", created.ID, "tracks", len(ids))
+	return created, nil
+}
+
+// printParts prints the names of the parts of a split playlist and their number of tracks
+func printParts(p backup.Playlist, parts []backup.Part, template string) {
+	fmt.Print(i18n.T("split.preview", p.Name, len(p.Items), len(parts)))
+	fmt.Println("=======================================")
+	for i, part := range parts {
+		fmt.Print(i18n.T("split.part", i+1, backup.PartName(template, p.Name, part, i+1), len(part.Tracks)))
+	}
+}
+
+/*
+createParts creates a new playlist for every part and reports the result
+Returns an error, if present
+*/
+func createParts(p backup.Playlist, parts []backup.Part, template string) error {
+	for i, part := range parts {
+		name := backup.PartName(template, p.Name, part, i+1)
+		created, err := createWithTracks(name, i18n.T("split.description")+p.Name, part.Tracks)
+		if err != nil {
+			return err
+		}
+		fmt.Print(i18n.T("split.created", name, len(part.Tracks), created.ID))
+	}
+	log.Info(i18n.T("log.splitDone"), "playlistName", p.Name, "playlistID", p.ID, "parts", len(parts))
+	return nil
+}
+
+/*
+splitMenu asks the user to choose a playlist, the criterion and the names of the parts, shows the parts and creates them after confirmation
+Returns an error, if present
+*/
+func splitMenu() error {
+	selected, err := selectPlaylist(i18n.T("split.selectPlaylist"))
+	if err != nil || selected == nil {
+		return err
+	}
+
+	choice, err := chooseOption(i18n.T("split.how"), i18n.T("common.cancel"),
+		i18n.T("split.byCount"),
+		i18n.T("split.byDecade"),
+		i18n.T("split.byInitial"),
+		i18n.T("split.byMonth"),
+	)
+	if err != nil {
+		return err
+	}
+	if choice < 1 || choice > len(backup.SplitCriteria()) {
+		return nil
+	}
+	by := backup.SplitCriteria()[choice-1]
+	size := 0
+	if by == backup.SplitCount {
+		fmt.Print(i18n.T("split.size"))
+		text, err := readAnswer()
+		if err != nil {
+			return err
+		}
+		size, err = strconv.Atoi(text)
+		if err != nil {
+			fmt.Println(i18n.T("selection.invalid"))
+			return nil
+		}
+	}
+	fmt.Print(i18n.T("split.name", backup.DefaultPartName))
+	template := readLine()
+	if template == "" {
+		template = backup.DefaultPartName
+	}
+
+	p, skipped, err := playlistTracks(selected.ID, selected.Name)
+	if err != nil {
+		fmt.Println(i18n.T("common.playlistError"), err)
+		return nil
+	}
+	parts, err := backup.Split(p.Items, by, size)
+	if err != nil {
+		fmt.Println("❌", err)
+		return nil
+	}
+	printParts(p, parts, template)
+	if skipped > 0 {
+		fmt.Print(i18n.T("split.notTracks", skipped))
+	}
+	fmt.Print(i18n.T("split.confirm"))
+	confirm, _ := readAnswer()
+	if confirm != i18n.T("answer.yes") {
+		fmt.Println(i18n.T("split.cancelled"))
+		return nil
+	}
+	err = createParts(p, parts, template)
+	if err != nil {
+		fmt.Println(i18n.T("split.createError"), err)
+	}
+	return nil
+}
+
+/*
+mergeMenu asks the user to choose the playlists to merge and creates a new playlist with all their tracks, without linking them
+Returns an error, if present
+*/
+func mergeMenu() error {
+	selected, err := selectPlaylists(i18n.T("merge.selectPlaylists"))
+	if err != nil || selected == nil {
+		return err
+	}
+	var playlists []backup.Playlist
+	skipped := 0
+	for _, sel := range selected {
+		p, s, err := playlistTracks(sel.ID, sel.Name)
+		if err != nil {
+			fmt.Println(i18n.T("common.playlistError"), err)
+			return nil
+		}
+		playlists = append(playlists, p)
+		skipped += s
+	}
+	if len(playlists) < 2 {
+		fmt.Println(i18n.T("merge.atLeastTwo"))
+		return nil
+	}
+
+	fmt.Print(i18n.T("merge.name"))
+	name := readLine()
+	if name == "" {
+		name = mergedName(playlists)
+	}
+	fmt.Print(i18n.T("merge.skipDuplicates"))
+	dedupe, _ := readAnswer()
+	return merge(playlists, name, dedupe == i18n.T("answer.yes"), skipped, false)
+}
+
+// mergedName returns the default name of a merged playlist: the names of the playlists joined with " + "
+func mergedName(playlists []backup.Playlist) string {
+	names := make([]string, 0, len(playlists))
+	for _, p := range playlists {
+		names = append(names, p.Name)
+	}
+	return strings.Join(names, " + ")
+}
+
+/*
+merge creates a new playlist with the tracks of the playlists (only the first occurrence of each if dedupe is true) and reports the result.
+If dryRun is true the playlist is not created
+Returns an error, if present
+*/
+func merge(playlists []backup.Playlist, name string, dedupe bool, skipped int, dryRun bool) error {
+	tracks, duplicates := backup.Merge(playlists, dedupe)
+	fmt.Print(i18n.T("merge.summary", name, len(tracks), len(playlists)))
+	if dedupe {
+		fmt.Print(i18n.T("merge.skipped", duplicates))
+	}
+	fmt.Println()
+	if skipped > 0 {
+		fmt.Print(i18n.T("merge.notTracks", skipped))
+	}
+	if dryRun {
+		return nil
+	}
+	created, err := createWithTracks(name, i18n.T("merge.description")+mergedName(playlists), tracks)
+	if err != nil {
+		fmt.Println(i18n.T("common.createPlaylistError"), err)
+		return err
+	}
+	log.Info(i18n.T("log.merged"), "playlistName", name, "playlistID", created.ID, "playlists", len(playlists), "tracks", len(tracks), "duplicates", duplicates)
+	fmt.Print(i18n.T("merge.created", name, created.ID))
+	return nil
+}
+
+// splitCommand splits a playlist into new playlists by number of tracks, decade, initial of the artist or month added
+func splitCommand(fs *flag.FlagSet, args []string) int {
+	by := fs.String("by", backup.SplitCount, i18n.T("flag.split.by")+strings.Join(backup.SplitCriteria(), ", "))
+	size := fs.Int("size", 100, i18n.T("flag.split.size"))
+	template := fs.String("name", backup.DefaultPartName, i18n.T("flag.split.name"))
+	dryRun := fs.Bool("dry-run", false, i18n.T("flag.split.dryRun"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return ExitError
+	}
+	id, ok := playlistArg(fs.Arg(0))
+	if !ok {
+		return ExitError
+	}
+	if !authCommand() {
+		return ExitError
+	}
+
+	full, err := spotify.GetPlaylist(id)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("selection.playlistNotFound"), err)
+		return ExitError
+	}
+	p, _, err := playlistTracks(id, full.Name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("common.playlistError"), err)
+		return ExitError
+	}
+	parts, err := backup.Split(p.Items, *by, *size)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		return ExitError
+	}
+	printParts(p, parts, *template)
+	if *dryRun {
+		return ExitOK
+	}
+	err = createParts(p, parts, *template)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("split.createError"), err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+// mergeCommand creates a new playlist with the tracks of several playlists, once, without linking them
+func mergeCommand(fs *flag.FlagSet, args []string) int {
+	name := fs.String("name", "", i18n.T("flag.merge.name"))
+	keepDuplicates := fs.Bool("keep-duplicates", false, i18n.T("flag.merge.keepDuplicates"))
+	dryRun := fs.Bool("dry-run", false, i18n.T("flag.merge.dryRun"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return ExitError
+	}
+	if !authCommand() {
+		return ExitError
+	}
+
+	var playlists []backup.Playlist
+	skipped := 0
+	for _, arg := range fs.Args() {
+		id, ok := playlistArg(arg)
+		if !ok {
+			return ExitError
+		}
+		full, err := spotify.GetPlaylist(id)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("selection.playlistNotFound"), arg, err)
+			return ExitError
+		}
+		p, s, err := playlistTracks(id, full.Name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("common.playlistError"), err)
+			return ExitError
+		}
+		playlists = append(playlists, p)
+		skipped += s
+	}
+	if *name == "" {
+		*name = mergedName(playlists)
+	}
+	if merge(playlists, *name, !*keepDuplicates, skipped, *dryRun) != nil {
+		return ExitError
+	}
+	return ExitOK
+}
+
+/*
+splitMergeMenu asks the user whether to split a playlist or to merge several playlists
+Returns an error, if present
+*/
+func splitMergeMenu() error {
+	choice, err := chooseOption(i18n.T("split.what"), i18n.T("common.backToMenu"), i18n.T("split.menu.split"), i18n.T("split.menu.merge"))
+	if err != nil {
+		return err
+	}
+	switch choice {
+	case 1:
+		return splitMenu()
+	case 2:
+		return mergeMenu()
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package terminal
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/export"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/tui"
+	"playlist-manager/pkg/utils"
+	"strings"
+	"time"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// Default number of artists and albums shown in the statistics and of pairs of playlists shown in the overlap
+const (
+	statsTop   = 10
+	overlapTop = 20
+	// Length of the longest bar of the release year histogram
+	histogramWidth = 30
+)
+
+// printStats prints the statistics of a playlist, with the histogram of the release years
+func printStats(s backup.Stats) {
+	fmt.Print(i18n.T("stats.title", s.Name))
+	fmt.Println("=======================================")
+	fmt.Print(i18n.T("stats.items", s.Items, s.Tracks, s.Episodes, s.Local))
+	if s.Unavailable > 0 {
+		fmt.Print(i18n.T("stats.unavailable", s.Unavailable))
+	}
+	fmt.Print(i18n.T("stats.duration", utils.FormatDuration(s.Duration)))
+	fmt.Print(i18n.T("stats.explicit", s.Explicit, s.ExplicitShare()))
+	printCounts(i18n.T("stats.artists"), s.Artists)
+	printCounts(i18n.T("stats.albums"), s.Albums)
+	if len(s.Years) > 0 {
+		fmt.Println(i18n.T("stats.years"))
+		highest := 0
+		for _, c := range s.Years {
+			highest = max(highest, c.Items)
+		}
+		for _, c := range s.Years {
+			bar := strings.Repeat("█", (c.Items*histogramWidth+highest-1)/highest)
+			fmt.Printf("   %-11s %s %d\n", c.Name, bar, c.Items)
+		}
+	}
+	// The breakdown is useful only for the collaborative playlists
+	if len(s.AddedBy) > 1 {
+		addedBy := make([]backup.Count, 0, len(s.AddedBy))
+		for _, c := range s.AddedBy {
+			if c.Name == userID {
+				c.Name += i18n.T("stats.you")
+			}
+			addedBy = append(addedBy, c)
+		}
+		printCounts(i18n.T("stats.addedBy"), addedBy)
+	}
+}
+
+// printCounts prints a numbered list of counts with a title, nothing if the list is empty
+func printCounts(title string, counts []backup.Count) {
+	if len(counts) == 0 {
+		return
+	}
+	fmt.Println(title + ":")
+	for i, c := range counts {
+		fmt.Printf("   %d. %s (%d)\n", i+1, c.Name, c.Items)
+	}
+}
+
+// printOverlap prints the pairs of playlists with the most items in common, at most limit pairs
+func printOverlap(o backup.Overlap, limit int) {
+	pairs := o.Pairs()
+	fmt.Println(i18n.T("stats.overlap"))
+	fmt.Println("=======================================")
+	if len(pairs) == 0 {
+		fmt.Println(i18n.T("stats.noOverlap"))
+		return
+	}
+	for i, p := range pairs {
+		if i == limit {
+			fmt.Print(i18n.T("stats.morePairs", len(pairs)-limit))
+			break
+		}
+		// The share is computed on the smaller playlist, that can be contained in the other one
+		smaller := min(o.Shared[p.I][p.I], o.Shared[p.J][p.J])
+		fmt.Print(i18n.T("stats.pair", i+1, o.Names[p.I], o.Names[p.J], p.Shared, float64(p.Shared)*100/float64(smaller)))
+	}
+}
+
+/*
+libraryPlaylists reads the liked songs and all the playlists of the user (owned and followed) from Spotify
+Returns the playlists, the liked songs first, and an error, if present
+*/
+func libraryPlaylists() ([]backup.Playlist, error) {
+	fmt.Println(i18n.T("stats.fetchingLiked"))
+	liked, err := spotify.GetLibrary(backup.KindLikedSongs)
+	if err != nil {
+		log.Error(i18n.T("log.likedSongsError"), "error", err)
+		return nil, err
+	}
+	res := []backup.Playlist{liked}
+	pl, err := spotify.GetPlaylists()
+	if err != nil {
+		log.Error(i18n.T("log.playlistsError"), "error", err, "userID", userID)
+		return nil, err
+	}
+	progress := tui.NewProgress(i18n.T("stats.progress"), len(pl))
+	for _, p := range pl {
+		progress.Next(p.Name)
+		snapshot, err := spotify.GetPlaylistSnapshot(p.ID, p.Name)
+		if err != nil {
+			log.Error(i18n.T("log.playlistError"), "error", err, "playlistID", p.ID)
+			return nil, err
+		}
+		res = append(res, snapshot)
+	}
+	progress.Done()
+	return res, nil
+}
+
+/*
+statsReport computes the statistics of the playlists and, if they are more than one, the overlap between them.
+With library the statistics of all the distinct items of the playlists are added too
+Returns the report
+*/
+func statsReport(playlists []backup.Playlist, top int, library bool) export.StatsReport {
+	r := export.StatsReport{Generated: time.Now().Format(time.RFC3339)}
+	for _, p := range playlists {
+		r.Playlists = append(r.Playlists, backup.ComputeStats(p, top))
+	}
+	if library {
+		items, _ := backup.Merge(playlists, true)
+		s := backup.ComputeStats(backup.Playlist{Name: i18n.T("stats.library"), Items: items}, top)
+		r.Library = &s
+	}
+	if len(playlists) > 1 {
+		o := backup.ComputeOverlap(playlists)
+		r.Overlap = &o
+	}
+	return r
+}
+
+// printReport prints all the statistics of the report
+func printReport(r export.StatsReport) {
+	if r.Library != nil {
+		printStats(*r.Library)
+		fmt.Println(i18n.T("stats.playlists"))
+		for _, s := range r.Playlists {
+			fmt.Print(i18n.T("stats.playlistLine", s.Name, s.Items, utils.FormatDuration(s.Duration)))
+		}
+	} else {
+		for _, s := range r.Playlists {
+			printStats(s)
+		}
+	}
+	if r.Overlap != nil {
+		printOverlap(*r.Overlap, overlapTop)
+	}
+}
+
+/*
+exportReport asks the user if and in which format (JSON or CSV) the report should be saved in data/export
+Returns an error, if present
+*/
+func exportReport(r export.StatsReport) error {
+	keepOutput()
+	choice, err := chooseOption(i18n.T("stats.export"), i18n.T("common.no"), "📄 JSON", "📄 CSV")
+	if err != nil {
+		return err
+	}
+	var format string
+	switch choice {
+	case 1:
+		format = export.StatsJSON
+	case 2:
+		format = export.StatsCSV
+	default:
+		return nil
+	}
+	path, err := export.StatsToPath(r, format, "")
+	if err != nil {
+		log.Error(i18n.T("log.statsExportError"), "error", err)
+		fmt.Println(i18n.T("stats.exportError"), err)
+		return nil
+	}
+	log.Info(i18n.T("log.statsExported"), "path", path)
+	fmt.Println(i18n.T("stats.exported") + path)
+	return nil
+}
+
+/*
+statsMenu asks the user to choose a live playlist, a backup or the whole library and shows its statistics
+Returns an error, if present
+*/
+func statsMenu() error {
+	choice, err := chooseOption(i18n.T("stats.what"), i18n.T("common.backToMenu"),
+		i18n.T("export.live"),
+		i18n.T("export.backup"),
+		i18n.T("stats.wholeLibrary"),
+	)
+	if err != nil {
+		return err
+	}
+
+	var r export.StatsReport
+	switch choice {
+	case 1:
+		selected, err := selectPlaylist(i18n.T("stats.selectPlaylist"))
+		if err != nil || selected == nil {
+			return err
+		}
+		p, err := livePlaylist(selected.ID, selected.Name)
+		if err != nil {
+			fmt.Println(i18n.T("common.playlistError"), err)
+			return nil
+		}
+		r = statsReport([]backup.Playlist{p}, statsTop, false)
+	case 2:
+		f, err := selectBackupFile(userID)
+		if err != nil || f == nil {
+			return err
+		}
+		r = statsReport([]backup.Playlist{f.Playlist}, statsTop, false)
+	case 3:
+		utils.ClearTerminal()
+		playlists, err := libraryPlaylists()
+		if err != nil {
+			fmt.Println(i18n.T("stats.libraryError"), err)
+			return nil
+		}
+		r = statsReport(playlists, statsTop, true)
+	default:
+		return nil
+	}
+	utils.ClearTerminal()
+	printReport(r)
+	return exportReport(r)
+}
+
+// statsCommand prints the statistics of some playlists or of the whole library, or exports them as JSON or CSV
+func statsCommand(fs *flag.FlagSet, args []string) int {
+	library := fs.Bool("library", false, i18n.T("flag.stats.library"))
+	top := fs.Int("top", statsTop, i18n.T("flag.stats.top"))
+	format := fs.String("format", "", i18n.T("flag.stats.format"))
+	out := fs.String("out", "", i18n.T("flag.stats.out"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if (*library && fs.NArg() != 0) || (!*library && fs.NArg() == 0) {
+		fs.Usage()
+		return ExitError
+	}
+	if *format != "" && *format != export.StatsJSON && *format != export.StatsCSV {
+		fmt.Fprint(os.Stderr, i18n.T("stats.unsupportedFormat", *format, export.StatsJSON, export.StatsCSV))
+		return ExitError
+	}
+	if !authCommand() {
+		return ExitError
+	}
+	userID, err = spotify.GetUserID()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("common.userError"), err)
+		return ExitError
+	}
+
+	var playlists []backup.Playlist
+	if *library {
+		playlists, err = libraryPlaylists()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("stats.libraryError"), err)
+			return ExitError
+		}
+	} else {
+		for _, arg := range fs.Args() {
+			id, ok := playlistArg(arg)
+			if !ok {
+				return ExitError
+			}
+			full, err := spotify.GetPlaylist(id)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, i18n.T("selection.playlistNotFound"), err)
+				return ExitError
+			}
+			p, err := livePlaylist(full.ID, full.Name)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, i18n.T("common.playlistError"), err)
+				return ExitError
+			}
+			playlists = append(playlists, p)
+		}
+	}
+
+	r := statsReport(playlists, *top, *library)
+	if *format == "" {
+		printReport(r)
+		return ExitOK
+	}
+	path, err := export.StatsToPath(r, *format, *out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("stats.exportError"), err)
+		return ExitError
+	}
+	fmt.Println(i18n.T("stats.exported") + path)
+	return ExitOK
+}
@@ -1,10 +1,13 @@
 package terminal
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/config"
 	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/tui"
 	"playlist-manager/pkg/utils"
 	"time"
 
@@ -19,44 +22,50 @@ var userID string
 const VERSION = "0.3.8"
 
 func Display() (err error) {
-	log.Info("Avvio di Playlist Manager", "version", VERSION)
+	log.Info(i18n.T("log.start"), "version", VERSION)
 	options := []string{
-		"Visualizza le playlist del tuo account",
-		"Visualizza i brani di una playlist del tuo account",
-		"Salva una playlist (Backup)",
-		"Salva tutte le playlist (Backup)",
-		"Carica una playlist (Restore)",
-		"Visualizza e gestisci le playlist collegate",
-		"Riautenticati (cancella credenziali e rifai login)",
+		i18n.T("menu.main.playlists"),
+		i18n.T("menu.main.tracks"),
+		i18n.T("menu.main.backup"),
+		i18n.T("menu.main.backupAll"),
+		i18n.T("menu.main.restore"),
+		i18n.T("menu.main.linked"),
+		i18n.T("menu.main.reauth"),
+		i18n.T("menu.main.diff"),
+		i18n.T("menu.main.prune"),
+		i18n.T("menu.main.export"),
+		i18n.T("menu.main.import"),
+		i18n.T("menu.main.library"),
+		i18n.T("menu.main.duplicates"),
+		i18n.T("menu.main.sort"),
+		i18n.T("menu.main.split"),
+		i18n.T("menu.main.smart"),
+		i18n.T("menu.main.stats"),
+		i18n.T("menu.main.compare"),
+		i18n.T("menu.main.search"),
+		i18n.T("menu.main.where"),
 	}
 
 	err = spotify.Auth()
 	if err != nil {
-		log.Error("Errore durante l'autenticazione Spotify", "error", err)
+		log.Error(i18n.T("log.authError"), "error", err)
 		return err
 	}
-	log.Info("Autenticazione Spotify completata con successo")
+	log.Info(i18n.T("log.authDone"))
 
 	//Clear terminal after auth
 	utils.ClearTerminal()
 
 	for {
-		fmt.Println("========================================================")
-		fmt.Println("🎧 Playlist Manager " + VERSION + " 🎧\n✨ Sviluppato da " + termlink.ColorLink("Matteo Lombardi", "https://github.com/matteolomba", "italic yellow") + " ✨")
-		fmt.Println("========================================================")
-		displayAuthStatus()
-		fmt.Println("========================================================")
-		fmt.Println("🏠 -> Menù Principale <- 🏠")
-		fmt.Println("========================================================")
-		fmt.Printf("🚪 0. Esci\n")
-		optionEmojis := []string{"📋", "🎵", "💾", "📁", "🔄", "🔗", "🔑"}
-		for i, o := range options {
-			fmt.Printf("%s %d. %s\n", optionEmojis[i], i+1, o)
+		// The header is shown in the title of the full-screen menu
+		if !tui.Enabled() {
+			fmt.Println("========================================================")
+			fmt.Println("🎧 Playlist Manager " + VERSION + i18n.T("menu.developedBy") + termlink.ColorLink("Matteo Lombardi", "https://github.com/matteolomba", "italic yellow") + " ✨")
+			fmt.Println("========================================================")
+			displayAuthStatus()
+			fmt.Println("========================================================")
 		}
-		fmt.Println("========================================================")
-		fmt.Print("❓ Cosa vuoi fare? ")
-		var sel int
-		_, err := fmt.Scan(&sel)
+		sel, err := chooseOption(menuTitle(i18n.T("menu.main.title")), i18n.T("menu.exit"), options...)
 		if err != nil {
 			return err
 		}
@@ -65,143 +74,126 @@ func Display() (err error) {
 
 		switch sel {
 		case 0:
-			log.Info("L'utente ha scelto di uscire dall'applicazione", "userID", userID)
-			fmt.Println("👋 Ciao! Esco dall'applicazione...")
+			log.Info(i18n.T("log.exit"), "userID", userID)
+			fmt.Println(i18n.T("menu.bye"))
 			return nil
 
 		case 1: // Get playlists
 			utils.ClearTerminal()
-			log.Info("L'utente ha richiesto la visualizzazione delle playlist", "userID", userID)
+			log.Info(i18n.T("log.showPlaylists"), "userID", userID)
 			pl, err := spotify.GetPlaylists()
 			if err != nil {
-				log.Error("Errore nel recupero delle playlist", "error", err, "userID", userID)
+				log.Error(i18n.T("log.playlistsError"), "error", err, "userID", userID)
 				return err
 			}
-			log.Info("Playlist recuperate con successo", "count", len(pl), "userID", userID)
-			fmt.Println("\n🎼 Le tue playlist:")
+			log.Info(i18n.T("log.playlistsFetched"), "count", len(pl), "userID", userID)
+			// In the full-screen list the details are shown beside the playlists, and enter shows the tracks
+			if tui.Enabled() {
+				for {
+					selected, _, err := choosePlaylist(i18n.T("menu.yourPlaylists"), pl)
+					if err != nil {
+						return err
+					}
+					if selected == nil {
+						break
+					}
+					err = showTracks(*selected)
+					if err != nil {
+						return err
+					}
+				}
+				utils.ClearTerminal()
+				continue
+			}
+			fmt.Println(i18n.T("menu.yourPlaylistsList"))
 			fmt.Println("==================")
 			for i, p := range pl {
 				fmt.Printf("🎵 %d. %s - %s\n", i+1, p.Name, p.ID)
 			}
-			fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-			fmt.Scanf("\n\n")
+			pressEnter()
 
 		case 2: // Get tracks from a playlist
 			utils.ClearTerminal()
-			log.Info("L'utente ha richiesto la visualizzazione dei brani di una playlist", "userID", userID)
+			log.Info(i18n.T("log.showTracks"), "userID", userID)
 			pl, err := spotify.GetPlaylists()
 			if err != nil {
-				log.Error("Errore nel recupero delle playlist per la visualizzazione dei brani", "error", err, "userID", userID)
+				log.Error(i18n.T("log.tracksPlaylistsError"), "error", err, "userID", userID)
 				return err
 			}
-			fmt.Println("\n🎼 Seleziona una playlist:")
-			fmt.Println("=========================")
-			for i, p := range pl {
-				fmt.Printf("🎵 %d. %s - %s\n", i+1, p.Name, p.ID)
-			}
-			fmt.Println("🔙 0. Annulla")
-			fmt.Print("\n🔍 Inserisci il numero della playlist di cui vuoi visualizzare i brani: ")
-			var sel int
-			_, err = fmt.Scan(&sel)
+			selected, cancelled, err := choosePlaylist(i18n.T("menu.selectPlaylist"), pl)
 			if err != nil {
-				log.Error("Errore nella lettura della selezione playlist per brani", "error", err, "userID", userID)
+				log.Error(i18n.T("log.tracksSelectionError"), "error", err, "userID", userID)
 				return err
 			}
-			if sel == 0 {
-				log.Info("L'utente ha annullato la visualizzazione dei brani", "userID", userID)
+			if cancelled {
+				log.Info(i18n.T("log.tracksCancelled"), "userID", userID)
 				break
 			}
-			if sel < 1 || sel > len(pl) {
-				log.Warn("Selezione non valida per visualizzare i brani", "selection", sel, "max", len(pl), "userID", userID)
-				fmt.Println("❌ Selezione non valida")
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
+			if selected == nil {
+				log.Warn(i18n.T("log.tracksInvalid"), "userID", userID)
+				pressEnter()
 				break
 			}
-			selectedPlaylist := pl[sel-1]
 			utils.ClearTerminal()
-			log.Info("L'utente ha selezionato una playlist per visualizzazione brani", "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID)
-			tracks, err := spotify.GetTracks(selectedPlaylist.ID)
+			err = showTracks(*selected)
 			if err != nil {
-				log.Error("Errore nel recupero dei brani della playlist", "error", err, "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID)
 				return err
 			}
-			log.Info("Brani della playlist recuperati con successo", "trackCount", len(tracks), "playlistName", selectedPlaylist.Name, "userID", userID)
-			fmt.Printf("\n🎵 Brani della playlist '%s':\n", selectedPlaylist.Name)
-			fmt.Println("=======================================")
-			for i, t := range tracks {
-				if t.Track.Track.ID == "" {
-					fmt.Printf("⚠️ %d. Brano non disponibile (potrebbe essere un podcast)\n", i+1)
-					log.Warn("Brano non disponibile, potrebbe essere un podcast o un brano non disponibile su Spotify")
-				} else {
-					text := fmt.Sprintf("🎶 %d. %s di ", i+1, t.Track.Track.Name)
-					for _, a := range t.Track.Track.Artists {
-						text += a.Name + ", "
-					}
-					text = text[:len(text)-2]
-					fmt.Println(text)
-				}
+			if !tui.Enabled() {
+				pressEnter()
 			}
-			fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-			fmt.Scanf("\n\n")
 
 		case 3: // Save playlist (Backup) to JSON file
 			utils.ClearTerminal()
-			log.Info("L'utente ha richiesto il backup di una singola playlist", "userID", userID)
+			log.Info(i18n.T("log.backupSome"), "userID", userID)
 			//Get playlists
 			pl, err := spotify.GetPlaylists()
 			if err != nil {
-				log.Error("Errore nel recupero delle playlist per backup singolo", "error", err, "userID", userID)
+				log.Error(i18n.T("log.backupPlaylistsError"), "error", err, "userID", userID)
 				return err
 			}
-			fmt.Println("\n💾 Seleziona la playlist da salvare:")
-			fmt.Println("====================================")
-			for i, p := range pl {
-				fmt.Printf("🎵 %d. %s - %s\n", i+1, p.Name, p.ID)
-			}
-			fmt.Println("🔙 0. Annulla")
-
-			//Select playlist
-			fmt.Print("\n💾 Inserisci il numero della playlist di cui vuoi fare il backup: ")
-			var sel int
-			_, err = fmt.Scan(&sel)
+			//Select playlists
+			selected, cancelled, err := choosePlaylists(i18n.T("backup.selectPlaylists"), pl)
 			if err != nil {
-				log.Error("Errore nella lettura della playlist selezionata per backup", "error", err, "userID", userID)
+				log.Error(i18n.T("log.backupSelectionError"), "error", err, "userID", userID)
 				return err
 			}
-			if sel == 0 {
-				log.Info("L'utente ha annullato il backup della playlist", "userID", userID)
+			if cancelled {
+				log.Info(i18n.T("log.backupCancelled"), "userID", userID)
 				break
 			}
-			if sel < 1 || sel > len(pl) {
-				log.Warn("Selezione non valida per il backup della playlist", "selection", sel, "max", len(pl), "userID", userID)
-				fmt.Println("❌ Selezione non valida")
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
+			if selected == nil {
+				log.Warn(i18n.T("log.backupInvalid"), "userID", userID)
+				pressEnter()
 				break
 			}
 
-			selectedPlaylist := pl[sel-1]
 			utils.ClearTerminal()
-			log.Info("Inizio backup playlist singola", "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID)
-			//Save playlist
-			backupDir, err := savePlaylistAsJSON(selectedPlaylist, userID)
-			if err != nil {
-				log.Error("Errore durante il backup della playlist", "error", err, "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID, "backupDir", backupDir)
-				return err
+			for i, selectedPlaylist := range selected {
+				log.Info(i18n.T("log.backupStart"), "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID)
+				fmt.Printf("⏳ %d/%d %s\n", i+1, len(selected), selectedPlaylist.Name)
+				//Save playlist
+				backupDir, unchanged, err := savePlaylistAsJSON(selectedPlaylist, userID)
+				if err != nil {
+					log.Error(i18n.T("log.backupError"), "error", err, "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID, "backupDir", backupDir)
+					return err
+				}
+				log.Info(i18n.T("log.backupDone"), "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID, "backupDir", backupDir, "unchanged", unchanged)
+				if unchanged {
+					fmt.Println(i18n.T("backup.unchangedIn"), backupDir)
+				} else {
+					fmt.Println(i18n.T("backup.savedIn"), backupDir)
+				}
 			}
-			log.Info("Backup playlist completato con successo", "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID, "backupDir", backupDir)
-			fmt.Println("✅ Playlist salvata in:", backupDir)
-			fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-			fmt.Scanf("\n\n")
+			pressEnter()
 
 		case 4: // Backup all personal playlists to JSON files
 			utils.ClearTerminal()
-			log.Info("L'utente ha richiesto il backup di tutte le playlist personali", "userID", userID)
+			log.Info(i18n.T("log.backupAll"), "userID", userID)
 			//Get playlists
 			pl, err := spotify.GetPlaylists()
 			if err != nil {
-				log.Error("Errore nel recupero delle playlist per backup multiplo", "error", err, "userID", userID)
+				log.Error(i18n.T("log.backupAllPlaylistsError"), "error", err, "userID", userID)
 				return err
 			}
 
@@ -211,377 +203,416 @@ func Display() (err error) {
 					personalPlaylistsCount++
 				}
 			}
-			log.Info("Playlist personali da salvare ottenute", "count", personalPlaylistsCount, "userID", userID)
+			log.Info(i18n.T("log.backupAllPlaylists"), "count", personalPlaylistsCount, "userID", userID)
 
-			today := time.Now().Format("2006-01-02")
-			fmt.Printf("💾 Verranno salvate %d playlist personali in:\n📂 %s\n\n", personalPlaylistsCount, "data/backup/"+userID+"/"+today+"/")
-			fmt.Println("⏳ Avvio backup...")
+			now := time.Now()
+			today := now.Format(backup.DateLayout)
+			fmt.Print(i18n.T("backup.willSave", personalPlaylistsCount, backup.Location(userID, true, now)+"/"))
 			savedCount := 0
+			unchangedCount := 0
+			var savedIDs []api.ID
+			progress := tui.NewProgress("Backup", personalPlaylistsCount)
 			for _, p := range pl {
 				//Process only personal playlists
 				if p.Owner.ID == userID {
 					//Save playlist
-					log.Info("Inizio backup playlist", "playlistName", p.Name, "playlistID", p.ID, "userID", userID)
-					_, err = savePlaylistAsJSON(p, userID)
+					log.Info(i18n.T("log.backupStart"), "playlistName", p.Name, "playlistID", p.ID, "userID", userID)
+					progress.Next(p.Name)
+					_, unchanged, err := spotify.SavePlaylistAsJSON(p, userID)
 					if err != nil {
-						log.Error("Errore durante il backup della playlist", "error", err, "playlistName", p.Name, "playlistID", p.ID, "userID", userID)
+						log.Error(i18n.T("log.backupError"), "error", err, "playlistName", p.Name, "playlistID", p.ID, "userID", userID)
+						progress.Println(i18n.T("backup.saveError", p.Name, err))
 						return err
 					}
-					savedCount++
-					log.Info("Backup playlist completato", "playlistName", p.Name, "playlistID", p.ID, "userID", userID)
+					if unchanged {
+						unchangedCount++
+					} else {
+						savedCount++
+					}
+					savedIDs = append(savedIDs, p.ID)
+					log.Info(i18n.T("log.backupPlaylistDone"), "playlistName", p.Name, "playlistID", p.ID, "userID", userID)
+				}
+			}
+			progress.Done()
+			removed, err := backup.SaveRemoved(userID, savedIDs, now)
+			if err != nil {
+				log.Error(i18n.T("log.backupRemovedError"), "error", err, "userID", userID)
+				return err
+			}
+			log.Info(i18n.T("log.backupAllDone"), "totalSaved", savedCount, "unchanged", unchangedCount, "removed", len(removed), "userID", userID)
+			fmt.Print(i18n.T("backup.allDone", savedCount, unchangedCount))
+
+			//Apply the retention policy, if configured
+			policy := retentionPolicy()
+			if !policy.IsZero() {
+				_, err = pruneBackups(userID, policy, false)
+				if err != nil {
+					log.Error(i18n.T("log.autoPruneError"), "error", err, "userID", userID)
+					return err
+				}
+			}
+
+			//Create the archive, if configured
+			if config.Envs.BackupArchive != "" {
+				err = archiveBackup(userID, today, savedIDs)
+				if err != nil {
+					log.Error(i18n.T("log.archiveError"), "error", err, "userID", userID)
+					return err
 				}
 			}
-			log.Info("Backup multiplo completato", "totalSaved", savedCount, "userID", userID)
-			fmt.Printf("✅ Backup completato! Salvate %d playlist personali.\n\n⏎ Premi invio per tornare al menu...", savedCount)
-			fmt.Scanf("\n\n")
+			pressEnter()
 
 		case 5: // Restore playlist from JSON file
 			utils.ClearTerminal()
-			log.Info("L'utente ha richiesto il ripristino di una playlist", "userID", userID)
-
-			// Prima scelta: playlist proprie o di altri
-			fmt.Println("\n🔄 Che playlist vuoi ripristinare?")
-			fmt.Println("=====================================")
-			fmt.Println("👤 1. Una delle mie personali")
-			fmt.Println("👥 2. Una di un altro utente")
-			fmt.Println("🔙 0. Torna al menu")
-			fmt.Print("\n❓ Scelta: ")
-
-			var ownerChoice int
-			_, err := fmt.Scan(&ownerChoice)
+			log.Info(i18n.T("log.restore"), "userID", userID)
+
+			file, err := selectBackupFile(userID)
 			if err != nil {
-				log.Error("Errore nella lettura della scelta sul proprietario", "error", err, "userID", userID)
 				return err
 			}
-
-			if ownerChoice == 0 {
-				log.Info("L'utente ha annullato il ripristino", "userID", userID)
+			if file == nil {
 				break
 			}
+			playlist := file.Playlist
+			utils.ClearTerminal()
 
-			var backupDir string
-			var isPersonal bool
-
-			switch ownerChoice {
-			case 1:
-				// Playlist personali
-				utils.ClearTerminal()
-				backupDir = "data/backup/" + userID
-				isPersonal = true
-				log.Info("L'utente ha scelto di ripristinare una playlist personale", "userID", userID)
-			case 2:
-				// Playlist di altri (nella cartella altre dell'utente)
-				utils.ClearTerminal()
-				backupDir = "data/backup/" + userID + "/altre"
-				isPersonal = false
-				log.Info("L'utente ha scelto di ripristinare una playlist di un altro utente", "userID", userID, "backupDir", backupDir)
-			default:
-				log.Warn("Scelta del proprietario non valida", "choice", ownerChoice, "userID", userID)
-				fmt.Println("❌ Scelta non valida")
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
-				continue // Torna al menu principale invece di break
-			}
-
-			// Verifica esistenza cartella
-			if _, err := os.Stat(backupDir); os.IsNotExist(err) {
-				log.Warn("Nessun backup trovato", "userID", userID, "backupDir", backupDir, "isPersonal", isPersonal)
-				if isPersonal {
-					fmt.Println("❌ Nessun backup di tue playlist trovato")
-				} else {
-					fmt.Println("❌ Nessun backup di playlist di altri trovato")
+			//Library backups are restored to the library of the user, not to a playlist
+			if playlist.IsLibrary() {
+				err = restoreLibrary(playlist)
+				if err != nil {
+					fmt.Println(i18n.T("restore.error"), err)
 				}
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
+				pressEnter()
 				break
 			}
 
-			//Get date directories
-			dateDirs, err := os.ReadDir(backupDir)
+			restoreChoice, err := chooseOption(i18n.T("restore.where", playlist.Name), i18n.T("common.cancel"),
+				i18n.T("restore.existing"),
+				i18n.T("restore.new"))
 			if err != nil {
-				log.Error("Errore nella lettura delle cartelle di backup", "error", err, "userID", userID, "backupDir", backupDir)
 				return err
 			}
-
-			if len(dateDirs) == 0 {
-				log.Info("Nessuna cartella di backup trovata", "userID", userID, "backupDir", backupDir)
-				if isPersonal {
-					fmt.Println("Nessun backup trovato per l'utente corrente")
-				} else {
-					fmt.Println("Nessun backup di playlist di altri trovato")
+			if restoreChoice == 2 {
+				utils.ClearTerminal()
+				err = restoreAsNew(*file)
+				if err != nil {
+					fmt.Println(i18n.T("restore.error"), err)
 				}
-				fmt.Printf("\nPremi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
+				pressEnter()
 				break
 			}
-
-			log.Info("Cartelle di backup trovate", "count", len(dateDirs), "userID", userID)
-			fmt.Println("\n📅 Seleziona da quale data ripristinare:")
-			fmt.Println("=====================================")
-			validDateDirs := []os.DirEntry{}
-			dateIndex := 1
-			for _, d := range dateDirs {
-				if d.IsDir() {
-					// Se siamo nelle playlist personali, nascondi la cartella "altre"
-					if isPersonal && d.Name() == "altre" {
-						continue
-					}
-					fmt.Printf("📆 %d. %s\n", dateIndex, d.Name())
-					validDateDirs = append(validDateDirs, d)
-					dateIndex++
-				}
+			if restoreChoice != 1 {
+				break
 			}
-			fmt.Println("🔙 0. Torna al menu")
+			utils.ClearTerminal()
 
-			if len(validDateDirs) == 0 {
-				log.Warn("Nessuna cartella di backup valida trovata", "userID", userID)
-				fmt.Println("❌ Nessuna cartella di backup valida trovata.")
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
-				break
+			//Get current playlists to restore into
+			pl, err := spotify.GetPlaylists()
+			if err != nil {
+				return err
 			}
 
-			//Select date
-			fmt.Print("\n📅 Inserisci il numero della data: ")
-			var dateSelect int
-			_, err = fmt.Scan(&dateSelect)
+			selected, cancelled, err := choosePlaylist(i18n.T("restore.selectDestination", playlist.Name), pl)
 			if err != nil {
-				log.Error("Errore nella lettura della selezione data", "error", err, "userID", userID)
 				return err
 			}
-			if dateSelect == 0 {
-				log.Info("L'utente ha annullato la selezione della data", "userID", userID)
+			if cancelled {
+				log.Info(i18n.T("log.restoreDestinationCancelled"), "userID", userID)
 				break
 			}
-			if dateSelect < 1 || dateSelect > len(validDateDirs) {
-				log.Warn("Selezione data non valida per il ripristino", "selection", dateSelect, "max", len(validDateDirs), "userID", userID)
-				fmt.Println("❌ Selezione non valida")
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
+			if selected == nil {
+				pressEnter()
 				break
 			}
 
-			selectedDateDir := validDateDirs[dateSelect-1].Name()
+			//Restore playlist
 			utils.ClearTerminal()
-			playlistDir := backupDir + "/" + selectedDateDir
-			log.Info("Data selezionata per il ripristino", "date", selectedDateDir, "userID", userID, "isPersonal", isPersonal)
-
-			//Get playlist files from selected date
-			files, err := os.ReadDir(playlistDir)
+			fmt.Print(i18n.T("restore.inProgress", playlist.Name))
+			err = restoreItems(playlist, selected.ID)
 			if err != nil {
 				return err
 			}
 
-			if len(files) == 0 {
-				fmt.Printf("❌ Nessun backup trovato per la data %s.\n", selectedDateDir)
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
-				break
-			}
+			fmt.Print(i18n.T("restore.done", playlist.Name, selected.Name))
+			pressEnter()
 
-			fmt.Printf("\n🎵 Playlist salvate il %s:\n", selectedDateDir)
-			fmt.Println("=======================================")
-			validPlaylistFiles := []os.DirEntry{}
-			playlistIndex := 1
-			for _, f := range files {
-				if !f.IsDir() && len(f.Name()) > 5 && f.Name()[len(f.Name())-5:] == ".json" {
-					//Read file to get playlist name
-					tempData, err := os.ReadFile(playlistDir + "/" + f.Name())
-					if err != nil {
-						log.Warn("Impossibile leggere il file: " + f.Name())
-						continue
-					}
+		case 6: // Manage linked playlists
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.linked"), "userID", userID)
+			err := linkedMenu()
+			if err != nil {
+				log.Error(i18n.T("log.linkedError"), "error", err, "userID", userID)
+				return err
+			}
 
-					//Parse JSON
-					var tempPl spotify.Playlist
-					err = json.Unmarshal(tempData, &tempPl)
-					if err != nil {
-						log.Warn("Impossibile parsare il file JSON: " + f.Name())
-						continue
-					}
+		case 7: // Riautentica con Spotify
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.reauth"), "userID", userID)
+			fmt.Println(i18n.T("reauth.start"))
+			fmt.Println("===============================")
 
-					fmt.Printf("🎵 %d. %s (%s)\n", playlistIndex, tempPl.Name, f.Name())
-					validPlaylistFiles = append(validPlaylistFiles, f)
-					playlistIndex++
+			// Rimuove le credenziali esistenti
+			authDir := "data/auth"
+			if _, err := os.Stat(authDir); err == nil {
+				log.Info(i18n.T("log.reauthRemove"), "authDir", authDir)
+				fmt.Println(i18n.T("reauth.remove"))
+				err = os.RemoveAll(authDir)
+				if err != nil {
+					log.Error(i18n.T("log.reauthRemoveError"), "error", err, "authDir", authDir)
+					fmt.Println(i18n.T("reauth.removeError"), err)
+					fmt.Println(i18n.T("reauth.continue"))
+				} else {
+					log.Info(i18n.T("log.reauthRemoved"), "authDir", authDir)
+					fmt.Println(i18n.T("reauth.removed"))
 				}
+			} else {
+				log.Info(i18n.T("log.reauthNoCredentials"), "authDir", authDir)
 			}
 
-			fmt.Println("🔙 0. Torna al menu")
-			if len(validPlaylistFiles) == 0 {
-				fmt.Printf("❌ Nessun file di backup valido trovato per la data %s.\n", selectedDateDir)
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
-				break
+			fmt.Println(i18n.T("reauth.new"))
+			err := spotify.Auth()
+			if err != nil {
+				log.Error(i18n.T("log.reauthError"), "error", err, "userID", userID)
+				fmt.Println(i18n.T("reauth.error"), err)
+			} else {
+				log.Info(i18n.T("log.reauthDone"), "userID", userID)
+				fmt.Println(i18n.T("reauth.done"))
 			}
+			pressEnter()
 
-			//Select playlist file
-			fmt.Print("\n🔄 Inserisci il numero della playlist da caricare: ")
-			var playlistSelect int
-			_, err = fmt.Scan(&playlistSelect)
+		case 8: // Compare a backup with another backup or with the live playlist
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.diff"), "userID", userID)
+			diffChoice, err := chooseOption(i18n.T("diff.with"), i18n.T("common.backToMenu"),
+				i18n.T("diff.withBackup"),
+				i18n.T("diff.withLive"))
 			if err != nil {
 				return err
 			}
-			if playlistSelect == 0 {
+			if diffChoice != 1 && diffChoice != 2 {
 				break
 			}
-			if playlistSelect < 1 || playlistSelect > len(validPlaylistFiles) {
-				fmt.Println("❌ Selezione non valida")
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
-				break
+			utils.ClearTerminal()
+			err = diffMenu(diffChoice == 2)
+			if err != nil {
+				log.Error(i18n.T("log.diffError"), "error", err, "userID", userID)
+				return err
 			}
+			pressEnter()
 
-			//Read selected playlist file
-			selectedPlaylistFile := validPlaylistFiles[playlistSelect-1].Name()
+		case 9: // Prune old backups
 			utils.ClearTerminal()
-			data, err := os.ReadFile(playlistDir + "/" + selectedPlaylistFile)
+			log.Info(i18n.T("log.prune"), "userID", userID)
+			err = pruneMenu(userID)
 			if err != nil {
+				log.Error(i18n.T("log.pruneError"), "error", err, "userID", userID)
 				return err
 			}
+			pressEnter()
 
-			//Parse JSON
-			var playlist spotify.Playlist
-			err = json.Unmarshal(data, &playlist)
+		case 10: // Export a live playlist or a backup
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.export"), "userID", userID)
+			err = exportMenu()
 			if err != nil {
+				log.Error(i18n.T("log.exportError"), "error", err, "userID", userID)
 				return err
 			}
+			pressEnter()
 
-			//Get current playlists to restore into
-			pl, err := spotify.GetPlaylists()
+		case 11: // Import a playlist from a file
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.import"), "userID", userID)
+			err = importMenu()
 			if err != nil {
+				log.Error(i18n.T("log.importError"), "error", err, "userID", userID)
 				return err
 			}
+			pressEnter()
 
-			fmt.Printf("\n🎯 Seleziona la playlist di destinazione per '%s':\n", playlist.Name)
-			fmt.Println("======================================================")
-			for i, p := range pl {
-				fmt.Printf("🎵 %d. %s - %s\n", i+1, p.Name, p.ID)
+		case 12: // Backup the library of the user
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.library"), "userID", userID)
+			err = backupLibrary(userID)
+			if err != nil {
+				fmt.Println(i18n.T("library.saveError"), err)
 			}
-			fmt.Println("🔙 0. Annulla")
+			pressEnter()
 
-			fmt.Print("\n📥 Inserisci il numero della playlist in cui caricare i brani: ")
-			var sel int
-			_, err = fmt.Scan(&sel)
+		case 13: // Find and remove duplicated tracks
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.duplicates"), "userID", userID)
+			err = duplicatesMenu()
 			if err != nil {
+				log.Error(i18n.T("log.duplicatesError"), "error", err, "userID", userID)
 				return err
 			}
-			if sel == 0 {
-				log.Info("L'utente ha annullato la selezione della playlist di destinazione", "userID", userID)
-				break
-			}
-			if sel < 1 || sel > len(pl) {
-				fmt.Println("❌ Selezione non valida")
-				fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-				fmt.Scanf("\n\n")
-				break
+			pressEnter()
+
+		case 14: // Sort a playlist
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.sort"), "userID", userID)
+			err = sortMenu()
+			if err != nil {
+				log.Error(i18n.T("log.sortError"), "error", err, "userID", userID)
+				return err
 			}
+			pressEnter()
 
-			//Restore playlist
+		case 15: // Split or merge playlists
 			utils.ClearTerminal()
-			fmt.Printf("⏳ Ripristino di '%s' in corso...\n", playlist.Name)
-			err = spotify.AddTracksToPlaylist(playlist.TrackIDs, pl[sel-1].ID)
+			log.Info(i18n.T("log.split"), "userID", userID)
+			err = splitMergeMenu()
 			if err != nil {
+				log.Error(i18n.T("log.splitError"), "error", err, "userID", userID)
 				return err
 			}
+			pressEnter()
 
-			fmt.Printf("✅ Playlist '%s' caricata con successo in '%s'!\n", playlist.Name, pl[sel-1].Name)
-			fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-			fmt.Scanf("\n\n")
+		case 16: // Manage smart playlists
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.smart"), "userID", userID)
+			err = smartMenu()
+			if err != nil {
+				log.Error(i18n.T("log.smartError"), "error", err, "userID", userID)
+				return err
+			}
+			pressEnter()
 
-		case 6: // Manage linked playlists
+		case 17: // Statistics of the playlists and of the library
 			utils.ClearTerminal()
-			log.Info("L'utente ha richiesto la gestione delle playlist collegate", "userID", userID)
-			err := linkedMenu()
+			log.Info(i18n.T("log.stats"), "userID", userID)
+			err = statsMenu()
 			if err != nil {
-				log.Error("Errore nella gestione delle playlist collegate", "error", err, "userID", userID)
+				log.Error(i18n.T("log.statsError"), "error", err, "userID", userID)
 				return err
 			}
+			pressEnter()
 
-		case 7: // Riautentica con Spotify
+		case 18: // Compare playlists
 			utils.ClearTerminal()
-			log.Info("L'utente ha richiesto la riautenticazione", "userID", userID)
-			fmt.Println("🔑 Riautenticazione in corso...")
-			fmt.Println("===============================")
+			log.Info(i18n.T("log.compare"), "userID", userID)
+			err = compareMenu()
+			if err != nil {
+				log.Error(i18n.T("log.compareError"), "error", err, "userID", userID)
+				return err
+			}
+			pressEnter()
 
-			// Rimuove le credenziali esistenti
-			authDir := "data/auth"
-			if _, err := os.Stat(authDir); err == nil {
-				log.Info("Rimozione credenziali esistenti", "authDir", authDir)
-				fmt.Println("🗑️ Rimozione credenziali esistenti...")
-				err = os.RemoveAll(authDir)
-				if err != nil {
-					log.Error("Errore nella rimozione delle credenziali", "error", err, "authDir", authDir)
-					fmt.Println("⚠️ Errore nella rimozione delle credenziali:", err)
-					fmt.Println("🔄 Procedo comunque con la riautenticazione...")
-				} else {
-					log.Info("Credenziali rimosse con successo", "authDir", authDir)
-					fmt.Println("✅ Credenziali rimosse con successo")
-				}
-			} else {
-				log.Info("Nessuna credenziale esistente da rimuovere", "authDir", authDir)
+		case 19: // Search in the playlists and in the backups
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.search"), "userID", userID)
+			err = searchMenu()
+			if err != nil {
+				log.Error(i18n.T("log.searchError"), "error", err, "userID", userID)
+				return err
 			}
+			pressEnter()
 
-			fmt.Println("🔐 Avvio nuova autenticazione...")
-			err := spotify.Auth()
+		case 20: // Find the playlists that contain a track
+			utils.ClearTerminal()
+			log.Info(i18n.T("log.where"), "userID", userID)
+			err = whereMenu()
 			if err != nil {
-				log.Error("Errore durante la riautenticazione", "error", err, "userID", userID)
-				fmt.Println("❌ Errore nella riautenticazione:", err)
-			} else {
-				log.Info("Riautenticazione completata con successo", "userID", userID)
-				fmt.Println("✅ Riautenticazione completata con successo!")
+				log.Error(i18n.T("log.whereError"), "error", err, "userID", userID)
+				return err
 			}
-			fmt.Printf("\n⏎ Premi invio per tornare al menu...")
-			fmt.Scanf("\n\n")
+			pressEnter()
 
 		default:
-			log.Warn("Scelta menu non valida", "selection", sel, "userID", userID)
-			fmt.Println("❌ Scelta non valida o non ancora implementata")
+			log.Warn(i18n.T("log.invalidChoice"), "selection", sel, "userID", userID)
+			fmt.Println(i18n.T("menu.invalidChoice"))
 		}
 		utils.ClearTerminal()
 	}
 }
 
+/*
+showTracks shows the tracks of a playlist, in a full-screen list to scroll and filter if the interface is enabled
+Returns an error, if present
+*/
+func showTracks(selectedPlaylist api.SimplePlaylist) error {
+	log.Info(i18n.T("log.tracksPlaylist"), "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID)
+	tracks, err := spotify.GetTracks(selectedPlaylist.ID)
+	if err != nil {
+		log.Error(i18n.T("log.tracksError"), "error", err, "playlistName", selectedPlaylist.Name, "playlistID", selectedPlaylist.ID, "userID", userID)
+		return err
+	}
+	log.Info(i18n.T("log.tracksFetched"), "trackCount", len(tracks), "playlistName", selectedPlaylist.Name, "userID", userID)
+	lines := make([]string, len(tracks))
+	for i, t := range tracks {
+		item, ok := spotify.PlaylistItemDetails(t)
+		switch {
+		case !ok:
+			lines[i] = i18n.T("tracks.unavailable", i+1)
+			log.Warn(i18n.T("log.tracksUnavailable"), "playlistID", selectedPlaylist.ID, "position", i+1)
+		default:
+			lines[i] = fmt.Sprintf("%s %d. %s", itemIcon(item), i+1, item)
+		}
+	}
+	return browse(i18n.T("tracks.title", selectedPlaylist.Name), lines)
+}
+
+// menuTitle returns the title of a full-screen menu, with the version and the user; without the full-screen interface only the name
+func menuTitle(name string) string {
+	if !tui.Enabled() {
+		return name
+	}
+	status := i18n.T("auth.notAuthenticatedShort")
+	if spotify.IsAuthenticated() {
+		loadUserID()
+		status = "👤 " + userID
+	}
+	return fmt.Sprintf("%s · 🎧 Playlist Manager %s · %s", name, VERSION, status)
+}
+
+// loadUserID reads the ID of the user from Spotify, if not already read
+func loadUserID() {
+	if userID != "" {
+		return
+	}
+	var err error
+	userID, err = spotify.GetUserID()
+	if err != nil {
+		log.Error(i18n.T("log.userIDError"), "error", err)
+		return
+	}
+	log.Info(i18n.T("log.userIDDone"), "userID", userID)
+}
+
 func displayAuthStatus() {
-	fmt.Print("🔐 Autenticato: ")
+	fmt.Print(i18n.T("auth.authenticated"))
 	if spotify.IsAuthenticated() {
 		fmt.Println("✅")
 
 		//Display user ID
-		if userID == "" {
-			var err error
-			userID, err = spotify.GetUserID()
-			if err != nil {
-				log.Error("Errore nel recupero dell'ID dell'utente", "error", err)
-			} else {
-				log.Info("UserID recuperato con successo", "userID", userID)
-				fmt.Println("👤 Utente:", userID)
-			}
-		} else {
-			fmt.Println("👤 Utente:", userID)
+		loadUserID()
+		if userID != "" {
+			fmt.Println(i18n.T("auth.user"), userID)
 		}
 
 	} else {
-		log.Warn("Utente non autenticato")
+		log.Warn(i18n.T("log.notAuthenticated"))
 		fmt.Println("❌")
 	}
 }
 
 // savePlaylistAsJSON salva una playlist mostrando un'animazione di caricamento
-func savePlaylistAsJSON(playlist api.SimplePlaylist, userID string) (backupDir string, err error) {
-	log.Info("Inizio salvataggio playlist (con animazione)", "playlistName", playlist.Name, "playlistID", playlist.ID, "userID", userID)
-	fmt.Printf("💾 Salvataggio di '%s' in corso ", playlist.Name)
+func savePlaylistAsJSON(playlist api.SimplePlaylist, userID string) (backupDir string, unchanged bool, err error) {
+	log.Info(i18n.T("log.saveStart"), "playlistName", playlist.Name, "playlistID", playlist.ID, "userID", userID)
+	fmt.Print(i18n.T("backup.saving", playlist.Name))
 
 	// Esegui il salvataggio in una goroutine
 	done := make(chan bool)
 	errChan := make(chan error)
 
 	go func() {
-		backupDir, err = spotify.SavePlaylistAsJSON(playlist, userID)
+		backupDir, unchanged, err = spotify.SavePlaylistAsJSON(playlist, userID)
 		if err != nil {
-			log.Error("Errore durante il salvataggio", "error", err, "playlistName", playlist.Name, "playlistID", playlist.ID, "userID", userID, "backupDir", backupDir)
+			log.Error(i18n.T("log.saveError"), "error", err, "playlistName", playlist.Name, "playlistID", playlist.ID, "userID", userID, "backupDir", backupDir)
 			errChan <- err
 		} else {
-			log.Info("Salvataggio completato con successo", "playlistName", playlist.Name, "playlistID", playlist.ID, "userID", userID, "backupDir", backupDir)
+			log.Info(i18n.T("log.saveDone"), "playlistName", playlist.Name, "playlistID", playlist.ID, "userID", userID, "backupDir", backupDir, "unchanged", unchanged)
 			done <- true
 		}
 	}()
@@ -593,11 +624,15 @@ func savePlaylistAsJSON(playlist api.SimplePlaylist, userID string) (backupDir s
 	for {
 		select {
 		case <-done:
-			fmt.Printf("\n✅ Playlist '%s' salvata come %s.json\n\n", playlist.Name, string(playlist.ID))
-			return backupDir, nil
+			if unchanged {
+				fmt.Print(i18n.T("backup.unchanged", playlist.Name, backupDir))
+			} else {
+				fmt.Print(i18n.T("backup.saved", playlist.Name, string(playlist.ID)))
+			}
+			return backupDir, unchanged, nil
 		case err := <-errChan:
-			fmt.Printf("\n❌ Errore nel salvataggio della playlist '%s': %v\n\n", playlist.Name, err)
-			return "", err
+			fmt.Print(i18n.T("backup.saveErrorLine", playlist.Name, err))
+			return "", false, err
 		default:
 			fmt.Printf("%s", spinChars[i%4])
 			i++
@@ -0,0 +1,301 @@
+package terminal
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"playlist-manager/internal/backup"
+	"playlist-manager/internal/link"
+	"playlist-manager/internal/selection"
+	"playlist-manager/internal/spotify"
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+
+	api "github.com/zmb3/spotify/v2"
+
+	log "playlist-manager/pkg/logger"
+)
+
+// whereResults is the number of tracks found by a text search among which the user chooses
+const whereResults = 5
+
+// occurrences are the positions of a track in a playlist, with the snapshot ID of the version read
+type occurrences struct {
+	Playlist   api.SimplePlaylist
+	SnapshotID string
+	Items      []backup.Change
+	// True if the user can remove the track: the playlist is owned by the user or collaborative
+	Editable bool
+}
+
+/*
+resolveTrack finds the track given its URI, its open.spotify.com link, its ID or a text to search.
+With a text the user chooses among the first results if choose is true, otherwise the first one is used
+Returns the track (nil if nothing has been found or the user cancelled) and an error, if present
+*/
+func resolveTrack(input string, choose bool) (*api.FullTrack, error) {
+	if _, err := link.Parse(input); err == nil {
+		id, err := link.ParseKind(input, link.Track)
+		if err != nil {
+			return nil, err
+		}
+		tracks, err := spotify.GetTrackDetails([]api.ID{id})
+		if err != nil {
+			return nil, err
+		}
+		if len(tracks) == 0 || tracks[0] == nil {
+			return nil, nil
+		}
+		return tracks[0], nil
+	}
+	results, err := spotify.SearchTracks(input, whereResults)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	if !choose {
+		return &results[0], nil
+	}
+	options := make([]string, len(results))
+	for i, t := range results {
+		options[i] = fmt.Sprintf("🎵 %s (%s, %s)", fullTrackName(t), t.Album.Name, utils.FormatDuration(int(t.Duration)))
+	}
+	sel, err := chooseOption(i18n.T("where.which"), i18n.T("common.cancel"), options...)
+	if err != nil {
+		return nil, err
+	}
+	if sel < 1 || sel > len(results) {
+		return nil, nil
+	}
+	return &results[sel-1], nil
+}
+
+// fullTrackName returns the track formatted as "Name di Artist1, Artist2"
+func fullTrackName(t api.FullTrack) string {
+	artists := make([]string, 0, len(t.Artists))
+	for _, a := range t.Artists {
+		artists = append(artists, a.Name)
+	}
+	return backup.Track{Name: t.Name, Artists: artists}.String()
+}
+
+/*
+findOccurrences reads all the playlists of the user (owned and followed) and finds the positions of the track.
+With sameISRC also the other versions of the track with the same ISRC (for example from a compilation) are found
+Returns the playlists that contain the track and an error, if present
+*/
+func findOccurrences(track *api.FullTrack, sameISRC bool) (res []occurrences, err error) {
+	pl, err := spotify.GetPlaylists()
+	if err != nil {
+		log.Error(i18n.T("log.playlistsError"), "error", err, "userID", userID)
+		return nil, err
+	}
+	isrc := track.ExternalIDs["isrc"]
+	for i, p := range pl {
+		fmt.Print(i18n.T("where.reading", i+1, len(pl)))
+		items, snapshotID, err := spotify.GetPositionedItems(p.ID)
+		if err != nil {
+			fmt.Println()
+			log.Error(i18n.T("log.tracksError"), "error", err, "playlistName", p.Name, "playlistID", p.ID)
+			return nil, err
+		}
+		o := occurrences{Playlist: p, SnapshotID: snapshotID, Editable: p.Owner.ID == userID || p.Collaborative}
+		for _, c := range items {
+			if c.Track.ID == track.ID || (sameISRC && isrc != "" && c.Track.ISRC == isrc) {
+				o.Items = append(o.Items, c)
+			}
+		}
+		if len(o.Items) > 0 {
+			res = append(res, o)
+		}
+	}
+	fmt.Println()
+	log.Info(i18n.T("log.whereDone"), "trackID", track.ID, "playlists", len(pl), "found", len(res))
+	return res, nil
+}
+
+// printOccurrences prints the playlists that contain the track, numbered, with the positions and the date each occurrence was added
+func printOccurrences(track *api.FullTrack, found []occurrences) {
+	if len(found) == 0 {
+		fmt.Print(i18n.T("where.none", fullTrackName(*track)))
+		return
+	}
+	fmt.Print(i18n.N("where.found", len(found), fullTrackName(*track), len(found)))
+	fmt.Println("=======================================")
+	for i, o := range found {
+		lock := ""
+		if !o.Editable {
+			lock = " 🔒"
+		}
+		fmt.Printf("%d. %s%s\n", i+1, o.Playlist.Name, lock)
+		for _, c := range o.Items {
+			text := fmt.Sprintf("   #%d", c.Position)
+			if c.Track.ID != track.ID {
+				text += " " + c.Track.String() + i18n.T("where.sameISRC")
+			}
+			if c.Track.AddedAt != "" {
+				text += i18n.T("duplicates.addedOn") + c.Track.AddedAt
+			}
+			fmt.Println(text)
+		}
+	}
+}
+
+/*
+removeOccurrences removes the track, at the positions found, from the playlists, stopping at the first error
+Returns an error, if present
+*/
+func removeOccurrences(found []occurrences) error {
+	for _, o := range found {
+		if !o.Editable {
+			fmt.Print(i18n.T("where.notEditable", o.Playlist.Name))
+			continue
+		}
+		_, err := spotify.RemovePositions(o.Playlist.ID, o.SnapshotID, o.Items)
+		if err != nil {
+			log.Error(i18n.T("log.whereRemoveError"), "error", err, "playlistName", o.Playlist.Name, "playlistID", o.Playlist.ID)
+			return fmt.Errorf("%s: %w", o.Playlist.Name, err)
+		}
+		log.Info(i18n.T("log.whereRemoved"), "playlistName", o.Playlist.Name, "playlistID", o.Playlist.ID, "removed", len(o.Items))
+		fmt.Print(i18n.T("where.removed", o.Playlist.Name, len(o.Items)))
+	}
+	return nil
+}
+
+/*
+chooseOccurrences asks the playlists from which to remove the track (see selection.Parse), the ones owned are those that can be edited
+Returns the chosen playlists (empty if the user cancelled) and an error, if present
+*/
+func chooseOccurrences(found []occurrences) ([]occurrences, error) {
+	items := make([]selection.Item, len(found))
+	for i, o := range found {
+		items[i] = selection.Item{Name: o.Playlist.Name, Mine: o.Editable}
+	}
+	fmt.Println(i18n.T(selectionHint))
+	fmt.Print(i18n.T("where.playlists"))
+	sel, _, err := scanSelection(items)
+	if err != nil {
+		return nil, err
+	}
+	chosen := make([]occurrences, 0, len(sel))
+	for _, i := range sel {
+		chosen = append(chosen, found[i])
+	}
+	return chosen, nil
+}
+
+/*
+whereMenu asks a track (URI, link or text to search), shows the playlists that contain it and removes it from all or some of them
+Returns an error, if present
+*/
+func whereMenu() error {
+	fmt.Print(i18n.T("where.track"))
+	input := readLine()
+	if input == "" {
+		fmt.Println(i18n.T("common.cancelled"))
+		return nil
+	}
+	track, err := resolveTrack(input, true)
+	if err != nil {
+		log.Error(i18n.T("log.whereSearchError"), "error", err, "input", input)
+		fmt.Println(i18n.T("where.searchError"), err)
+		return nil
+	}
+	if track == nil {
+		fmt.Println(i18n.T("where.notFound"))
+		return nil
+	}
+	fmt.Print(i18n.T("where.isrc"))
+	isrc, _ := readAnswer()
+
+	utils.ClearTerminal()
+	found, err := findOccurrences(track, isrc == i18n.T("answer.yes"))
+	if err != nil {
+		fmt.Println(i18n.T("where.readError"), err)
+		return nil
+	}
+	printOccurrences(track, found)
+	editable := 0
+	for _, o := range found {
+		if o.Editable {
+			editable++
+		}
+	}
+	if editable == 0 {
+		return nil
+	}
+
+	keepOutput()
+	choice, err := chooseOption(i18n.T("where.remove"), i18n.T("common.no"), i18n.T("where.fromAll", editable), i18n.T("where.choose"))
+	if err != nil {
+		return err
+	}
+	var remove []occurrences
+	switch choice {
+	case 1:
+		remove = found
+	case 2:
+		remove, err = chooseOccurrences(found)
+		if err != nil {
+			return err
+		}
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+	err = removeOccurrences(remove)
+	if err != nil {
+		fmt.Println(i18n.T("where.removeError"), err)
+	}
+	return nil
+}
+
+// whereCommand lists the playlists that contain a track and, with -remove, removes it from the editable ones
+func whereCommand(fs *flag.FlagSet, args []string) int {
+	isrc := fs.Bool("isrc", false, i18n.T("flag.where.isrc"))
+	remove := fs.Bool("remove", false, i18n.T("flag.where.remove"))
+	err := fs.Parse(args)
+	if err != nil {
+		return ExitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return ExitError
+	}
+	// Without confirmation the track to remove can't be the first result of a search, that could be the wrong one
+	if _, err := link.Parse(fs.Arg(0)); *remove && err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("where.linkOnly"))
+		return ExitError
+	}
+	if !authCommand() {
+		return ExitError
+	}
+	userID, err = spotify.GetUserID()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("common.userError"), err)
+		return ExitError
+	}
+	track, err := resolveTrack(fs.Arg(0), false)
+	if err == nil && track == nil {
+		err = errors.New(i18n.T("where.noTrack"))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("where.searchError"), err)
+		return ExitError
+	}
+	found, err := findOccurrences(track, *isrc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("where.readError"), err)
+		return ExitError
+	}
+	printOccurrences(track, found)
+	if !*remove {
+		return ExitOK
+	}
+	err = removeOccurrences(found)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("where.removeError"), err)
+		return ExitError
+	}
+	return ExitOK
+}
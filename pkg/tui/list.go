@@ -0,0 +1,292 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"playlist-manager/pkg/i18n"
+	"playlist-manager/pkg/utils"
+)
+
+// Item is an element of a List
+type Item struct {
+	Title string
+	// Lines shown in the detail pane while the item is highlighted, the pane is shown only if at least an item has details
+	Detail []string
+}
+
+// List is a full-screen list of items, navigated with the arrows and filtered by typing
+type List struct {
+	Title string
+	Items []Item
+	// If true more items can be selected with Tab (Ctrl+A for all the ones shown)
+	Multi bool
+	// Text shown under the keys, for example the other values accepted in the filter
+	Hint string
+}
+
+// Result is what the user chose in a List
+type Result struct {
+	// Indexes of the chosen items, in the order of the list
+	Indexes []int
+	// Text of the filter when the user pressed enter without any item shown, for example a link or a selection like 1,4,7-12
+	Query string
+}
+
+// listState is the state of a List while it is shown
+type listState struct {
+	List
+	filter   string
+	shown    []int // Indexes of the items that match the filter
+	cursor   int   // Position of the highlighted item in shown
+	offset   int   // Position in shown of the first row of the page
+	selected map[int]bool
+}
+
+/*
+Run shows the list and waits for the choice of the user. Keys: arrows, PgUp/PgDn, Home/End to move, characters to filter,
+Backspace and Ctrl+U to edit the filter, Enter to choose, Tab and Ctrl+A to select more items (Multi), Esc to clear the filter or to go back
+Returns the choice, false if the user went back and an error, if present
+*/
+func (l List) Run() (Result, bool, error) {
+	s, err := openScreen()
+	if err != nil {
+		return Result{}, false, err
+	}
+	defer s.close()
+
+	st := &listState{List: l, selected: map[int]bool{}}
+	st.applyFilter()
+	for {
+		st.draw(s)
+		keys, err := readKeys()
+		if err != nil {
+			return Result{}, false, err
+		}
+		for _, k := range keys {
+			res, done, ok := st.handle(k, s.height)
+			if done {
+				return res, ok, nil
+			}
+		}
+	}
+}
+
+// handle applies a key to the state, returning the result and true if the list must be closed (ok is false if the user went back)
+func (st *listState) handle(k key, height int) (res Result, done bool, ok bool) {
+	page := max(1, st.rows(height))
+	switch k.code {
+	case keyUp:
+		st.move(-1)
+	case keyDown:
+		st.move(1)
+	case keyPageUp:
+		st.move(-page)
+	case keyPageDown:
+		st.move(page)
+	case keyHome:
+		st.move(-len(st.shown))
+	case keyEnd:
+		st.move(len(st.shown))
+	case keyTab:
+		if st.Multi && len(st.shown) > 0 {
+			i := st.shown[st.cursor]
+			st.selected[i] = !st.selected[i]
+			st.move(1)
+		}
+	case keyCtrlA:
+		if st.Multi {
+			all := true
+			for _, i := range st.shown {
+				all = all && st.selected[i]
+			}
+			for _, i := range st.shown {
+				st.selected[i] = !all
+			}
+		}
+	case keyBackspace:
+		if f := []rune(st.filter); len(f) > 0 {
+			st.filter = string(f[:len(f)-1])
+			st.applyFilter()
+		}
+	case keyCtrlU:
+		st.filter = ""
+		st.applyFilter()
+	case keyRune:
+		st.filter += string(k.r)
+		st.applyFilter()
+	case keyEsc:
+		if st.filter == "" {
+			return Result{}, true, false
+		}
+		st.filter = ""
+		st.applyFilter()
+	case keyCtrlC:
+		return Result{}, true, false
+	case keyEnter:
+		return st.result()
+	}
+	return Result{}, false, false
+}
+
+// result returns the choice of the user when enter is pressed: the selected items, the highlighted one or the text of the filter
+func (st *listState) result() (Result, bool, bool) {
+	var res Result
+	for i := range st.Items {
+		if st.selected[i] {
+			res.Indexes = append(res.Indexes, i)
+		}
+	}
+	switch {
+	case len(res.Indexes) > 0:
+	case len(st.shown) > 0:
+		res.Indexes = []int{st.shown[st.cursor]}
+	case strings.TrimSpace(st.filter) != "":
+		res.Query = strings.TrimSpace(st.filter)
+	default:
+		return res, false, false
+	}
+	return res, true, true
+}
+
+// applyFilter shows only the items whose title contains all the words of the filter, ignoring the case
+func (st *listState) applyFilter() {
+	words := strings.Fields(utils.Lower(st.filter))
+	st.shown = st.shown[:0]
+	for i, it := range st.Items {
+		title := utils.Lower(it.Title)
+		match := true
+		for _, w := range words {
+			match = match && strings.Contains(title, w)
+		}
+		if match {
+			st.shown = append(st.shown, i)
+		}
+	}
+	st.cursor, st.offset = 0, 0
+}
+
+// move moves the highlighted item by delta positions, within the items shown
+func (st *listState) move(delta int) {
+	st.cursor = min(max(st.cursor+delta, 0), max(len(st.shown)-1, 0))
+}
+
+// rows returns the number of rows of the list in a terminal of the given height: the title, the filter and the keys take 4 rows
+func (st *listState) rows(height int) int {
+	return height - 4
+}
+
+// detailLines returns the highest number of lines of the details of an item, 0 if the detail pane is not shown
+func (st *listState) detailLines() (n int) {
+	for _, it := range st.Items {
+		n = max(n, len(it.Detail))
+	}
+	return n
+}
+
+// draw draws the list: the title, the filter, the page of the items, the detail pane (on the right or, if the terminal is narrow, at the bottom) and the keys
+func (st *listState) draw(s *screen) {
+	s.begin()
+	s.line(0, bold, " "+st.Title)
+	filter := fmt.Sprintf(" 🔍 %s▏", st.filter)
+	if st.filter == "" {
+		filter = i18n.T("tui.filterPlaceholder")
+	}
+	count := fmt.Sprintf("%d/%d", len(st.shown), len(st.Items))
+	if n := st.countSelected(); n > 0 {
+		count = i18n.T("tui.selectedCount", n, count)
+	}
+	s.line(1, faint, pad(filter, s.width-width(count)-1)+count)
+
+	rows := st.rows(s.height)
+	listWidth := s.width
+	var detail []string
+	if len(st.shown) > 0 {
+		detail = st.Items[st.shown[st.cursor]].Detail
+	}
+	lines := st.detailLines()
+	side := lines > 0 && s.width >= 80
+	bottom := 0
+	if lines > 0 && !side {
+		// The pane at the bottom takes the rows needed by the longest details, but the list keeps at least half of them
+		bottom = min(lines+1, rows/2)
+		rows -= bottom
+	}
+	if side {
+		listWidth = s.width * 55 / 100
+	}
+	rows = max(rows, 1)
+
+	// The page follows the highlighted item
+	if st.cursor < st.offset {
+		st.offset = st.cursor
+	} else if st.cursor >= st.offset+rows {
+		st.offset = st.cursor - rows + 1
+	}
+	for r := 0; r < rows; r++ {
+		text := ""
+		style := ""
+		if p := st.offset + r; p < len(st.shown) {
+			i := st.shown[p]
+			mark := ""
+			if st.Multi {
+				mark = "[ ] "
+				if st.selected[i] {
+					mark = "[x] "
+				}
+			}
+			text = " " + mark + st.Items[i].Title
+			if p == st.cursor {
+				style = reverse
+			}
+		} else if r == 0 && len(st.shown) == 0 {
+			text = i18n.T("tui.noMatch")
+			style = faint
+		}
+		text = pad(text, listWidth)
+		if style != "" {
+			text = style + text + reset
+		}
+		if side {
+			line := ""
+			if r < len(detail) {
+				line = " " + detail[r]
+			}
+			text += "│" + truncate(line, s.width-listWidth-1)
+		}
+		s.line(2+r, "", text)
+	}
+	last := 1 + rows
+	if bottom > 0 {
+		last++
+		s.line(last, faint, strings.Repeat("─", s.width))
+		for r := 0; r < bottom-1; r++ {
+			last++
+			line := ""
+			if r < len(detail) {
+				line = " " + detail[r]
+			}
+			s.line(last, "", line)
+		}
+	}
+
+	pages := max(1, (len(st.shown)+rows-1)/rows)
+	keys := i18n.T("tui.keys")
+	if st.Multi {
+		keys = i18n.T("tui.keysMulti")
+	}
+	keys += i18n.T("tui.page", st.offset/rows+1, pages)
+	s.line(s.height-2, faint, keys)
+	s.line(s.height-1, faint, " "+st.Hint)
+	s.flush(s.height - 1)
+}
+
+// countSelected returns the number of items selected
+func (st *listState) countSelected() (n int) {
+	for _, sel := range st.selected {
+		if sel {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"playlist-manager/pkg/i18n"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Progress is a progress bar drawn on the current line of the terminal, for the operations on many playlists like the backups and the syncs
+type Progress struct {
+	title string
+	total int
+	done  int
+	start time.Time
+}
+
+// NewProgress returns a progress bar of an operation of total steps
+func NewProgress(title string, total int) *Progress {
+	return &Progress{title: title, total: total, start: time.Now()}
+}
+
+// Next draws the bar when a step starts, with its label (for example the name of the playlist)
+func (p *Progress) Next(label string) {
+	p.draw(p.done+1, label)
+	p.done = min(p.done+1, p.total)
+}
+
+// NextLine is Next for the operations that print their output during the steps: the bar is left on its own line
+func (p *Progress) NextLine(label string) {
+	p.Next(label)
+	if enabled {
+		fmt.Println()
+	}
+}
+
+// Println prints a message during a step, on the line of the bar that is drawn again by the next step
+func (p *Progress) Println(a ...any) {
+	if enabled {
+		fmt.Print("\r" + clearLine)
+	}
+	fmt.Println(a...)
+}
+
+// Done completes the bar, leaving it on screen with the total time
+func (p *Progress) Done() {
+	p.done = p.total
+	p.draw(p.total, i18n.T("tui.completed", time.Since(p.start).Round(time.Second)))
+	if enabled {
+		fmt.Println()
+	}
+}
+
+// draw draws the bar of the step n, filled for the steps done; without the full-screen interface a line is printed for each step instead
+func (p *Progress) draw(n int, label string) {
+	if !enabled {
+		fmt.Printf("⏳ %s %d/%d %s\n", p.title, n, p.total, label)
+		return
+	}
+	w, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || w <= 0 {
+		w = 80
+	}
+	const barWidth = 24
+	filled := barWidth
+	if p.total > 0 {
+		filled = barWidth * p.done / p.total
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	text := fmt.Sprintf("⏳ %s [%s] %d/%d %s", p.title, bar, n, p.total, label)
+	fmt.Print("\r" + clearLine + truncate(text, w-1))
+}
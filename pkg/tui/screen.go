@@ -0,0 +1,294 @@
+// Contents: full-screen interface in the terminal: raw mode, alternate screen, keys and text width
+package tui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+
+	"playlist-manager/pkg/utils"
+)
+
+// enabled is true if the full-screen interface is used, see Init
+var enabled bool
+
+// Init enables the full-screen interface if use is true and both the standard input and output are a terminal
+func Init(use bool) {
+	enabled = use && term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		utils.EnableVT()
+	}
+}
+
+// Enabled returns true if the full-screen interface is used, otherwise the menus are numbered lists
+func Enabled() bool {
+	return enabled
+}
+
+// Escape sequences used to draw the screen
+const (
+	altScreenOn  = "\x1b[?1049h"
+	altScreenOff = "\x1b[?1049l"
+	cursorHide   = "\x1b[?25l"
+	cursorShow   = "\x1b[?25h"
+	clearLine    = "\x1b[K"
+	reverse      = "\x1b[7m"
+	bold         = "\x1b[1m"
+	faint        = "\x1b[2m"
+	reset        = "\x1b[0m"
+)
+
+// screen is the terminal in raw mode on the alternate screen, restored by close
+type screen struct {
+	state  *term.State
+	width  int
+	height int
+	buf    strings.Builder
+}
+
+/*
+openScreen puts the terminal in raw mode and switches to the alternate screen, so the content of the terminal is restored at the end
+Returns the screen and an error, if present
+*/
+func openScreen() (*screen, error) {
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	s := &screen{state: state}
+	os.Stdout.WriteString(altScreenOn + cursorHide)
+	return s, nil
+}
+
+// close restores the terminal as it was before openScreen
+func (s *screen) close() {
+	os.Stdout.WriteString(cursorShow + altScreenOff)
+	term.Restore(int(os.Stdin.Fd()), s.state)
+}
+
+// begin starts drawing a new frame, reading the size of the terminal that may have been resized
+func (s *screen) begin() {
+	w, h, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || w <= 0 || h <= 0 {
+		w, h = 80, 24
+	}
+	s.width, s.height = w, h
+	s.buf.Reset()
+	s.buf.WriteString("\x1b[H")
+}
+
+// line writes a row of the frame, cut to the width of the terminal; the rows are numbered from 0
+func (s *screen) line(row int, style string, text string) {
+	if row >= s.height {
+		return
+	}
+	s.buf.WriteString("\x1b[" + strconv.Itoa(row+1) + ";1H" + clearLine)
+	if style != "" {
+		s.buf.WriteString(style + pad(text, s.width) + reset)
+		return
+	}
+	s.buf.WriteString(truncate(text, s.width))
+}
+
+// flush writes the frame to the terminal, clearing the rows after last
+func (s *screen) flush(last int) {
+	for row := last + 1; row < s.height; row++ {
+		s.buf.WriteString("\x1b[" + strconv.Itoa(row+1) + ";1H" + clearLine)
+	}
+	os.Stdout.WriteString(s.buf.String())
+}
+
+// Keys read from the terminal in raw mode, the other keys are the characters typed
+const (
+	keyNone = iota
+	keyRune
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEsc
+	keyBackspace
+	keyTab
+	keyCtrlA
+	keyCtrlC
+	keyCtrlU
+)
+
+// key is a key pressed by the user, with the character typed for keyRune
+type key struct {
+	code int
+	r    rune
+}
+
+/*
+readKeys reads what the user typed through utils.Stdin, shared with the prompts: a terminal sends an escape sequence in a single write,
+so an Esc alone is the Esc key. A character split between two reads is completed before decoding it
+Returns the keys and an error, if present
+*/
+func readKeys() ([]key, error) {
+	buf := make([]byte, 64)
+	n, err := utils.Stdin.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	b := buf[:n]
+	for incompleteRune(b) {
+		c, err := utils.Stdin.ReadByte()
+		if err != nil {
+			break
+		}
+		b = append(b, c)
+	}
+	return parseKeys(b), nil
+}
+
+// incompleteRune returns true if b ends with the first bytes of a character of more bytes, that would be decoded as U+FFFD
+func incompleteRune(b []byte) bool {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			return !utf8.FullRune(b[i:])
+		}
+	}
+	return false
+}
+
+// parseKeys decodes the bytes read from the terminal
+func parseKeys(b []byte) (keys []key) {
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b && len(b) == 1:
+			keys = append(keys, key{code: keyEsc})
+			b = b[1:]
+		case c == 0x1b:
+			k, size := parseEscape(b)
+			keys = append(keys, key{code: k})
+			b = b[size:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, key{code: keyEnter})
+			b = b[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, key{code: keyBackspace})
+			b = b[1:]
+		case c == '\t':
+			keys = append(keys, key{code: keyTab})
+			b = b[1:]
+		case c == 0x01:
+			keys = append(keys, key{code: keyCtrlA})
+			b = b[1:]
+		case c == 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+			b = b[1:]
+		case c == 0x15:
+			keys = append(keys, key{code: keyCtrlU})
+			b = b[1:]
+		case c == 0x0e: // Ctrl+N
+			keys = append(keys, key{code: keyDown})
+			b = b[1:]
+		case c == 0x10: // Ctrl+P
+			keys = append(keys, key{code: keyUp})
+			b = b[1:]
+		case c < 0x20:
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, key{code: keyRune, r: r})
+			b = b[size:]
+		}
+	}
+	return keys
+}
+
+// parseEscape decodes an escape sequence (ESC [ ... or ESC O ...) at the beginning of b, returning the key (keyNone if unknown) and its length
+func parseEscape(b []byte) (int, int) {
+	if len(b) < 3 || (b[1] != '[' && b[1] != 'O') {
+		return keyEsc, 1
+	}
+	// The sequence ends with a letter or a ~, after the numeric parameters
+	end := 2
+	for end < len(b) && (b[end] >= '0' && b[end] <= '9' || b[end] == ';') {
+		end++
+	}
+	if end == len(b) {
+		return keyNone, len(b)
+	}
+	params := string(b[2:end])
+	switch b[end] {
+	case 'A':
+		return keyUp, end + 1
+	case 'B':
+		return keyDown, end + 1
+	case 'C':
+		return keyRight, end + 1
+	case 'D':
+		return keyLeft, end + 1
+	case 'H':
+		return keyHome, end + 1
+	case 'F':
+		return keyEnd, end + 1
+	case '~':
+		switch params {
+		case "5":
+			return keyPageUp, end + 1
+		case "6":
+			return keyPageDown, end + 1
+		case "1", "7":
+			return keyHome, end + 1
+		case "4", "8":
+			return keyEnd, end + 1
+		}
+	}
+	return keyNone, end + 1
+}
+
+// width returns the number of columns of the text in the terminal: the emoji take two columns, the joiners and the variation selectors none
+func width(s string) (w int) {
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+// runeWidth returns the number of columns of a character, see width
+func runeWidth(r rune) int {
+	switch {
+	case r == 0x200d || (r >= 0xfe00 && r <= 0xfe0f) || (r >= 0x300 && r <= 0x36f):
+		return 0
+	case r >= 0x1f000 || (r >= 0x2600 && r <= 0x27bf) || (r >= 0x2b00 && r <= 0x2bff) ||
+		(r >= 0x1100 && r <= 0x115f) || (r >= 0x2e80 && r <= 0xa4cf) || (r >= 0xac00 && r <= 0xd7a3) || (r >= 0xff00 && r <= 0xff60):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// truncate cuts the text to the given number of columns, ending it with … if it is longer
+func truncate(s string, w int) string {
+	if width(s) <= w {
+		return s
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		rw := runeWidth(r)
+		if used+rw > w-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += rw
+	}
+	return b.String() + "…"
+}
+
+// pad cuts or fills with spaces the text to exactly the given number of columns
+func pad(s string, w int) string {
+	s = truncate(s, w)
+	return s + strings.Repeat(" ", max(0, w-width(s)))
+}
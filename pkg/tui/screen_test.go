@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []key
+	}{
+		{name: "text", input: "aè😀", want: []key{{code: keyRune, r: 'a'}, {code: keyRune, r: 'è'}, {code: keyRune, r: '😀'}}},
+		{name: "esc alone", input: "\x1b", want: []key{{code: keyEsc}}},
+		{name: "arrows", input: "\x1b[A\x1b[B\x1bOC\x1b[D", want: []key{{code: keyUp}, {code: keyDown}, {code: keyRight}, {code: keyLeft}}},
+		{name: "enter", input: "\r\n", want: []key{{code: keyEnter}, {code: keyEnter}}},
+		{name: "backspace", input: "\x7f\x08", want: []key{{code: keyBackspace}, {code: keyBackspace}}},
+		{name: "control keys", input: "\t\x01\x03\x15\x0e\x10",
+			want: []key{{code: keyTab}, {code: keyCtrlA}, {code: keyCtrlC}, {code: keyCtrlU}, {code: keyDown}, {code: keyUp}}},
+		{name: "other control characters skipped", input: "\x02x", want: []key{{code: keyRune, r: 'x'}}},
+		{name: "sequence and text", input: "\x1b[5~q", want: []key{{code: keyPageUp}, {code: keyRune, r: 'q'}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.input)); !slices.Equal(got, tt.want) {
+				t.Errorf("parseKeys(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEscape(t *testing.T) {
+	tests := []struct {
+		input string
+		key   int
+		size  int
+	}{
+		{input: "\x1b[A", key: keyUp, size: 3},
+		{input: "\x1bOF", key: keyEnd, size: 3},
+		{input: "\x1b[H", key: keyHome, size: 3},
+		{input: "\x1b[1~", key: keyHome, size: 4},
+		{input: "\x1b[7~", key: keyHome, size: 4},
+		{input: "\x1b[4~", key: keyEnd, size: 4},
+		{input: "\x1b[8~", key: keyEnd, size: 4},
+		{input: "\x1b[6~", key: keyPageDown, size: 4},
+		{input: "\x1b[1;5C", key: keyRight, size: 6},
+		{input: "\x1b[3~", key: keyNone, size: 4},
+		{input: "\x1b[Z", key: keyNone, size: 3},
+		{input: "\x1b[12", key: keyNone, size: 4},
+		{input: "\x1bx", key: keyEsc, size: 1},
+		{input: "\x1bab", key: keyEsc, size: 1},
+	}
+	for _, tt := range tests {
+		k, size := parseEscape([]byte(tt.input))
+		if k != tt.key || size != tt.size {
+			t.Errorf("parseEscape(%q) = %d, %d, want %d, %d", tt.input, k, size, tt.key, tt.size)
+		}
+	}
+}
+
+func TestIncompleteRune(t *testing.T) {
+	full := []byte("a😀")
+	tests := []struct {
+		input []byte
+		want  bool
+	}{
+		{input: nil, want: false},
+		{input: full, want: false},
+		{input: full[:len(full)-1], want: true},
+		{input: full[:2], want: true},
+		{input: []byte("\x1b[A"), want: false},
+		{input: []byte{0xff}, want: false},
+	}
+	for _, tt := range tests {
+		if got := incompleteRune(tt.input); got != tt.want {
+			t.Errorf("incompleteRune(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{text: "", want: 0},
+		{text: "abc", want: 3},
+		{text: "città", want: 5},
+		{text: "🎵 Rock", want: 7},
+		{text: "❤️", want: 2}, // The variation selector takes no columns
+		{text: "👍🏽", want: 4}, // The skin tone is a second emoji
+		{text: "é", want: 1}, // Combining accent
+		{text: "한국", want: 4},
+	}
+	for _, tt := range tests {
+		if got := width(tt.text); got != tt.want {
+			t.Errorf("width(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{text: "Rock", width: 10, want: "Rock"},
+		{text: "Rock", width: 4, want: "Rock"},
+		{text: "Rock anni 80", width: 6, want: "Rock …"},
+		{text: "🎵🎵🎵", width: 4, want: "🎵…"},
+		{text: "🎵🎵🎵", width: 5, want: "🎵🎵…"},
+		{text: "abc", width: 1, want: "…"},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.text, tt.width)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+		if width(got) > tt.width {
+			t.Errorf("truncate(%q, %d) is %d columns wide", tt.text, tt.width, width(got))
+		}
+	}
+	if got := pad("ab", 4); got != "ab  " {
+		t.Errorf("pad = %q, want %q", got, "ab  ")
+	}
+}
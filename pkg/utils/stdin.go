@@ -0,0 +1,12 @@
+package utils
+
+import (
+	"bufio"
+	"os"
+)
+
+/*
+Stdin is the only reader of the standard input, used both by the prompts and by the keys of the full-screen interface:
+another reader would lose what this one has already buffered (for example text pasted in the terminal) or read it in the wrong prompt
+*/
+var Stdin = bufio.NewReader(os.Stdin)
@@ -2,13 +2,15 @@
 package utils
 
 import (
+	"fmt"
 	"log/slog"
 	"math/rand"
 	"os"
-	"os/exec"
-	"runtime"
 	"strconv"
 	"strings"
+	"unicode"
+
+	"golang.org/x/term"
 )
 
 // RandomString generates a random string of length n made of letters (lower and uppercase) and numbers
@@ -42,6 +44,82 @@ func Lower(s string) string {
 	return strings.ToLower(s)
 }
 
+// SafeFileName replaces the characters that are not allowed in file names (on linux and windows) with an underscore
+func SafeFileName(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) || r < 32 {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+/*
+Normalise prepares a title or a name to be compared: lowercase, without the parts in brackets (feat., remastered, ...),
+the suffixes after " - " (for example "- Remastered 2011") and the punctuation
+*/
+func Normalise(s string) string {
+	s = Lower(s)
+	for _, brackets := range []string{"()", "[]"} {
+		for {
+			start := strings.IndexByte(s, brackets[0])
+			end := strings.IndexByte(s, brackets[1])
+			if start < 0 || end < start {
+				break
+			}
+			s = s[:start] + s[end+1:]
+		}
+	}
+	if before, _, ok := strings.Cut(s, " - "); ok && strings.TrimSpace(before) != "" {
+		s = before
+	}
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return r
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// FormatDuration formats a duration in milliseconds as m:ss, or h:mm:ss if it is at least one hour
+func FormatDuration(ms int) string {
+	s := ms / 1000
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// Similarity returns how similar two strings are, from 0 to 1, based on the Levenshtein distance
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(max(len(ra), len(rb)))
+}
+
 // LevelStringToSlog converts a log level string to slog.Level(int)
 func LevelStringToSlog(level string) slog.Level {
 	switch Lower(level) {
@@ -60,27 +138,14 @@ func LevelStringToSlog(level string) slog.Level {
 	}
 }
 
-// ClearTerminal clears the terminal screen based on the O.S. (linux and windows implemented)
-var clear = make(map[string]func()) //create a map for storing clear funcs
-
-func init() {
-	clear["linux"] = func() {
-		cmd := exec.Command("clear")
-		cmd.Stdout = os.Stdout
-		cmd.Run()
-	}
-	clear["windows"] = func() {
-		cmd := exec.Command("cmd", "/c", "cls")
-		cmd.Stdout = os.Stdout
-		cmd.Run()
-	}
-}
+// clearScreen moves the cursor to the top left corner and clears the screen, as the full-screen interface does (see pkg/tui)
+const clearScreen = "\x1b[H\x1b[2J"
 
+// ClearTerminal clears the terminal screen with the escape sequences, without running a command; the output that is not a terminal is left as it is
 func ClearTerminal() {
-	value, ok := clear[runtime.GOOS] //runtime.GOOS returns linux, windows, etc.
-	if ok {                          //If the O.S. is supported
-		value()
-	} else { //unsupported platform
-		slog.Error("Sistema operativo non supportato. Non posso pulire il terminale.")
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return
 	}
+	EnableVT()
+	os.Stdout.WriteString(clearScreen)
 }
@@ -0,0 +1,6 @@
+//go:build !windows
+
+package utils
+
+// EnableVT does nothing: the terminals of the other systems always support the escape sequences
+func EnableVT() {}
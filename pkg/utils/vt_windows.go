@@ -0,0 +1,18 @@
+//go:build windows
+
+package utils
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// EnableVT enables the escape sequences in the Windows console, used to draw the interface and to clear the screen
+func EnableVT() {
+	handle := windows.Handle(os.Stdout.Fd())
+	var mode uint32
+	if windows.GetConsoleMode(handle, &mode) == nil {
+		windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+	}
+}